@@ -2,235 +2,759 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
-	"fmt"
-	"log"
+	"log/slog"
+	"math"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
-	"slices"
-	"sync"
 	"syscall"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
-	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/Igorezka/rocket-factory/inventory/internal/config"
+	"github.com/Igorezka/rocket-factory/inventory/internal/metrics"
+	"github.com/Igorezka/rocket-factory/inventory/internal/search"
+	"github.com/Igorezka/rocket-factory/inventory/internal/storage"
+	"github.com/Igorezka/rocket-factory/inventory/internal/watch"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	sharedMetrics "github.com/Igorezka/rocket-factory/shared/pkg/metrics"
+	"github.com/Igorezka/rocket-factory/shared/pkg/money"
 	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+	"github.com/Igorezka/rocket-factory/shared/pkg/tracing"
 )
 
-const grpcPort = 50051
+const (
+	// reservationSweepInterval период проверки просроченных резервов
+	reservationSweepInterval = 30 * time.Second
 
-var (
-	ErrPartNotFound  = errors.New("part not found")
-	ErrPartsNotFound = errors.New("parts not found")
+	// Размер страницы списка деталей по умолчанию и максимальный
+	defaultPartsPageSize = 50
+	maxPartsPageSize     = 1000
+
+	// partEventsRetention время хранения событий изменения деталей для переподключения подписчиков
+	partEventsRetention = 15 * time.Minute
+	// maxPartEvents максимальное количество хранимых событий изменения деталей
+	maxPartEvents = 10000
+
+	// Количество результатов поиска по умолчанию и максимальное
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
 )
 
-type FilterFunc func(part *inventoryV1.Part) bool
+// InventoryService реализует gRPC сервис для работы с деталями
+type InventoryService struct {
+	inventoryV1.UnimplementedInventoryServiceServer
+	storage storage.InventoryStorage
+	index   *search.Index
+	events  *watch.Hub
+	metrics *metrics.Metrics
+	// reservationTTL время, в течение которого резерв ожидает оплаты заказа
+	reservationTTL time.Duration
+}
 
-type InventoryStorage interface {
-	Part(partUuid string) (*inventoryV1.Part, error)
-	Parts(filter *inventoryV1.PartsFilter) ([]*inventoryV1.Part, error)
+func NewInventoryService(
+	inventoryStorage storage.InventoryStorage,
+	index *search.Index,
+	events *watch.Hub,
+	inventoryMetrics *metrics.Metrics,
+	reservationTTL time.Duration,
+) *InventoryService {
+	return &InventoryService{
+		storage:        inventoryStorage,
+		index:          index,
+		events:         events,
+		metrics:        inventoryMetrics,
+		reservationTTL: reservationTTL,
+	}
 }
 
-// InventoryStorageInMem представляет потокобезопасное хранилище данных о деталях
-type InventoryStorageInMem struct {
-	mu    sync.RWMutex
-	parts map[string]*inventoryV1.Part
+// GetPart возвращает деталь по UUID
+func (s *InventoryService) GetPart(ctx context.Context, req *inventoryV1.GetPartRequest) (*inventoryV1.GetPartResponse, error) {
+	part, err := s.storage.Part(ctx, req.GetUuid())
+	if err != nil {
+		if errors.Is(err, storage.ErrPartNotFound) {
+			return nil, status.Errorf(codes.NotFound, "part with UUID %s not found", req.GetUuid())
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &inventoryV1.GetPartResponse{
+		Part: part,
+	}, nil
 }
 
-// Part возвращает деталь по uuid
-func (s *InventoryStorageInMem) Part(partUuid string) (*inventoryV1.Part, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// ListParts возвращает список деталей соответствующих переданным фильтрам
+// или возвращает все детали если фильтры не переданы
+func (s *InventoryService) ListParts(ctx context.Context, req *inventoryV1.ListPartsRequest) (*inventoryV1.ListPartsResponse, error) {
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		return nil, status.Error(codes.InvalidArgument, "page size must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPartsPageSize
+	case pageSize > maxPartsPageSize:
+		pageSize = maxPartsPageSize
+	}
+
+	query := storage.PartsQuery{
+		Filter:     req.GetFilter(),
+		OrderBy:    req.GetOrderBy(),
+		Descending: req.GetDescending(),
+		// Запрашиваем на одну деталь больше, чтобы узнать, есть ли следующая страница
+		Limit: pageSize + 1,
+	}
+
+	if req.GetPageToken() != "" {
+		cursor, err := decodePartsPageToken(req.GetPageToken(), query)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		query.After = cursor
+	}
+
+	start := time.Now()
+	page, err := s.storage.Parts(ctx, query)
+	s.metrics.RecordFilter(ctx, time.Since(start))
+	if err != nil {
+		if errors.Is(err, storage.ErrPartsNotFound) {
+			return nil, status.Error(codes.NotFound, "no parts found")
+		}
+		if errors.Is(err, storage.ErrInvalidFilter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	res := &inventoryV1.ListPartsResponse{
+		Parts:     page.Parts,
+		TotalSize: int32(min(page.TotalSize, math.MaxInt32)), //nolint:gosec // значение ограничено сверху
+	}
+
+	if len(page.Parts) > pageSize {
+		res.Parts = page.Parts[:pageSize]
 
-	part, ok := s.parts[partUuid]
-	if !ok {
-		return nil, ErrPartNotFound
+		res.NextPageToken, err = encodePartsPageToken(query, res.Parts[pageSize-1])
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to encode page token", logger.Err(err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
 	}
 
-	return part, nil
+	return res, nil
 }
 
-func NewFilter(filter *inventoryV1.PartsFilter) []FilterFunc {
-	return []FilterFunc{
-		func(part *inventoryV1.Part) bool {
-			if len(filter.GetUuids()) == 0 {
-				return true
-			}
-			return slices.Contains(filter.GetUuids(), part.Uuid)
-		},
-		func(part *inventoryV1.Part) bool {
-			if len(filter.GetNames()) == 0 {
-				return true
-			}
-			return slices.Contains(filter.GetNames(), part.Name)
-		},
-		func(part *inventoryV1.Part) bool {
-			if len(filter.GetCategories()) == 0 {
-				return true
-			}
-			return slices.Contains(filter.GetCategories(), part.Category)
-		},
-		func(part *inventoryV1.Part) bool {
-			if len(filter.GetManufacturerCountries()) == 0 {
-				return true
-			}
-			return slices.Contains(filter.GetManufacturerCountries(), part.Manufacturer.Country)
-		},
-		func(part *inventoryV1.Part) bool {
-			if len(filter.GetTags()) == 0 {
-				return true
-			}
+// ReserveParts резервирует детали под заказ, одинаковые детали в запросе объединяются в одну позицию
+func (s *InventoryService) ReserveParts(ctx context.Context, req *inventoryV1.ReservePartsRequest) (*inventoryV1.ReservePartsResponse, error) {
+	if req.GetOrderUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order uuid is required")
+	}
+	if len(req.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items are required")
+	}
 
-			if len(part.Tags) == 0 {
-				return false
-			}
-			for _, tag := range filter.GetTags() {
-				if slices.Contains(part.Tags, tag) {
-					return true
-				}
-			}
-			return false
-		},
+	items := make([]*inventoryV1.ReservationItem, 0, len(req.GetItems()))
+	byPart := make(map[string]*inventoryV1.ReservationItem, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		if item.GetQuantity() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "quantity of part %s must be positive", item.GetPartUuid())
+		}
+
+		if existing, ok := byPart[item.GetPartUuid()]; ok {
+			existing.Quantity += item.GetQuantity()
+			continue
+		}
+
+		merged := &inventoryV1.ReservationItem{
+			PartUuid: item.GetPartUuid(),
+			Quantity: item.GetQuantity(),
+		}
+		byPart[item.GetPartUuid()] = merged
+		items = append(items, merged)
 	}
+
+	reservation, err := s.storage.ReserveParts(ctx, req.GetOrderUuid(), items, s.reservationTTL)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrPartNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, storage.ErrInsufficientStock):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, storage.ErrReservationAlreadyExists):
+			return nil, status.Errorf(codes.AlreadyExists, "reservation for order %s already exists", req.GetOrderUuid())
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &inventoryV1.ReservePartsResponse{
+		Reservation: reservation,
+	}, nil
 }
 
-// Parts возвращает детали отфильтрованные в соответствии с переданным фильтром
-func (s *InventoryStorageInMem) Parts(filter *inventoryV1.PartsFilter) ([]*inventoryV1.Part, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// CommitReservation подтверждает резерв деталей после оплаты заказа
+func (s *InventoryService) CommitReservation(ctx context.Context, req *inventoryV1.CommitReservationRequest) (*inventoryV1.CommitReservationResponse, error) {
+	reservation, err := s.storage.CommitReservation(ctx, req.GetOrderUuid())
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrReservationNotFound):
+			return nil, status.Errorf(codes.NotFound, "reservation for order %s not found", req.GetOrderUuid())
+		case errors.Is(err, storage.ErrReservationNotActive):
+			return nil, status.Errorf(codes.FailedPrecondition, "reservation for order %s was released", req.GetOrderUuid())
+		}
 
-	// Создаем список фильтров
-	filters := NewFilter(filter)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
 
-	filteredParts := make([]*inventoryV1.Part, 0)
-	for _, part := range s.parts {
-		match := false
-		for _, f := range filters {
-			if !f(part) {
-				match = false
-				continue
-			}
-			match = true
+	return &inventoryV1.CommitReservationResponse{
+		Reservation: reservation,
+	}, nil
+}
+
+// ReleaseReservation снимает резерв деталей и возвращает их на склад
+func (s *InventoryService) ReleaseReservation(ctx context.Context, req *inventoryV1.ReleaseReservationRequest) (*inventoryV1.ReleaseReservationResponse, error) {
+	reservation, err := s.storage.ReleaseReservation(ctx, req.GetOrderUuid())
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrReservationNotFound):
+			return nil, status.Errorf(codes.NotFound, "reservation for order %s not found", req.GetOrderUuid())
+		case errors.Is(err, storage.ErrReservationCommitted):
+			return nil, status.Errorf(codes.FailedPrecondition, "reservation for order %s already committed", req.GetOrderUuid())
 		}
-		if match {
-			filteredParts = append(filteredParts, part)
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &inventoryV1.ReleaseReservationResponse{
+		Reservation: reservation,
+	}, nil
+}
+
+// ReturnReservation возвращает на склад детали заказа, по которому оформлен возврат средств
+func (s *InventoryService) ReturnReservation(ctx context.Context, req *inventoryV1.ReturnReservationRequest) (*inventoryV1.ReturnReservationResponse, error) {
+	reservation, err := s.storage.ReturnReservation(ctx, req.GetOrderUuid())
+	if err != nil {
+		if errors.Is(err, storage.ErrReservationNotFound) {
+			return nil, status.Errorf(codes.NotFound, "reservation for order %s not found", req.GetOrderUuid())
 		}
+
+		return nil, status.Error(codes.Internal, "internal error")
 	}
 
-	if len(filteredParts) == 0 {
-		return nil, ErrPartsNotFound
+	return &inventoryV1.ReturnReservationResponse{
+		Reservation: reservation,
+	}, nil
+}
+
+// CreatePart добавляет новую деталь в каталог
+func (s *InventoryService) CreatePart(ctx context.Context, req *inventoryV1.CreatePartRequest) (*inventoryV1.CreatePartResponse, error) {
+	if req.GetPart() == nil {
+		return nil, status.Error(codes.InvalidArgument, "part is required")
 	}
 
-	return filteredParts, nil
+	part, err := s.storage.CreatePart(ctx, req.GetPart())
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidPart):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, storage.ErrPartAlreadyExists):
+			return nil, status.Errorf(codes.AlreadyExists, "part with UUID %s already exists", req.GetPart().GetUuid())
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &inventoryV1.CreatePartResponse{
+		Part: part,
+	}, nil
 }
 
-// InventoryService реализует gRPC сервис для работы с деталями
-type InventoryService struct {
-	inventoryV1.UnimplementedInventoryServiceServer
-	storage InventoryStorage
+// UpdatePart обновляет поля детали, перечисленные в маске
+func (s *InventoryService) UpdatePart(ctx context.Context, req *inventoryV1.UpdatePartRequest) (*inventoryV1.UpdatePartResponse, error) {
+	if req.GetPart() == nil {
+		return nil, status.Error(codes.InvalidArgument, "part is required")
+	}
+
+	part, err := s.storage.UpdatePart(ctx, req.GetPart().GetUuid(), req.GetPart(), req.GetUpdateMask().GetPaths())
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrPartNotFound):
+			return nil, status.Errorf(codes.NotFound, "part with UUID %s not found", req.GetPart().GetUuid())
+		case errors.Is(err, storage.ErrInvalidPart), errors.Is(err, storage.ErrInvalidUpdateMask):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &inventoryV1.UpdatePartResponse{
+		Part: part,
+	}, nil
 }
 
-func NewInventoryService(storage InventoryStorage) *InventoryService {
-	return &InventoryService{
-		storage: storage,
+// DeletePart удаляет деталь из каталога
+func (s *InventoryService) DeletePart(ctx context.Context, req *inventoryV1.DeletePartRequest) (*inventoryV1.DeletePartResponse, error) {
+	err := s.storage.DeletePart(ctx, req.GetUuid())
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrPartNotFound):
+			return nil, status.Errorf(codes.NotFound, "part with UUID %s not found", req.GetUuid())
+		case errors.Is(err, storage.ErrPartReserved):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		return nil, status.Error(codes.Internal, "internal error")
 	}
+
+	return &inventoryV1.DeletePartResponse{}, nil
 }
 
-// GetPart возвращает деталь по UUID
-func (s *InventoryService) GetPart(_ context.Context, req *inventoryV1.GetPartRequest) (*inventoryV1.GetPartResponse, error) {
-	part, err := s.storage.Part(req.GetUuid())
+// AdjustStock изменяет остаток детали на складе
+func (s *InventoryService) AdjustStock(ctx context.Context, req *inventoryV1.AdjustStockRequest) (*inventoryV1.AdjustStockResponse, error) {
+	if req.GetDelta() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "delta must not be zero")
+	}
+
+	part, err := s.storage.AdjustStock(ctx, req.GetUuid(), req.GetDelta())
 	if err != nil {
-		if errors.Is(err, ErrPartNotFound) {
+		switch {
+		case errors.Is(err, storage.ErrPartNotFound):
 			return nil, status.Errorf(codes.NotFound, "part with UUID %s not found", req.GetUuid())
+		case errors.Is(err, storage.ErrInsufficientStock):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, storage.ErrInvalidPart):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
 
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
-	return &inventoryV1.GetPartResponse{
+	return &inventoryV1.AdjustStockResponse{
 		Part: part,
 	}, nil
 }
 
-// ListParts возвращает список деталей соответствующих переданным фильтрам
-// или возвращает все детали если фильтры не переданы
-func (s *InventoryService) ListParts(_ context.Context, req *inventoryV1.ListPartsRequest) (*inventoryV1.ListPartsResponse, error) {
-	parts, err := s.storage.Parts(req.GetFilter())
+// SearchParts ищет детали по словам запроса в поисковом индексе, применяет к найденным деталям
+// опциональный фильтр и возвращает их по убыванию релевантности
+func (s *InventoryService) SearchParts(ctx context.Context, req *inventoryV1.SearchPartsRequest) (*inventoryV1.SearchPartsResponse, error) {
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0:
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	case limit == 0:
+		limit = defaultSearchLimit
+	case limit > maxSearchLimit:
+		limit = maxSearchLimit
+	}
+
+	if err := storage.ValidateFilter(req.GetFilter()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	filters := storage.NewFilter(req.GetFilter())
+
+	hits, err := s.index.Search(req.GetQuery())
 	if err != nil {
-		if errors.Is(err, ErrPartsNotFound) {
-			return nil, status.Error(codes.NotFound, "no parts found")
+		if errors.Is(err, search.ErrEmptyQuery) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
 
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
-	return &inventoryV1.ListPartsResponse{
-		Parts: parts,
-	}, nil
+	res := &inventoryV1.SearchPartsResponse{}
+	for _, hit := range hits {
+		if len(res.Results) == limit {
+			break
+		}
+
+		part, err := s.storage.Part(ctx, hit.PartUuid)
+		if err != nil {
+			// Деталь могла быть удалена между поиском и чтением
+			if errors.Is(err, storage.ErrPartNotFound) {
+				continue
+			}
+
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		if err = storage.ValidateMetadataTypes(req.GetFilter(), part); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if !storage.MatchFilters(part, filters) {
+			continue
+		}
+
+		result := &inventoryV1.SearchResult{
+			Part:  part,
+			Score: hit.Score,
+		}
+		for _, h := range hit.Highlights {
+			result.Highlights = append(result.Highlights, &inventoryV1.SearchHighlight{
+				Field:   h.Field,
+				Snippet: h.Snippet,
+			})
+		}
+		res.Results = append(res.Results, result)
+	}
+
+	return res, nil
+}
+
+// WatchParts передает подписчику события изменения деталей, подходящих под фильтр до или после изменения.
+// Поток завершается при отключении клиента, остановке сервера или если подписчик отстал от окна хранения событий
+func (s *InventoryService) WatchParts(req *inventoryV1.WatchPartsRequest, stream grpc.ServerStreamingServer[inventoryV1.PartEvent]) error {
+	if err := storage.ValidateFilter(req.GetFilter()); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	filters := storage.NewFilter(req.GetFilter())
+
+	cursor := s.events.Head()
+	if req.GetResumeToken() != "" {
+		var err error
+		cursor, err = s.events.ParseToken(req.GetResumeToken())
+		if err != nil {
+			return watchError(err)
+		}
+	}
+
+	for {
+		events, wake, err := s.events.Events(cursor)
+		if err != nil {
+			return watchError(err)
+		}
+
+		for _, event := range events {
+			cursor = event.Seq
+
+			if !storage.MatchFilters(event.Part, filters) &&
+				(event.Previous == nil || !storage.MatchFilters(event.Previous, filters)) {
+				continue
+			}
+
+			if err = stream.Send(partEventToProto(event, s.events.Token(event.Seq))); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-wake:
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-s.events.Done():
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+	}
 }
 
 func main() {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
+	logger.Init("inventory")
+
+	cfg, err := config.Load()
 	if err != nil {
-		log.Printf("failed to listen: %v", err)
+		slog.Error("invalid config", logger.Err(err))
+		return
+	}
+	logger.SetLevel(cfg.Log.SlogLevel())
+	slog.Info("effective config", slog.String("config", cfg.String()))
+
+	shutdownTracing, err := tracing.Init(context.Background(), "inventory", cfg.Tracing)
+	if err != nil {
+		slog.Error("failed to init tracing", logger.Err(err))
+		return
 	}
 	defer func() {
-		if cerr := lis.Close(); cerr != nil {
-			log.Printf("failed to close listener: %v\n", cerr)
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.GRPC.ShutdownTimeout)
+		defer cancel()
+
+		if serr := shutdownTracing(ctx); serr != nil {
+			slog.Error("failed to shut down tracing", logger.Err(serr))
 		}
 	}()
 
-	// Создаем хранилище и заполняем тестовые детали
-	storage := &InventoryStorageInMem{
-		parts: fillTestData(4),
+	metricsHandler, shutdownMetrics, err := sharedMetrics.Init(context.Background(), "inventory")
+	if err != nil {
+		slog.Error("failed to init metrics", logger.Err(err))
+		return
 	}
+	defer func() {
+		if serr := shutdownMetrics(context.Background()); serr != nil {
+			slog.Error("failed to shut down metrics", logger.Err(serr))
+		}
+	}()
+
+	inventoryMetrics, err := metrics.New()
+	if err != nil {
+		slog.Error("failed to create metrics", logger.Err(err))
+		return
+	}
+
+	creds, err := cfg.GRPC.TLS.GRPCCredentials()
+	if err != nil {
+		slog.Error("failed to load tls config", logger.Err(err))
+		return
+	}
+
+	lis, err := net.Listen("tcp", cfg.GRPC.Address)
+	if err != nil {
+		slog.Error("failed to listen", slog.String("address", cfg.GRPC.Address), logger.Err(err))
+		return
+	}
+	defer func() {
+		if cerr := lis.Close(); cerr != nil && !errors.Is(cerr, net.ErrClosed) {
+			slog.Error("failed to close listener", logger.Err(cerr))
+		}
+	}()
+
+	// Создаем хранилище и заполняем тестовые детали
+	inventoryStorage := storage.WithTracing(storage.NewInventoryStorageInMem(fillTestData(cfg.Seed.Parts, cfg.Seed.RandomSeed)))
+
+	// Запускаем снятие просроченных резервов
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go expireReservations(ctx, inventoryStorage)
+
+	// Строим поисковый индекс, дальше он обновляется при каждом изменении деталей
+	index := search.NewIndex()
+	inventoryStorage.Subscribe(index)
+
+	// Остаток по категориям для метрик считается так же по изменениям деталей
+	inventoryStorage.Subscribe(inventoryMetrics)
+
+	// Журнал изменений деталей для подписчиков WatchParts
+	events := watch.NewHub(inventoryStorage, partEventsRetention, maxPartEvents)
 
 	// Создаем gRPC сервер
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.UnaryInterceptor(logger.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(logger.StreamServerInterceptor()),
+	)
 
 	// Регистрируем сервис
-	service := NewInventoryService(storage)
+	service := NewInventoryService(inventoryStorage, index, events, inventoryMetrics, cfg.ReservationTTL)
 
 	inventoryV1.RegisterInventoryServiceServer(s, service)
 
+	// Регистрируем стандартную проверку здоровья, по ней order service проверяет готовность зависимостей
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(inventoryV1.InventoryService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+
 	// Включаем рефлексию для отладки
 	reflection.Register(s)
 
 	go func() {
-		log.Printf("🚀 gRPC server listening on %d\n", grpcPort)
+		slog.Info("grpc server listening", slog.String("address", lis.Addr().String()))
 		err = s.Serve(lis)
 		if err != nil {
-			log.Printf("failed to serve: %v\n", err)
+			slog.Error("failed to serve", logger.Err(err))
 			return
 		}
 	}()
 
+	// Запускаем сервер метрик
+	metricsServer := sharedMetrics.NewServer(cfg.Metrics.Address, metricsHandler)
+	go func() {
+		slog.Info("metrics server listening", slog.String("address", cfg.Metrics.Address))
+		if serr := metricsServer.ListenAndServe(); serr != nil && !errors.Is(serr, http.ErrServerClosed) {
+			slog.Error("failed to serve metrics", logger.Err(serr))
+		}
+	}()
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	log.Println("🛑 Shutting down gRPC server...")
-	s.GracefulStop()
-	log.Println("✅ Server stopped")
+	slog.Info("shutting down grpc server")
+	// Сообщаем клиентам, что сервер больше не принимает запросы
+	healthServer.Shutdown()
+	// Завершаем потоки WatchParts, иначе GracefulStop будет ждать их бесконечно
+	events.Close()
+	gracefulStop(s, cfg.GRPC.ShutdownTimeout)
+	if err = metricsServer.Close(); err != nil {
+		slog.Error("failed to close metrics server", logger.Err(err))
+	}
+	slog.Info("server stopped")
 }
 
-// fillTestData генерирует тестовые данные
-func fillTestData(count int) map[string]*inventoryV1.Part {
+// gracefulStop ожидает завершения обрабатываемых запросов не дольше timeout, после чего прерывает их
+func gracefulStop(s *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		slog.Warn("shutdown timeout exceeded, closing remaining connections")
+		s.Stop()
+	}
+}
+
+// watchError преобразует ошибку позиции подписки в gRPC статус
+func watchError(err error) error {
+	switch {
+	case errors.Is(err, watch.ErrResumeTokenExpired):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, watch.ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+// partEventToProto преобразует событие журнала в сообщение API
+func partEventToProto(event watch.Event, token string) *inventoryV1.PartEvent {
+	eventType := inventoryV1.PartEventType_PART_EVENT_TYPE_UNSPECIFIED
+	switch event.Kind {
+	case storage.PartCreated:
+		eventType = inventoryV1.PartEventType_PART_EVENT_TYPE_CREATED
+	case storage.PartUpdated:
+		eventType = inventoryV1.PartEventType_PART_EVENT_TYPE_UPDATED
+	case storage.PartDeleted:
+		eventType = inventoryV1.PartEventType_PART_EVENT_TYPE_DELETED
+	case storage.PartStockChanged:
+		eventType = inventoryV1.PartEventType_PART_EVENT_TYPE_STOCK_CHANGED
+	}
+
+	return &inventoryV1.PartEvent{
+		ResumeToken: token,
+		Type:        eventType,
+		Part:        event.Part,
+		OccurredAt:  timestamppb.New(event.OccurredAt),
+	}
+}
+
+// partsPageToken содержимое курсора страницы списка деталей. Вместе с позицией последней детали
+// сохраняются сортировка и отпечаток фильтра, чтобы курсор нельзя было применить к другому запросу
+type partsPageToken struct {
+	OrderBy       inventoryV1.PartsOrderBy `json:"order_by"`
+	Descending    bool                     `json:"desc"`
+	Filter        string                   `json:"filter"`
+	PriceMinor    int64                    `json:"price_minor"`
+	Name          string                   `json:"name"`
+	CreatedAt     time.Time                `json:"created_at"`
+	StockQuantity int64                    `json:"stock"`
+	Uuid          string                   `json:"uuid"`
+}
+
+// encodePartsPageToken создает курсор страницы, начинающейся после детали
+func encodePartsPageToken(query storage.PartsQuery, last *inventoryV1.Part) (string, error) {
+	fingerprint, err := filterFingerprint(query.Filter)
+	if err != nil {
+		return "", err
+	}
+
+	cursor := storage.CursorOf(last)
+	data, err := json.Marshal(partsPageToken{
+		OrderBy:       query.OrderBy,
+		Descending:    query.Descending,
+		Filter:        fingerprint,
+		PriceMinor:    cursor.PriceMinor,
+		Name:          cursor.Name,
+		CreatedAt:     cursor.CreatedAt,
+		StockQuantity: cursor.StockQuantity,
+		Uuid:          cursor.Uuid,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePartsPageToken разбирает курсор страницы и проверяет, что он выдан для того же запроса
+func decodePartsPageToken(token string, query storage.PartsQuery) (*storage.PartCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid page token")
+	}
+
+	var t partsPageToken
+	if err = json.Unmarshal(data, &t); err != nil || t.Uuid == "" {
+		return nil, errors.New("invalid page token")
+	}
+
+	fingerprint, err := filterFingerprint(query.Filter)
+	if err != nil {
+		return nil, errors.New("invalid filter")
+	}
+
+	if t.OrderBy != query.OrderBy || t.Descending != query.Descending || t.Filter != fingerprint {
+		return nil, errors.New("page token was issued for a different filter or sorting")
+	}
+
+	return &storage.PartCursor{
+		PriceMinor:    t.PriceMinor,
+		Name:          t.Name,
+		CreatedAt:     t.CreatedAt,
+		StockQuantity: t.StockQuantity,
+		Uuid:          t.Uuid,
+	}, nil
+}
+
+// filterFingerprint возвращает отпечаток фильтра деталей
+func filterFingerprint(filter *inventoryV1.PartsFilter) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(filter)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// expireReservations периодически снимает резервы, которые не были подтверждены оплатой вовремя
+func expireReservations(ctx context.Context, inventoryStorage storage.InventoryStorage) {
+	ticker := time.NewTicker(reservationSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if n := inventoryStorage.ExpireReservations(ctx, now); n > 0 {
+				slog.Info("released expired reservations", slog.Int("count", n))
+			}
+		}
+	}
+}
+
+// fillTestData генерирует тестовые данные, с одним seed генерируются одни и те же детали, 0 — случайный seed
+func fillTestData(count int, seed uint64) map[string]*inventoryV1.Part {
 	data := make(map[string]*inventoryV1.Part)
+	faker := gofakeit.New(seed)
 
 	for i := 0; i < count; i++ {
-		id := uuid.NewString()
+		id := faker.UUID()
+		now := timestamppb.New(time.Now())
 		// Сделал так потому что линтер при использовании inventoryV1.Category(gofakeit.IntRange(0, 4))
 		// выкидывает ошибку gosec G115 int <- int32
 		category := func() inventoryV1.Category {
-			c := gofakeit.IntRange(0, 4)
+			c := faker.IntRange(0, 4)
 
 			switch c {
 			case 1:
@@ -246,31 +770,36 @@ func fillTestData(count int) map[string]*inventoryV1.Part {
 			return inventoryV1.Category_CATEGORY_UNKNOWN_UNSPECIFIED
 		}()
 
+		priceMinor := int64(faker.IntRange(10000, 1000000))
+
 		part := &inventoryV1.Part{
 			Uuid:          id,
-			Name:          gofakeit.Name(),
-			Description:   gofakeit.Name(),
-			Price:         gofakeit.Float64(),
-			StockQuantity: gofakeit.Int64(),
+			Name:          faker.Name(),
+			Description:   faker.Name(),
+			Price:         money.FromMinor(priceMinor, money.DefaultCurrency),
+			PriceMinor:    priceMinor,
+			Currency:      money.DefaultCurrency,
+			StockQuantity: int64(faker.IntRange(0, 100)),
 			Category:      category,
 			Dimensions: &inventoryV1.Dimensions{
-				Length: gofakeit.Float64(),
-				Width:  gofakeit.Float64(),
-				Height: gofakeit.Float64(),
-				Weight: gofakeit.Float64(),
+				Length: faker.Float64Range(10, 500),
+				Width:  faker.Float64Range(10, 500),
+				Height: faker.Float64Range(10, 500),
+				Weight: faker.Float64Range(1, 1000),
 			},
 			Manufacturer: &inventoryV1.Manufacturer{
-				Name:    gofakeit.Company(),
-				Country: gofakeit.Country(),
-				Website: gofakeit.URL(),
+				Name:    faker.Company(),
+				Country: faker.Country(),
+				Website: faker.URL(),
 			},
-			Tags: []string{gofakeit.Name(), gofakeit.Company(), gofakeit.Country()},
+			Tags: []string{faker.Name(), faker.Company(), faker.Country()},
 			Metadata: map[string]*inventoryV1.Value{
 				"name": {
-					ValueType: &inventoryV1.Value_StringValue{StringValue: gofakeit.Name()},
+					ValueType: &inventoryV1.Value_StringValue{StringValue: faker.Name()},
 				},
 			},
-			CreatedAt: timestamppb.New(time.Now()),
+			CreatedAt: now,
+			UpdatedAt: now,
 		}
 		data[id] = part
 	}
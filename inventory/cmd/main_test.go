@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Igorezka/rocket-factory/inventory/internal/metrics"
+	"github.com/Igorezka/rocket-factory/inventory/internal/storage"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// newListService создает сервис с деталями a-e, детали a-c относятся к двигателям
+func newListService(t *testing.T) *InventoryService {
+	t.Helper()
+
+	m, err := metrics.New()
+	if err != nil {
+		t.Fatalf("metrics.New: %v", err)
+	}
+
+	parts := make(map[string]*inventoryV1.Part)
+	for i, partUuid := range []string{"a", "b", "c", "d", "e"} {
+		category := inventoryV1.Category_CATEGORY_ENGINE
+		if i >= 3 {
+			category = inventoryV1.Category_CATEGORY_WING
+		}
+		parts[partUuid] = &inventoryV1.Part{Uuid: partUuid, Name: partUuid, PriceMinor: 100, Currency: "RUB", Category: category}
+	}
+
+	return NewInventoryService(storage.NewInventoryStorageInMem(parts), nil, nil, m, 0)
+}
+
+func TestListPartsPages(t *testing.T) {
+	s := newListService(t)
+	req := &inventoryV1.ListPartsRequest{
+		PageSize: 2,
+		OrderBy:  inventoryV1.PartsOrderBy_PARTS_ORDER_BY_PRICE,
+	}
+
+	var got []string
+	for range 3 {
+		res, err := s.ListParts(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ListParts: %v", err)
+		}
+		for _, part := range res.GetParts() {
+			got = append(got, part.GetUuid())
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+
+	// Детали с одинаковой ценой упорядочены по uuid, на последней странице курсора нет
+	if want := "[a b c d e]"; fmt.Sprint(got) != want {
+		t.Fatalf("ListParts: got %v, want %v", got, want)
+	}
+	if req.GetPageToken() != "" {
+		t.Fatalf("ListParts: got next page token %q on the last page, want none", req.GetPageToken())
+	}
+}
+
+func TestListPartsPageTokenOfOtherQuery(t *testing.T) {
+	engines := &inventoryV1.PartsFilter{Categories: []inventoryV1.Category{inventoryV1.Category_CATEGORY_ENGINE}}
+
+	tests := []struct {
+		name     string
+		change   func(req *inventoryV1.ListPartsRequest)
+		wantCode codes.Code
+	}{
+		{name: "same query", change: func(*inventoryV1.ListPartsRequest) {}, wantCode: codes.OK},
+		{name: "page size may change", change: func(req *inventoryV1.ListPartsRequest) { req.PageSize = 10 }, wantCode: codes.OK},
+		{
+			name: "changed filter",
+			change: func(req *inventoryV1.ListPartsRequest) {
+				req.Filter = &inventoryV1.PartsFilter{Categories: []inventoryV1.Category{inventoryV1.Category_CATEGORY_WING}}
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{name: "removed filter", change: func(req *inventoryV1.ListPartsRequest) { req.Filter = nil }, wantCode: codes.InvalidArgument},
+		{
+			name:     "changed order",
+			change:   func(req *inventoryV1.ListPartsRequest) { req.OrderBy = inventoryV1.PartsOrderBy_PARTS_ORDER_BY_NAME },
+			wantCode: codes.InvalidArgument,
+		},
+		{name: "changed direction", change: func(req *inventoryV1.ListPartsRequest) { req.Descending = true }, wantCode: codes.InvalidArgument},
+		{name: "malformed token", change: func(req *inventoryV1.ListPartsRequest) { req.PageToken = "!" }, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newListService(t)
+			req := &inventoryV1.ListPartsRequest{
+				PageSize: 1,
+				Filter:   engines,
+				OrderBy:  inventoryV1.PartsOrderBy_PARTS_ORDER_BY_PRICE,
+			}
+
+			res, err := s.ListParts(context.Background(), req)
+			if err != nil {
+				t.Fatalf("ListParts: %v", err)
+			}
+
+			req.PageToken = res.GetNextPageToken()
+			tt.change(req)
+
+			if _, err = s.ListParts(context.Background(), req); status.Code(err) != tt.wantCode {
+				t.Fatalf("ListParts: got %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
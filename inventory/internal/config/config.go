@@ -0,0 +1,88 @@
+// Package config описывает конфигурацию inventory service
+package config
+
+import (
+	"errors"
+	"time"
+
+	sharedConfig "github.com/Igorezka/rocket-factory/shared/pkg/config"
+)
+
+// EnvPrefix префикс переменных окружения inventory service
+const EnvPrefix = "INVENTORY"
+
+// Config конфигурация inventory service
+type Config struct {
+	Log     sharedConfig.Log        `yaml:"log" env:"LOG"`
+	Tracing sharedConfig.Tracing    `yaml:"tracing" env:"TRACING"`
+	Metrics sharedConfig.Metrics    `yaml:"metrics" env:"METRICS"`
+	GRPC    sharedConfig.GRPCServer `yaml:"grpc" env:"GRPC"`
+	// ReservationTTL время, в течение которого резерв ожидает оплаты заказа
+	ReservationTTL time.Duration `yaml:"reservation_ttl" env:"RESERVATION_TTL"`
+	Seed           Seed          `yaml:"seed" env:"SEED"`
+}
+
+// Seed настройки тестовых деталей, которыми заполняется хранилище при старте
+type Seed struct {
+	// Parts количество тестовых деталей
+	Parts int `yaml:"parts" env:"PARTS"`
+	// RandomSeed начальное значение генератора тестовых деталей, с одним значением детали и их uuid
+	// совпадают между запусками, 0 — случайное значение
+	RandomSeed uint64 `yaml:"random_seed" env:"RANDOM_SEED"`
+}
+
+// Validate проверяет количество тестовых деталей
+func (s *Seed) Validate() error {
+	if s.Parts < 0 {
+		return errors.New("parts must not be negative")
+	}
+
+	return nil
+}
+
+// Default возвращает конфигурацию по умолчанию
+func Default() *Config {
+	return &Config{
+		Log: sharedConfig.Log{
+			Level: "info",
+		},
+		Tracing: sharedConfig.Tracing{
+			Exporter:    sharedConfig.TracingExporterNone,
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			File:        "inventory-traces.jsonl",
+			SampleRatio: 1,
+		},
+		Metrics: sharedConfig.Metrics{
+			Address: ":9091",
+		},
+		GRPC: sharedConfig.GRPCServer{
+			Address:         ":50051",
+			ShutdownTimeout: 10 * time.Second,
+		},
+		ReservationTTL: 15 * time.Minute,
+		Seed: Seed{
+			Parts: 4,
+		},
+	}
+}
+
+// Load загружает конфигурацию из YAML файла INVENTORY_CONFIG_FILE и переменных окружения INVENTORY_*
+func Load() (*Config, error) {
+	cfg := Default()
+	if err := sharedConfig.Load(EnvPrefix, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate проверяет время резерва
+func (c *Config) Validate() error {
+	return sharedConfig.Positive("reservation_ttl", c.ReservationTTL)
+}
+
+// String возвращает итоговую конфигурацию в формате YAML
+func (c *Config) String() string {
+	return sharedConfig.Format(EnvPrefix, c)
+}
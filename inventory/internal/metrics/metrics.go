@@ -0,0 +1,108 @@
+// Package metrics описывает бизнес-метрики inventory service: остаток деталей на складе по категориям
+// и длительность фильтрации деталей
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/Igorezka/rocket-factory/inventory/internal/storage"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// filterDurationBuckets границы гистограммы длительности фильтрации в миллисекундах:
+// фильтрация выполняется в памяти и обычно укладывается в доли миллисекунды
+var filterDurationBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 50, 100}
+
+// Metrics бизнес-метрики inventory service. Остаток по категориям считается по уведомлениям
+// хранилища, поэтому Metrics нужно подписать на изменения деталей
+type Metrics struct {
+	filterDuration metric.Float64Histogram
+
+	mu sync.Mutex
+	// parts категория и остаток каждой детали на складе
+	parts map[string]partStock
+	// stock суммарный остаток по категориям, категории без деталей остаются с нулевым остатком
+	stock map[inventoryV1.Category]int64
+}
+
+// partStock категория и остаток детали
+type partStock struct {
+	category inventoryV1.Category
+	quantity int64
+}
+
+// New создает метрики inventory service в провайдере метрик по умолчанию
+func New() (*Metrics, error) {
+	meter := otel.Meter("github.com/Igorezka/rocket-factory/inventory/internal/metrics")
+
+	m := &Metrics{
+		parts: make(map[string]partStock),
+		stock: make(map[inventoryV1.Category]int64),
+	}
+
+	var err error
+	m.filterDuration, err = meter.Float64Histogram("inventory.filter.duration",
+		metric.WithDescription("Duration of filtering and sorting parts for ListParts"),
+		metric.WithUnit("ms"),
+		metric.WithExplicitBucketBoundaries(filterDurationBuckets...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = meter.Int64ObservableGauge("inventory.stock",
+		metric.WithDescription("Parts in stock by category"),
+		metric.WithInt64Callback(m.observeStock),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RecordFilter записывает длительность выборки страницы деталей по фильтру запроса
+func (m *Metrics) RecordFilter(ctx context.Context, d time.Duration) {
+	m.filterDuration.Record(ctx, float64(d)/float64(time.Millisecond))
+}
+
+// PartChanged обновляет остаток категории детали
+func (m *Metrics) PartChanged(change storage.PartChange) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	uuid := change.Part.GetUuid()
+	if previous, ok := m.parts[uuid]; ok {
+		m.stock[previous.category] -= previous.quantity
+		delete(m.parts, uuid)
+	}
+
+	if change.Kind == storage.PartDeleted {
+		return
+	}
+
+	current := partStock{
+		category: change.Part.GetCategory(),
+		quantity: change.Part.GetStockQuantity(),
+	}
+	m.parts[uuid] = current
+	m.stock[current.category] += current.quantity
+}
+
+// observeStock отдает остаток по категориям при сборе метрик
+func (m *Metrics) observeStock(_ context.Context, observer metric.Int64Observer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for category, quantity := range m.stock {
+		observer.Observe(quantity, metric.WithAttributes(attribute.String("category", category.String())))
+	}
+
+	return nil
+}
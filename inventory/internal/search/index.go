@@ -0,0 +1,287 @@
+package search
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Igorezka/rocket-factory/inventory/internal/storage"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// Поля детали, по которым строится индекс
+const (
+	FieldName         = "name"
+	FieldDescription  = "description"
+	FieldManufacturer = "manufacturer"
+	FieldTags         = "tags"
+	// FieldMetadataPrefix префикс полей строковых значений метаданных, за ним следует ключ
+	FieldMetadataPrefix = "metadata."
+)
+
+const (
+	// minPrefixLength минимальная длина слова запроса, с которой оно ищется как начало слова
+	minPrefixLength = 2
+
+	// Веса совпадений слова запроса со словом индекса
+	exactWeight  = 1.0
+	prefixWeight = 0.6
+	fuzzyWeight  = 0.5
+)
+
+// fieldWeights веса полей, совпадение в названии важнее совпадения в описании
+var fieldWeights = map[string]float64{
+	FieldName:         3,
+	FieldTags:         2,
+	FieldManufacturer: 1.5,
+	FieldDescription:  1,
+}
+
+// fieldOrder порядок полей во фрагментах результата, поля метаданных идут следом по алфавиту
+var fieldOrder = []string{FieldName, FieldDescription, FieldManufacturer, FieldTags}
+
+// ErrEmptyQuery поисковая строка не содержит ни одного слова
+var ErrEmptyQuery = errors.New("search query is empty")
+
+// Highlight фрагмент поля детали с выделенными найденными словами
+type Highlight struct {
+	Field   string
+	Snippet string
+}
+
+// Hit найденная деталь
+type Hit struct {
+	PartUuid   string
+	Score      float64
+	Highlights []Highlight
+}
+
+// document проиндексированные тексты полей детали
+type document map[string]string
+
+// Index инвертированный индекс деталей в памяти. Реализует storage.PartListener
+// и обновляется по одной детали при каждом ее изменении в хранилище
+type Index struct {
+	mu sync.RWMutex
+	// postings слово -> UUID детали -> поле -> количество вхождений
+	postings map[string]map[string]map[string]int
+	docs     map[string]document
+}
+
+// NewIndex создает пустой индекс
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[string]map[string]int),
+		docs:     make(map[string]document),
+	}
+}
+
+// PartChanged переиндексирует измененную деталь или удаляет из индекса удаленную
+func (i *Index) PartChanged(change storage.PartChange) {
+	part := change.Part
+	if change.Kind == storage.PartDeleted {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+
+		i.remove(part.GetUuid())
+		return
+	}
+
+	doc := documentOf(part)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	// Остаток и цена в индекс не входят, поэтому изменение склада не требует переиндексации
+	if old, ok := i.docs[part.GetUuid()]; ok && maps.Equal(old, doc) {
+		return
+	}
+
+	i.remove(part.GetUuid())
+	i.add(part.GetUuid(), doc)
+}
+
+// Search ищет детали по словам запроса и возвращает их по убыванию релевантности.
+// Слово запроса совпадает со словом индекса точно, как начало слова или с опечаткой.
+// Детали, в которых найдены не все слова запроса, получают пропорционально меньшую оценку
+func (i *Index) Search(query string) ([]Hit, error) {
+	queryTerms := terms(query)
+	if len(queryTerms) == 0 {
+		return nil, ErrEmptyQuery
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	// scores UUID детали -> лучшая оценка каждого слова запроса
+	scores := make(map[string][]float64)
+	// matched UUID детали -> найденные слова индекса
+	matched := make(map[string]map[string]struct{})
+
+	total := float64(len(i.docs))
+	for qi, q := range queryTerms {
+		for term, weight := range i.expand(q) {
+			docs := i.postings[term]
+			idf := math.Log(1 + total/float64(len(docs)))
+
+			for partUuid, fields := range docs {
+				score := 0.0
+				for field, tf := range fields {
+					score += fieldWeight(field) * float64(tf) / float64(tf+1)
+				}
+				score *= weight * idf
+
+				if _, ok := scores[partUuid]; !ok {
+					scores[partUuid] = make([]float64, len(queryTerms))
+					matched[partUuid] = make(map[string]struct{})
+				}
+				scores[partUuid][qi] = max(scores[partUuid][qi], score)
+				matched[partUuid][term] = struct{}{}
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for partUuid, termScores := range scores {
+		sum, found := 0.0, 0
+		for _, score := range termScores {
+			if score > 0 {
+				sum += score
+				found++
+			}
+		}
+
+		hits = append(hits, Hit{
+			PartUuid:   partUuid,
+			Score:      sum * float64(found) / float64(len(queryTerms)),
+			Highlights: highlights(i.docs[partUuid], matched[partUuid]),
+		})
+	}
+
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return hits[a].PartUuid < hits[b].PartUuid
+	})
+
+	return hits, nil
+}
+
+// expand возвращает слова индекса, подходящие под слово запроса, с весом совпадения
+func (i *Index) expand(query string) map[string]float64 {
+	result := make(map[string]float64)
+	if _, ok := i.postings[query]; ok {
+		result[query] = exactWeight
+	}
+
+	for term := range i.postings {
+		switch {
+		case term == query:
+		case hasPrefix(term, query):
+			result[term] = prefixWeight
+		case fuzzyMatch(query, term):
+			result[term] = fuzzyWeight
+		}
+	}
+
+	return result
+}
+
+// add добавляет документ в индекс, вызывается под блокировкой на запись
+func (i *Index) add(partUuid string, doc document) {
+	i.docs[partUuid] = doc
+
+	for field, text := range doc {
+		for _, t := range tokenize(text) {
+			docs, ok := i.postings[t.term]
+			if !ok {
+				docs = make(map[string]map[string]int)
+				i.postings[t.term] = docs
+			}
+			fields, ok := docs[partUuid]
+			if !ok {
+				fields = make(map[string]int)
+				docs[partUuid] = fields
+			}
+			fields[field]++
+		}
+	}
+}
+
+// remove удаляет документ из индекса, вызывается под блокировкой на запись
+func (i *Index) remove(partUuid string) {
+	doc, ok := i.docs[partUuid]
+	if !ok {
+		return
+	}
+	delete(i.docs, partUuid)
+
+	for _, text := range doc {
+		for _, t := range tokenize(text) {
+			docs, ok := i.postings[t.term]
+			if !ok {
+				continue
+			}
+			delete(docs, partUuid)
+			if len(docs) == 0 {
+				delete(i.postings, t.term)
+			}
+		}
+	}
+}
+
+// documentOf собирает индексируемые тексты полей детали
+func documentOf(part *inventoryV1.Part) document {
+	doc := document{
+		FieldName:        part.GetName(),
+		FieldDescription: part.GetDescription(),
+		FieldTags:        strings.Join(part.GetTags(), ", "),
+	}
+
+	if m := part.GetManufacturer(); m != nil {
+		doc[FieldManufacturer] = strings.Join([]string{m.GetName(), m.GetCountry()}, ", ")
+	}
+
+	for key, value := range part.GetMetadata() {
+		if v, ok := value.GetValueType().(*inventoryV1.Value_StringValue); ok {
+			doc[FieldMetadataPrefix+key] = v.StringValue
+		}
+	}
+
+	return doc
+}
+
+// fieldWeight возвращает вес поля, все поля метаданных имеют вес описания
+func fieldWeight(field string) float64 {
+	if weight, ok := fieldWeights[field]; ok {
+		return weight
+	}
+
+	return fieldWeights[FieldDescription]
+}
+
+// highlights собирает фрагменты полей документа, в которых найдены слова
+func highlights(doc document, matched map[string]struct{}) []Highlight {
+	fields := slices.Clone(fieldOrder)
+	start := len(fields)
+	for field := range doc {
+		if strings.HasPrefix(field, FieldMetadataPrefix) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields[start:])
+
+	var result []Highlight
+	for _, field := range fields {
+		if text, ok := snippet(doc[field], matched); ok {
+			result = append(result, Highlight{Field: field, Snippet: text})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,179 @@
+package search
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Igorezka/rocket-factory/inventory/internal/storage"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// newTestIndex создает индекс с переданными деталями
+func newTestIndex(parts ...*inventoryV1.Part) *Index {
+	index := NewIndex()
+	for _, part := range parts {
+		index.PartChanged(storage.PartChange{Kind: storage.PartCreated, Part: part})
+	}
+	return index
+}
+
+// hitUuids возвращает uuid найденных деталей в порядке выдачи
+func hitUuids(hits []Hit) []string {
+	uuids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		uuids = append(uuids, hit.PartUuid)
+	}
+	return uuids
+}
+
+// search выполняет поиск и возвращает uuid найденных деталей
+func search(t *testing.T, index *Index, query string) []string {
+	t.Helper()
+
+	hits, err := index.Search(query)
+	if err != nil {
+		t.Fatalf("Search(%q): %v", query, err)
+	}
+	return hitUuids(hits)
+}
+
+func TestSearchRanking(t *testing.T) {
+	index := newTestIndex(
+		&inventoryV1.Part{Uuid: "name", Name: "Ion engine"},
+		&inventoryV1.Part{Uuid: "description", Name: "Backup unit", Description: "Spare part for the ion engine"},
+		&inventoryV1.Part{Uuid: "tags", Name: "Thruster", Tags: []string{"ion"}},
+		&inventoryV1.Part{Uuid: "other", Name: "Porthole", Description: "Round window"},
+	)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "name outranks tags and description", query: "ion", want: []string{"name", "tags", "description"}},
+		{name: "all words outrank some of them", query: "ion engine", want: []string{"name", "description", "tags"}},
+		{name: "prefix", query: "por", want: []string{"other"}},
+		{name: "typo", query: "enginr", want: []string{"name", "description"}},
+		{name: "case insensitive", query: "ROUND", want: []string{"other"}},
+		{name: "short word is not a prefix", query: "p", want: []string{}},
+		{name: "nothing found", query: "wing", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := search(t, index, tt.query); !slices.Equal(got, tt.want) {
+				t.Fatalf("Search(%q): got %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchExactOutranksPrefixAndTypo(t *testing.T) {
+	index := newTestIndex(
+		&inventoryV1.Part{Uuid: "prefix", Name: "Engines"},
+		&inventoryV1.Part{Uuid: "exact", Name: "Engine"},
+		&inventoryV1.Part{Uuid: "typo", Name: "Enfine"},
+	)
+
+	if got, want := search(t, index, "engine"), []string{"exact", "prefix", "typo"}; !slices.Equal(got, want) {
+		t.Fatalf("Search: got %v, want %v", got, want)
+	}
+}
+
+func TestSearchEmptyQuery(t *testing.T) {
+	if _, err := NewIndex().Search(" ,.- "); !errors.Is(err, ErrEmptyQuery) {
+		t.Fatalf("Search: got %v, want %v", err, ErrEmptyQuery)
+	}
+}
+
+func TestSearchTiesAreOrderedByUuid(t *testing.T) {
+	index := newTestIndex(
+		&inventoryV1.Part{Uuid: "c", Name: "Fuel tank"},
+		&inventoryV1.Part{Uuid: "a", Name: "Fuel tank"},
+		&inventoryV1.Part{Uuid: "b", Name: "Fuel tank"},
+	)
+
+	// Порядок обхода map случаен, поэтому поиск повторяется несколько раз
+	for range 20 {
+		hits, err := index.Search("fuel")
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if got, want := hitUuids(hits), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+			t.Fatalf("Search: got %v, want %v", got, want)
+		}
+		if hits[0].Score != hits[2].Score {
+			t.Fatalf("Search: got scores %v and %v, want equal", hits[0].Score, hits[2].Score)
+		}
+	}
+}
+
+func TestPartChangedReindexes(t *testing.T) {
+	part := &inventoryV1.Part{Uuid: "a", Name: "Ion engine", StockQuantity: 5}
+	index := newTestIndex(part, &inventoryV1.Part{Uuid: "b", Name: "Ion wing"})
+
+	renamed := &inventoryV1.Part{Uuid: "a", Name: "Plasma thruster", StockQuantity: 5}
+	index.PartChanged(storage.PartChange{Kind: storage.PartUpdated, Part: renamed, Previous: part})
+
+	if got, want := search(t, index, "ion"), []string{"b"}; !slices.Equal(got, want) {
+		t.Fatalf("Search old name: got %v, want %v", got, want)
+	}
+	if got := search(t, index, "engine"); len(got) != 0 {
+		t.Fatalf("Search old word: got %v, want none", got)
+	}
+	if got, want := search(t, index, "plasma"), []string{"a"}; !slices.Equal(got, want) {
+		t.Fatalf("Search new name: got %v, want %v", got, want)
+	}
+
+	// Изменение остатка не меняет индексируемые поля
+	restocked := &inventoryV1.Part{Uuid: "a", Name: "Plasma thruster", StockQuantity: 1}
+	index.PartChanged(storage.PartChange{Kind: storage.PartStockChanged, Part: restocked, Previous: renamed})
+	if got, want := search(t, index, "plasma"), []string{"a"}; !slices.Equal(got, want) {
+		t.Fatalf("Search after stock change: got %v, want %v", got, want)
+	}
+
+	index.PartChanged(storage.PartChange{Kind: storage.PartDeleted, Part: restocked})
+	if got := search(t, index, "plasma"); len(got) != 0 {
+		t.Fatalf("Search deleted part: got %v, want none", got)
+	}
+
+	index.PartChanged(storage.PartChange{Kind: storage.PartDeleted, Part: &inventoryV1.Part{Uuid: "b"}})
+	if len(index.postings) != 0 || len(index.docs) != 0 {
+		t.Fatalf("PartChanged: index keeps %d terms and %d documents after all parts are deleted", len(index.postings), len(index.docs))
+	}
+}
+
+func TestSearchHighlights(t *testing.T) {
+	index := newTestIndex(&inventoryV1.Part{
+		Uuid:         "a",
+		Name:         "Ion engine",
+		Description:  "Engine for deep space",
+		Manufacturer: &inventoryV1.Manufacturer{Name: "Orbital", Country: "RU"},
+		Tags:         []string{"space", "engines"},
+		Metadata: map[string]*inventoryV1.Value{
+			"series": {ValueType: &inventoryV1.Value_StringValue{StringValue: "engine mk2"}},
+			"model":  {ValueType: &inventoryV1.Value_StringValue{StringValue: "engine x"}},
+			"thrust": {ValueType: &inventoryV1.Value_Int64Value{Int64Value: 100}},
+		},
+	})
+
+	hits, err := index.Search("engine")
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("Search: got %d hits, want 1", len(hits))
+	}
+
+	want := []Highlight{
+		{Field: FieldName, Snippet: "Ion <em>engine</em>"},
+		{Field: FieldDescription, Snippet: "<em>Engine</em> for deep space"},
+		{Field: FieldTags, Snippet: "space, <em>engines</em>"},
+		{Field: FieldMetadataPrefix + "model", Snippet: "<em>engine</em> x"},
+		{Field: FieldMetadataPrefix + "series", Snippet: "<em>engine</em> mk2"},
+	}
+	if got := hits[0].Highlights; !slices.Equal(got, want) {
+		t.Fatalf("Search highlights: got %v, want %v", got, want)
+	}
+}
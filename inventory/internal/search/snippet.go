@@ -0,0 +1,63 @@
+package search
+
+import (
+	"strings"
+)
+
+const (
+	// HighlightStart и HighlightEnd обрамляют найденные слова во фрагменте
+	HighlightStart = "<em>"
+	HighlightEnd   = "</em>"
+
+	// snippetLength максимальная длина фрагмента в рунах без учета разметки
+	snippetLength = 160
+	// snippetContext количество рун перед первым найденным словом, с которого начинается обрезанный фрагмент
+	snippetContext = 40
+	ellipsis       = "…"
+)
+
+// snippet возвращает фрагмент текста, в котором слова из matched выделены разметкой.
+// Длинный текст обрезается вокруг первого найденного слова
+func snippet(text string, matched map[string]struct{}) (string, bool) {
+	runes := []rune(text)
+
+	var spans []token
+	for _, t := range tokenize(text) {
+		if _, ok := matched[t.term]; ok {
+			spans = append(spans, t)
+		}
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(runes)
+	if len(runes) > snippetLength {
+		from = max(0, spans[0].start-snippetContext)
+		to = min(len(runes), from+snippetLength)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString(ellipsis)
+	}
+
+	pos := from
+	for _, span := range spans {
+		if span.start < pos || span.end > to {
+			continue
+		}
+		b.WriteString(string(runes[pos:span.start]))
+		b.WriteString(HighlightStart)
+		b.WriteString(string(runes[span.start:span.end]))
+		b.WriteString(HighlightEnd)
+		pos = span.end
+	}
+	b.WriteString(string(runes[pos:to]))
+
+	if to < len(runes) {
+		b.WriteString(ellipsis)
+	}
+
+	return b.String(), true
+}
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("a ", 50) + "engine" + strings.Repeat(" b", 100)
+
+	tests := []struct {
+		name    string
+		text    string
+		matched []string
+		want    string
+		wantOk  bool
+	}{
+		{name: "no matches", text: "Ion engine", matched: []string{"wing"}},
+		{name: "empty text", text: "", matched: []string{"engine"}},
+		{name: "single word", text: "Ion engine", matched: []string{"engine"}, want: "Ion <em>engine</em>", wantOk: true},
+		{
+			name:    "every occurrence",
+			text:    "Engine, spare engine; ion",
+			matched: []string{"engine", "ion"},
+			want:    "<em>Engine</em>, spare <em>engine</em>; <em>ion</em>",
+			wantOk:  true,
+		},
+		{name: "whole words only", text: "Engines", matched: []string{"engine"}},
+		{name: "cyrillic", text: "Двигатель РД-180", matched: []string{"рд"}, want: "Двигатель <em>РД</em>-180", wantOk: true},
+		{
+			name:    "long text is cut around first match",
+			text:    long,
+			matched: []string{"engine"},
+			want:    "…" + strings.Repeat("a ", 20) + "<em>engine</em>" + strings.Repeat(" b", 57) + "…",
+			wantOk:  true,
+		},
+		{
+			name:    "match at the start of long text",
+			text:    "engine" + strings.Repeat(" b", 100),
+			matched: []string{"engine"},
+			want:    "<em>engine</em>" + strings.Repeat(" b", 77) + "…",
+			wantOk:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched := make(map[string]struct{})
+			for _, term := range tt.matched {
+				matched[term] = struct{}{}
+			}
+
+			got, ok := snippet(tt.text, matched)
+			if ok != tt.wantOk {
+				t.Fatalf("snippet: got ok %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Fatalf("snippet: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		term  string
+		want  bool
+	}{
+		{query: "ion", term: "ian", want: false},
+		{query: "wing", term: "wign", want: false},
+		{query: "wing", term: "wink", want: true},
+		{query: "engine", term: "engin", want: true},
+		{query: "engine", term: "engi", want: false},
+		{query: "porthole", term: "prothole", want: true},
+		{query: "porthole", term: "prothle", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.query, tt.term); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q): got %v, want %v", tt.query, tt.term, got, tt.want)
+		}
+	}
+}
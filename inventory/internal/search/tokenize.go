@@ -0,0 +1,114 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// token слово текста и его положение в рунах
+type token struct {
+	term  string
+	start int
+	end   int
+}
+
+// tokenize разбивает текст на слова из букв и цифр и приводит их к нижнему регистру
+func tokenize(text string) []token {
+	var (
+		tokens []token
+		word   []rune
+		start  int
+	)
+
+	runes := []rune(text)
+	for i, r := range runes {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if len(word) == 0 {
+				start = i
+			}
+			word = append(word, unicode.ToLower(r))
+			continue
+		}
+
+		if len(word) > 0 {
+			tokens = append(tokens, token{term: string(word), start: start, end: i})
+			word = word[:0]
+		}
+	}
+
+	if len(word) > 0 {
+		tokens = append(tokens, token{term: string(word), start: start, end: len(runes)})
+	}
+
+	return tokens
+}
+
+// terms возвращает уникальные слова запроса в порядке их появления
+func terms(query string) []string {
+	var result []string
+	seen := make(map[string]struct{})
+	for _, t := range tokenize(query) {
+		if _, ok := seen[t.term]; ok {
+			continue
+		}
+		seen[t.term] = struct{}{}
+		result = append(result, t.term)
+	}
+
+	return result
+}
+
+// fuzzyMatch проверяет, что слово индекса отличается от слова запроса не более чем на допустимое
+// для его длины количество правок. Короткие слова должны совпадать точно
+func fuzzyMatch(query, term string) bool {
+	q, t := []rune(query), []rune(term)
+
+	maxEdits := 0
+	switch {
+	case len(q) >= 8:
+		maxEdits = 2
+	case len(q) >= 4:
+		maxEdits = 1
+	}
+
+	if maxEdits == 0 || abs(len(q)-len(t)) > maxEdits {
+		return false
+	}
+
+	return levenshtein(q, t) <= maxEdits
+}
+
+// levenshtein считает расстояние редактирования между словами
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// hasPrefix проверяет, что слово индекса начинается со слова запроса
+func hasPrefix(term, query string) bool {
+	return len(query) >= minPrefixLength && term != query && strings.HasPrefix(term, query)
+}
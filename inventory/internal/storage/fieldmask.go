@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// fullMaskPath путь маски, означающий обновление всех изменяемых полей
+const fullMaskPath = "*"
+
+// mutablePartFields поля детали, которые можно изменить через маску обновления
+var mutablePartFields = []string{
+	"name",
+	"description",
+	"price",
+	"price_minor",
+	"currency",
+	"category",
+	"dimensions",
+	"manufacturer",
+	"tags",
+	"metadata",
+}
+
+// applyPartMask копирует в dst значения полей src, перечисленных в paths.
+// Вложенные поля задаются через точку, например dimensions.weight
+func applyPartMask(dst, src *inventoryV1.Part, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("%w: update mask is empty", ErrInvalidUpdateMask)
+	}
+
+	for _, path := range paths {
+		if path == fullMaskPath {
+			return applyPartMask(dst, src, mutablePartFields)
+		}
+
+		fields := strings.Split(path, ".")
+		if !isMutablePartField(fields[0]) {
+			return fmt.Errorf("%w: field %q cannot be updated", ErrInvalidUpdateMask, path)
+		}
+
+		if err := copyField(dst.ProtoReflect(), src.ProtoReflect(), fields); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidUpdateMask, path, err)
+		}
+	}
+
+	return nil
+}
+
+// copyField копирует значение поля по пути из src в dst, неустановленное в src поле очищается в dst
+func copyField(dst, src protoreflect.Message, path []string) error {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		return fmt.Errorf("unknown field %q", path[0])
+	}
+
+	if len(path) == 1 {
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+		return nil
+	}
+
+	if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return fmt.Errorf("field %q has no subfields", path[0])
+	}
+
+	return copyField(dst.Mutable(fd).Message(), src.Get(fd).Message(), path[1:])
+}
+
+// isMutablePartField проверяет, что поле детали верхнего уровня можно изменить через маску
+func isMutablePartField(name string) bool {
+	return slices.Contains(mutablePartFields, name)
+}
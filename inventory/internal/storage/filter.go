@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+type FilterFunc func(part *inventoryV1.Part) bool
+
+// NewFilter создает условия фильтра, деталь подходит под фильтр, если выполняются все условия.
+// Незаданные поля фильтра условий не добавляют
+func NewFilter(filter *inventoryV1.PartsFilter) []FilterFunc {
+	var filters []FilterFunc
+	add := func(active bool, f FilterFunc) {
+		if active {
+			filters = append(filters, f)
+		}
+	}
+
+	add(len(filter.GetUuids()) > 0, func(part *inventoryV1.Part) bool {
+		return slices.Contains(filter.GetUuids(), part.GetUuid())
+	})
+	add(len(filter.GetNames()) > 0, func(part *inventoryV1.Part) bool {
+		return slices.Contains(filter.GetNames(), part.GetName())
+	})
+	add(len(filter.GetCategories()) > 0, func(part *inventoryV1.Part) bool {
+		return slices.Contains(filter.GetCategories(), part.GetCategory())
+	})
+	add(len(filter.GetManufacturerCountries()) > 0, func(part *inventoryV1.Part) bool {
+		return slices.Contains(filter.GetManufacturerCountries(), part.GetManufacturer().GetCountry())
+	})
+	add(len(filter.GetTags()) > 0, func(part *inventoryV1.Part) bool {
+		if filter.GetTagsMode() == inventoryV1.TagsMatchMode_TAGS_MATCH_MODE_ALL {
+			return containsAll(part.GetTags(), filter.GetTags())
+		}
+		return containsAny(part.GetTags(), filter.GetTags())
+	})
+
+	add(filter.GetPrice() != nil, func(part *inventoryV1.Part) bool {
+		return inDoubleRange(filter.GetPrice(), part.GetPrice())
+	})
+	add(filter.GetStockQuantity() != nil, func(part *inventoryV1.Part) bool {
+		return inInt64Range(filter.GetStockQuantity(), part.GetStockQuantity())
+	})
+	add(filter.GetLength() != nil, func(part *inventoryV1.Part) bool {
+		return inDoubleRange(filter.GetLength(), part.GetDimensions().GetLength())
+	})
+	add(filter.GetWidth() != nil, func(part *inventoryV1.Part) bool {
+		return inDoubleRange(filter.GetWidth(), part.GetDimensions().GetWidth())
+	})
+	add(filter.GetHeight() != nil, func(part *inventoryV1.Part) bool {
+		return inDoubleRange(filter.GetHeight(), part.GetDimensions().GetHeight())
+	})
+	add(filter.GetWeight() != nil, func(part *inventoryV1.Part) bool {
+		return inDoubleRange(filter.GetWeight(), part.GetDimensions().GetWeight())
+	})
+	add(filter.GetCreatedAt() != nil, func(part *inventoryV1.Part) bool {
+		createdAt := part.GetCreatedAt().AsTime()
+		r := filter.GetCreatedAt()
+		if r.GetFrom() != nil && createdAt.Before(r.GetFrom().AsTime()) {
+			return false
+		}
+		return r.GetTo() == nil || createdAt.Before(r.GetTo().AsTime())
+	})
+
+	add(len(filter.GetExcludeUuids()) > 0, func(part *inventoryV1.Part) bool {
+		return !slices.Contains(filter.GetExcludeUuids(), part.GetUuid())
+	})
+	add(len(filter.GetExcludeNames()) > 0, func(part *inventoryV1.Part) bool {
+		return !slices.Contains(filter.GetExcludeNames(), part.GetName())
+	})
+	add(len(filter.GetExcludeCategories()) > 0, func(part *inventoryV1.Part) bool {
+		return !slices.Contains(filter.GetExcludeCategories(), part.GetCategory())
+	})
+	add(len(filter.GetExcludeManufacturerCountries()) > 0, func(part *inventoryV1.Part) bool {
+		return !slices.Contains(filter.GetExcludeManufacturerCountries(), part.GetManufacturer().GetCountry())
+	})
+	add(len(filter.GetExcludeTags()) > 0, func(part *inventoryV1.Part) bool {
+		return !containsAny(part.GetTags(), filter.GetExcludeTags())
+	})
+
+	for _, p := range filter.GetMetadata() {
+		filters = append(filters, metadataFilter(p))
+	}
+
+	return filters
+}
+
+// ValidateFilter проверяет, что границы диапазонов фильтра не перепутаны,
+// а операции условий на метаданные применимы к типам их значений
+func ValidateFilter(filter *inventoryV1.PartsFilter) error {
+	doubleRanges := []struct {
+		name string
+		r    *inventoryV1.DoubleRange
+	}{
+		{"price", filter.GetPrice()},
+		{"length", filter.GetLength()},
+		{"width", filter.GetWidth()},
+		{"height", filter.GetHeight()},
+		{"weight", filter.GetWeight()},
+	}
+	for _, dr := range doubleRanges {
+		if dr.r != nil && dr.r.Min != nil && dr.r.Max != nil && dr.r.GetMin() > dr.r.GetMax() {
+			return fmt.Errorf("%w: %s min is greater than max", ErrInvalidFilter, dr.name)
+		}
+	}
+
+	stock := filter.GetStockQuantity()
+	if stock != nil && stock.Min != nil && stock.Max != nil && stock.GetMin() > stock.GetMax() {
+		return fmt.Errorf("%w: stock_quantity min is greater than max", ErrInvalidFilter)
+	}
+
+	from, to := filter.GetCreatedAt().GetFrom(), filter.GetCreatedAt().GetTo()
+	if (from != nil && !from.IsValid()) || (to != nil && !to.IsValid()) {
+		return fmt.Errorf("%w: created_at bounds must be valid timestamps", ErrInvalidFilter)
+	}
+	if from != nil && to != nil && !from.AsTime().Before(to.AsTime()) {
+		return fmt.Errorf("%w: created_at from must be before to", ErrInvalidFilter)
+	}
+
+	for _, p := range filter.GetMetadata() {
+		if err := validateMetadataPredicate(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MatchFilters проверяет, что деталь подходит под все условия фильтра
+func MatchFilters(part *inventoryV1.Part, filters []FilterFunc) bool {
+	for _, f := range filters {
+		if !f(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// inDoubleRange проверяет, что значение попадает в диапазон
+func inDoubleRange(r *inventoryV1.DoubleRange, v float64) bool {
+	if r.Min != nil && v < r.GetMin() {
+		return false
+	}
+	return r.Max == nil || v <= r.GetMax()
+}
+
+// inInt64Range проверяет, что значение попадает в диапазон
+func inInt64Range(r *inventoryV1.Int64Range, v int64) bool {
+	if r.Min != nil && v < r.GetMin() {
+		return false
+	}
+	return r.Max == nil || v <= r.GetMax()
+}
+
+// containsAny проверяет, что среди тегов детали есть хотя бы один из искомых
+func containsAny(tags, wanted []string) bool {
+	for _, tag := range wanted {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAll проверяет, что среди тегов детали есть все искомые
+func containsAll(tags, wanted []string) bool {
+	for _, tag := range wanted {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
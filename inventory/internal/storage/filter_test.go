@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+var createdAt = time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// testPart возвращает деталь, под которую подбираются фильтры в тестах
+func testPart() *inventoryV1.Part {
+	return &inventoryV1.Part{
+		Uuid:          "2f1c6a3e-8a4b-4d8e-9c1a-1b2c3d4e5f60",
+		Name:          "Main engine",
+		Price:         1500.5,
+		PriceMinor:    150050,
+		StockQuantity: 10,
+		Category:      inventoryV1.Category_CATEGORY_ENGINE,
+		Dimensions:    &inventoryV1.Dimensions{Length: 200, Width: 100, Height: 50, Weight: 300},
+		Manufacturer:  &inventoryV1.Manufacturer{Name: "Roscosmos", Country: "RU"},
+		Tags:          []string{"heavy", "liquid"},
+		CreatedAt:     timestamppb.New(createdAt),
+	}
+}
+
+func TestMatchFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *inventoryV1.PartsFilter
+		want   bool
+	}{
+		{name: "nil filter", filter: nil, want: true},
+		{name: "empty filter", filter: &inventoryV1.PartsFilter{}, want: true},
+		{
+			name:   "empty lists and tags mode add no conditions",
+			filter: &inventoryV1.PartsFilter{Uuids: []string{}, Tags: []string{}, TagsMode: inventoryV1.TagsMatchMode_TAGS_MATCH_MODE_ALL},
+			want:   true,
+		},
+		{name: "uuid", filter: &inventoryV1.PartsFilter{Uuids: []string{"other", testPart().GetUuid()}}, want: true},
+		{name: "other uuid", filter: &inventoryV1.PartsFilter{Uuids: []string{"other"}}, want: false},
+		{name: "name is exact", filter: &inventoryV1.PartsFilter{Names: []string{"main engine"}}, want: false},
+		{
+			name:   "category",
+			filter: &inventoryV1.PartsFilter{Categories: []inventoryV1.Category{inventoryV1.Category_CATEGORY_WING, inventoryV1.Category_CATEGORY_ENGINE}},
+			want:   true,
+		},
+		{name: "manufacturer country", filter: &inventoryV1.PartsFilter{ManufacturerCountries: []string{"US"}}, want: false},
+		{name: "any tag", filter: &inventoryV1.PartsFilter{Tags: []string{"light", "liquid"}}, want: true},
+		{
+			name:   "all tags",
+			filter: &inventoryV1.PartsFilter{Tags: []string{"heavy", "liquid"}, TagsMode: inventoryV1.TagsMatchMode_TAGS_MATCH_MODE_ALL},
+			want:   true,
+		},
+		{
+			name:   "all tags with missing one",
+			filter: &inventoryV1.PartsFilter{Tags: []string{"heavy", "solid"}, TagsMode: inventoryV1.TagsMatchMode_TAGS_MATCH_MODE_ALL},
+			want:   false,
+		},
+		{
+			name: "all fields match",
+			filter: &inventoryV1.PartsFilter{
+				Names:                 []string{"Main engine"},
+				Categories:            []inventoryV1.Category{inventoryV1.Category_CATEGORY_ENGINE},
+				ManufacturerCountries: []string{"RU"},
+				Tags:                  []string{"heavy"},
+				Price:                 &inventoryV1.DoubleRange{Min: proto.Float64(1000), Max: proto.Float64(2000)},
+				StockQuantity:         &inventoryV1.Int64Range{Min: proto.Int64(1)},
+			},
+			want: true,
+		},
+		{
+			name: "one of fields does not match",
+			filter: &inventoryV1.PartsFilter{
+				Names:                 []string{"Main engine"},
+				Categories:            []inventoryV1.Category{inventoryV1.Category_CATEGORY_ENGINE},
+				ManufacturerCountries: []string{"CN"},
+				Price:                 &inventoryV1.DoubleRange{Min: proto.Float64(1000)},
+			},
+			want: false,
+		},
+		{name: "price bounds are inclusive", filter: &inventoryV1.PartsFilter{Price: &inventoryV1.DoubleRange{Min: proto.Float64(1500.5), Max: proto.Float64(1500.5)}}, want: true},
+		{name: "price below min", filter: &inventoryV1.PartsFilter{Price: &inventoryV1.DoubleRange{Min: proto.Float64(1500.51)}}, want: false},
+		{name: "price above max", filter: &inventoryV1.PartsFilter{Price: &inventoryV1.DoubleRange{Max: proto.Float64(1500)}}, want: false},
+		{name: "empty range", filter: &inventoryV1.PartsFilter{Weight: &inventoryV1.DoubleRange{}}, want: true},
+		{name: "stock quantity max", filter: &inventoryV1.PartsFilter{StockQuantity: &inventoryV1.Int64Range{Max: proto.Int64(9)}}, want: false},
+		{
+			name: "dimensions",
+			filter: &inventoryV1.PartsFilter{
+				Length: &inventoryV1.DoubleRange{Min: proto.Float64(200)},
+				Width:  &inventoryV1.DoubleRange{Max: proto.Float64(100)},
+				Height: &inventoryV1.DoubleRange{Min: proto.Float64(10), Max: proto.Float64(60)},
+				Weight: &inventoryV1.DoubleRange{Min: proto.Float64(300)},
+			},
+			want: true,
+		},
+		{name: "height out of range", filter: &inventoryV1.PartsFilter{Height: &inventoryV1.DoubleRange{Min: proto.Float64(51)}}, want: false},
+		{
+			name:   "created at from is inclusive",
+			filter: &inventoryV1.PartsFilter{CreatedAt: &inventoryV1.TimestampRange{From: timestamppb.New(createdAt)}},
+			want:   true,
+		},
+		{
+			name:   "created at to is exclusive",
+			filter: &inventoryV1.PartsFilter{CreatedAt: &inventoryV1.TimestampRange{To: timestamppb.New(createdAt)}},
+			want:   false,
+		},
+		{
+			name: "created at inside period",
+			filter: &inventoryV1.PartsFilter{CreatedAt: &inventoryV1.TimestampRange{
+				From: timestamppb.New(createdAt.Add(-time.Hour)),
+				To:   timestamppb.New(createdAt.Add(time.Second)),
+			}},
+			want: true,
+		},
+		{name: "exclude uuid", filter: &inventoryV1.PartsFilter{ExcludeUuids: []string{testPart().GetUuid()}}, want: false},
+		{name: "exclude other uuid", filter: &inventoryV1.PartsFilter{ExcludeUuids: []string{"other"}}, want: true},
+		{name: "exclude name", filter: &inventoryV1.PartsFilter{ExcludeNames: []string{"Main engine"}}, want: false},
+		{
+			name:   "exclude category",
+			filter: &inventoryV1.PartsFilter{ExcludeCategories: []inventoryV1.Category{inventoryV1.Category_CATEGORY_ENGINE}},
+			want:   false,
+		},
+		{name: "exclude manufacturer country", filter: &inventoryV1.PartsFilter{ExcludeManufacturerCountries: []string{"RU"}}, want: false},
+		{name: "exclude any of tags", filter: &inventoryV1.PartsFilter{ExcludeTags: []string{"solid", "liquid"}}, want: false},
+		{name: "exclude missing tags", filter: &inventoryV1.PartsFilter{ExcludeTags: []string{"solid"}}, want: true},
+		{
+			name: "exclusion wins over inclusion",
+			filter: &inventoryV1.PartsFilter{
+				Uuids:        []string{testPart().GetUuid()},
+				ExcludeNames: []string{"Main engine"},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchFilters(testPart(), NewFilter(tt.filter)); got != tt.want {
+				t.Fatalf("MatchFilters: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFilterSkipsUnsetFields(t *testing.T) {
+	filter := &inventoryV1.PartsFilter{
+		Names:        []string{"Main engine"},
+		Price:        &inventoryV1.DoubleRange{},
+		ExcludeUuids: []string{"other"},
+	}
+
+	if got := len(NewFilter(filter)); got != 3 {
+		t.Fatalf("NewFilter: got %d conditions, want %d", got, 3)
+	}
+	if got := len(NewFilter(&inventoryV1.PartsFilter{})); got != 0 {
+		t.Fatalf("NewFilter: got %d conditions, want %d", got, 0)
+	}
+}
+
+func TestValidateFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *inventoryV1.PartsFilter
+		wantErr bool
+	}{
+		{name: "empty", filter: &inventoryV1.PartsFilter{}},
+		{name: "equal bounds", filter: &inventoryV1.PartsFilter{Price: &inventoryV1.DoubleRange{Min: proto.Float64(1), Max: proto.Float64(1)}}},
+		{name: "min greater than max", filter: &inventoryV1.PartsFilter{Width: &inventoryV1.DoubleRange{Min: proto.Float64(2), Max: proto.Float64(1)}}, wantErr: true},
+		{name: "stock min greater than max", filter: &inventoryV1.PartsFilter{StockQuantity: &inventoryV1.Int64Range{Min: proto.Int64(2), Max: proto.Int64(1)}}, wantErr: true},
+		{
+			name: "empty period",
+			filter: &inventoryV1.PartsFilter{CreatedAt: &inventoryV1.TimestampRange{
+				From: timestamppb.New(createdAt),
+				To:   timestamppb.New(createdAt),
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFilter(tt.filter)
+			if tt.wantErr && !errors.Is(err, ErrInvalidFilter) {
+				t.Fatalf("ValidateFilter: got %v, want %v", err, ErrInvalidFilter)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("ValidateFilter: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// InventoryStorageInMem представляет потокобезопасное хранилище данных о деталях
+type InventoryStorageInMem struct {
+	mu           sync.RWMutex
+	parts        map[string]*inventoryV1.Part
+	reservations map[string]*inventoryV1.Reservation
+	listeners    []PartListener
+}
+
+// NewInventoryStorageInMem создает новое хранилище данных о деталях с переданным набором деталей
+func NewInventoryStorageInMem(parts map[string]*inventoryV1.Part) *InventoryStorageInMem {
+	return &InventoryStorageInMem{
+		parts:        parts,
+		reservations: make(map[string]*inventoryV1.Reservation),
+	}
+}
+
+// Part возвращает деталь по uuid
+func (s *InventoryStorageInMem) Part(_ context.Context, partUuid string) (*inventoryV1.Part, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	part, ok := s.parts[partUuid]
+	if !ok {
+		return nil, ErrPartNotFound
+	}
+
+	// Возвращаем копию, так как остаток на складе меняется при резервировании
+	return proto.CloneOf(part), nil
+}
+
+// Parts возвращает страницу деталей отфильтрованных в соответствии с переданным фильтром
+func (s *InventoryStorageInMem) Parts(_ context.Context, query PartsQuery) (*PartsPage, error) {
+	if err := ValidateFilter(query.Filter); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Создаем список фильтров
+	filters := NewFilter(query.Filter)
+
+	// Деталь попадает в выборку, только если подходит под все фильтры
+	filteredParts := make([]*inventoryV1.Part, 0)
+	for _, part := range s.parts {
+		if err := ValidateMetadataTypes(query.Filter, part); err != nil {
+			return nil, err
+		}
+		if MatchFilters(part, filters) {
+			filteredParts = append(filteredParts, part)
+		}
+	}
+
+	if len(filteredParts) == 0 {
+		return nil, ErrPartsNotFound
+	}
+
+	// Сортируем детали, так как порядок обхода map случаен
+	slices.SortFunc(filteredParts, func(a, b *inventoryV1.Part) int {
+		return comparePartsInQuery(query, CursorOf(a), CursorOf(b))
+	})
+
+	page := &PartsPage{
+		Parts:     make([]*inventoryV1.Part, 0),
+		TotalSize: len(filteredParts),
+	}
+
+	start := 0
+	if query.After != nil {
+		start, _ = slices.BinarySearchFunc(filteredParts, query.After, func(part *inventoryV1.Part, after *PartCursor) int {
+			if comparePartsInQuery(query, CursorOf(part), after) <= 0 {
+				return -1
+			}
+			return 1
+		})
+	}
+
+	end := len(filteredParts)
+	if query.Limit > 0 {
+		end = min(end, start+query.Limit)
+	}
+
+	for _, part := range filteredParts[start:end] {
+		page.Parts = append(page.Parts, proto.CloneOf(part))
+	}
+
+	return page, nil
+}
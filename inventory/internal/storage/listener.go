@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// PartChangeKind вид изменения детали
+type PartChangeKind int
+
+const (
+	PartCreated PartChangeKind = iota + 1
+	PartUpdated
+	// PartStockChanged изменился только остаток на складе: резервирование, возврат или AdjustStock
+	PartStockChanged
+	PartDeleted
+)
+
+// PartChange изменение детали в хранилище
+type PartChange struct {
+	Kind PartChangeKind
+	// Part состояние детали после изменения, для удаленной детали — последнее состояние
+	Part *inventoryV1.Part
+	// Previous состояние детали до изменения, nil для созданной детали
+	Previous *inventoryV1.Part
+}
+
+// PartListener получает уведомления об изменении деталей в хранилище. Метод вызывается синхронно
+// под блокировкой хранилища в порядке изменений, поэтому должен выполняться быстро
+// и не обращаться к хранилищу. Previous общий для всех получателей и не должен изменяться
+type PartListener interface {
+	PartChanged(change PartChange)
+}
+
+// Subscribe регистрирует получателя уведомлений об изменении деталей. Сразу при регистрации
+// получатель получает все имеющиеся детали как созданные и может построить по ним начальное состояние
+func (s *InventoryStorageInMem) Subscribe(listener PartListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, part := range s.parts {
+		listener.PartChanged(PartChange{Kind: PartCreated, Part: proto.CloneOf(part)})
+	}
+
+	s.listeners = append(s.listeners, listener)
+}
+
+// notify уведомляет получателей об изменении детали, вызывается под блокировкой на запись
+func (s *InventoryStorageInMem) notify(kind PartChangeKind, previous, part *inventoryV1.Part) {
+	for _, listener := range s.listeners {
+		listener.PartChanged(PartChange{Kind: kind, Part: proto.CloneOf(part), Previous: previous})
+	}
+}
+
+// snapshot возвращает копию детали перед изменением на месте, если есть получатели уведомлений
+func (s *InventoryStorageInMem) snapshot(part *inventoryV1.Part) *inventoryV1.Part {
+	if len(s.listeners) == 0 {
+		return nil
+	}
+
+	return proto.CloneOf(part)
+}
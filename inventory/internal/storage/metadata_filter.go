@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strings"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// validateMetadataPredicate проверяет, что операция предиката применима к типу его значения
+func validateMetadataPredicate(p *inventoryV1.MetadataPredicate) error {
+	if p.GetKey() == "" {
+		return fmt.Errorf("%w: metadata key is required", ErrInvalidFilter)
+	}
+
+	value := p.GetValue().GetValueType()
+
+	switch p.GetOperator() {
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_EXISTS:
+		return nil
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_EQUALS:
+		if value == nil {
+			return fmt.Errorf("%w: metadata %q: value is required", ErrInvalidFilter, p.GetKey())
+		}
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_LESS_THAN,
+		inventoryV1.MetadataOperator_METADATA_OPERATOR_LESS_OR_EQUAL,
+		inventoryV1.MetadataOperator_METADATA_OPERATOR_GREATER_THAN,
+		inventoryV1.MetadataOperator_METADATA_OPERATOR_GREATER_OR_EQUAL:
+		if !isNumeric(p.GetValue()) {
+			return fmt.Errorf("%w: metadata %q: %s requires a numeric value", ErrInvalidFilter, p.GetKey(), p.GetOperator())
+		}
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_PREFIX:
+		if _, ok := value.(*inventoryV1.Value_StringValue); !ok {
+			return fmt.Errorf("%w: metadata %q: %s requires a string value", ErrInvalidFilter, p.GetKey(), p.GetOperator())
+		}
+	default:
+		return fmt.Errorf("%w: metadata %q: operator is required", ErrInvalidFilter, p.GetKey())
+	}
+
+	if v, ok := value.(*inventoryV1.Value_DoubleValue); ok && math.IsNaN(v.DoubleValue) {
+		return fmt.Errorf("%w: metadata %q: value must be a number", ErrInvalidFilter, p.GetKey())
+	}
+
+	return nil
+}
+
+// ValidateMetadataTypes проверяет, что условия фильтра на метаданные применимы к типам значений детали.
+// Несовместимые типы означают ошибку в запросе, а не отсутствие совпадения
+func ValidateMetadataTypes(filter *inventoryV1.PartsFilter, part *inventoryV1.Part) error {
+	for _, p := range filter.GetMetadata() {
+		value, ok := part.GetMetadata()[p.GetKey()]
+		if !ok {
+			continue
+		}
+		if _, err := matchMetadata(p, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// metadataFilter создает условие фильтра для предиката на метаданные
+func metadataFilter(p *inventoryV1.MetadataPredicate) FilterFunc {
+	return func(part *inventoryV1.Part) bool {
+		value, ok := part.GetMetadata()[p.GetKey()]
+		if !ok {
+			return false
+		}
+
+		match, err := matchMetadata(p, value)
+		return err == nil && match
+	}
+}
+
+// matchMetadata сравнивает значение метаданных детали с предикатом.
+// Возвращает ошибку, если операция неприменима к типу значения детали
+func matchMetadata(p *inventoryV1.MetadataPredicate, value *inventoryV1.Value) (bool, error) {
+	switch p.GetOperator() {
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_EXISTS:
+		return true, nil
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_PREFIX:
+		s, ok := value.GetValueType().(*inventoryV1.Value_StringValue)
+		if !ok {
+			return false, typeMismatch(p, value)
+		}
+		return strings.HasPrefix(s.StringValue, p.GetValue().GetStringValue()), nil
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_EQUALS:
+		if isNumeric(p.GetValue()) && isNumeric(value) {
+			return compareNumeric(value, p.GetValue()) == 0, nil
+		}
+
+		switch want := p.GetValue().GetValueType().(type) {
+		case *inventoryV1.Value_StringValue:
+			if got, ok := value.GetValueType().(*inventoryV1.Value_StringValue); ok {
+				return got.StringValue == want.StringValue, nil
+			}
+		case *inventoryV1.Value_BoolValue:
+			if got, ok := value.GetValueType().(*inventoryV1.Value_BoolValue); ok {
+				return got.BoolValue == want.BoolValue, nil
+			}
+		}
+		return false, typeMismatch(p, value)
+	}
+
+	if !isNumeric(value) {
+		return false, typeMismatch(p, value)
+	}
+
+	c := compareNumeric(value, p.GetValue())
+	switch p.GetOperator() {
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_LESS_THAN:
+		return c < 0, nil
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_LESS_OR_EQUAL:
+		return c <= 0, nil
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_GREATER_THAN:
+		return c > 0, nil
+	case inventoryV1.MetadataOperator_METADATA_OPERATOR_GREATER_OR_EQUAL:
+		return c >= 0, nil
+	default:
+		return false, fmt.Errorf("%w: metadata %q: operator is required", ErrInvalidFilter, p.GetKey())
+	}
+}
+
+// isNumeric проверяет, что значение целое или дробное
+func isNumeric(value *inventoryV1.Value) bool {
+	switch value.GetValueType().(type) {
+	case *inventoryV1.Value_Int64Value, *inventoryV1.Value_DoubleValue:
+		return true
+	default:
+		return false
+	}
+}
+
+// compareNumeric сравнивает числовые значения. Два целых сравниваются без потери точности,
+// иначе сравнение выполняется в double
+func compareNumeric(a, b *inventoryV1.Value) int {
+	ai, aIsInt := a.GetValueType().(*inventoryV1.Value_Int64Value)
+	bi, bIsInt := b.GetValueType().(*inventoryV1.Value_Int64Value)
+	if aIsInt && bIsInt {
+		return cmp.Compare(ai.Int64Value, bi.Int64Value)
+	}
+
+	return cmp.Compare(asFloat(a), asFloat(b))
+}
+
+// asFloat возвращает числовое значение в виде double
+func asFloat(value *inventoryV1.Value) float64 {
+	if v, ok := value.GetValueType().(*inventoryV1.Value_Int64Value); ok {
+		return float64(v.Int64Value)
+	}
+
+	return value.GetDoubleValue()
+}
+
+// typeMismatch возвращает ошибку сравнения несовместимых типов
+func typeMismatch(p *inventoryV1.MetadataPredicate, value *inventoryV1.Value) error {
+	return fmt.Errorf("%w: metadata %q holds %s, %s is not applicable",
+		ErrInvalidFilter, p.GetKey(), valueTypeName(value), p.GetOperator())
+}
+
+// valueTypeName возвращает название типа значения метаданных
+func valueTypeName(value *inventoryV1.Value) string {
+	switch value.GetValueType().(type) {
+	case *inventoryV1.Value_StringValue:
+		return "string_value"
+	case *inventoryV1.Value_Int64Value:
+		return "int64_value"
+	case *inventoryV1.Value_DoubleValue:
+		return "double_value"
+	case *inventoryV1.Value_BoolValue:
+		return "bool_value"
+	default:
+		return "empty value"
+	}
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// CreatePart добавляет новую деталь в каталог, генерируя uuid если он не передан
+func (s *InventoryStorageInMem) CreatePart(_ context.Context, part *inventoryV1.Part) (*inventoryV1.Part, error) {
+	part = proto.CloneOf(part)
+	if part.Uuid == "" {
+		part.Uuid = uuid.NewString()
+	}
+
+	if err := syncPrice(part, priceFromMajorOnCreate(part)); err != nil {
+		return nil, err
+	}
+
+	if err := ValidatePart(part); err != nil {
+		return nil, err
+	}
+
+	now := timestamppb.New(time.Now())
+	part.CreatedAt = now
+	part.UpdatedAt = now
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.parts[part.Uuid]; ok {
+		return nil, ErrPartAlreadyExists
+	}
+
+	s.parts[part.Uuid] = part
+	s.notify(PartCreated, nil, part)
+
+	return proto.CloneOf(part), nil
+}
+
+// UpdatePart обновляет поля детали, перечисленные в paths, значениями из patch
+func (s *InventoryStorageInMem) UpdatePart(_ context.Context, partUuid string, patch *inventoryV1.Part, paths []string) (*inventoryV1.Part, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.parts[partUuid]
+	if !ok {
+		return nil, ErrPartNotFound
+	}
+
+	// Изменяем копию, чтобы при ошибке валидации деталь в хранилище осталась прежней
+	updated := proto.CloneOf(existing)
+	if err := applyPartMask(updated, proto.CloneOf(patch), paths); err != nil {
+		return nil, err
+	}
+
+	if err := syncPrice(updated, priceFromMajorOnUpdate(paths)); err != nil {
+		return nil, err
+	}
+
+	if err := ValidatePart(updated); err != nil {
+		return nil, err
+	}
+
+	updated.UpdatedAt = timestamppb.New(time.Now())
+	s.parts[partUuid] = updated
+	s.notify(PartUpdated, existing, updated)
+
+	return proto.CloneOf(updated), nil
+}
+
+// DeletePart удаляет деталь из каталога, если она не удерживается активным резервом
+func (s *InventoryStorageInMem) DeletePart(_ context.Context, partUuid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	part, ok := s.parts[partUuid]
+	if !ok {
+		return ErrPartNotFound
+	}
+
+	for _, reservation := range s.reservations {
+		if reservation.Status != inventoryV1.ReservationStatus_RESERVATION_STATUS_RESERVED {
+			continue
+		}
+		for _, item := range reservation.Items {
+			if item.GetPartUuid() == partUuid {
+				return fmt.Errorf("%w: order %s", ErrPartReserved, reservation.OrderUuid)
+			}
+		}
+	}
+
+	delete(s.parts, partUuid)
+	s.notify(PartDeleted, part, part)
+
+	return nil
+}
+
+// AdjustStock изменяет остаток детали на складе на delta, остаток не может стать отрицательным
+func (s *InventoryStorageInMem) AdjustStock(_ context.Context, partUuid string, delta int64) (*inventoryV1.Part, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	part, ok := s.parts[partUuid]
+	if !ok {
+		return nil, ErrPartNotFound
+	}
+
+	if delta > 0 && part.StockQuantity > math.MaxInt64-delta {
+		return nil, fmt.Errorf("%w: stock quantity overflow", ErrInvalidPart)
+	}
+
+	if part.StockQuantity+delta < 0 {
+		return nil, fmt.Errorf("%w: part %s", ErrInsufficientStock, partUuid)
+	}
+
+	previous := s.snapshot(part)
+	part.StockQuantity += delta
+	part.UpdatedAt = timestamppb.New(time.Now())
+	s.notify(PartStockChanged, previous, part)
+
+	return proto.CloneOf(part), nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"slices"
+
+	"github.com/Igorezka/rocket-factory/shared/pkg/money"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// syncPrice приводит цену детали к единому виду: валюта по умолчанию RUB, основной считается цена
+// в минимальных единицах, а устаревшая цена в основных единицах вычисляется из нее.
+// Если fromMajor, цена в минимальных единицах сначала пересчитывается из price
+func syncPrice(part *inventoryV1.Part, fromMajor bool) error {
+	if part.Currency == "" {
+		part.Currency = money.DefaultCurrency
+	}
+	if !money.ValidCurrency(part.Currency) {
+		return invalidPart("currency must be an ISO 4217 code")
+	}
+
+	if fromMajor {
+		if !isFinite(part.Price) || part.Price < 0 {
+			return invalidPart("price must be a non-negative number")
+		}
+
+		minor, err := money.ToMinor(part.Price, part.Currency)
+		if err != nil {
+			return invalidPart("price is too large")
+		}
+		part.PriceMinor = minor
+	}
+
+	if part.PriceMinor < 0 {
+		return invalidPart("price_minor must be non-negative")
+	}
+	part.Price = money.FromMinor(part.PriceMinor, part.Currency)
+
+	return nil
+}
+
+// priceFromMajorOnCreate проверяет, что у создаваемой детали задана только устаревшая цена в основных единицах
+func priceFromMajorOnCreate(part *inventoryV1.Part) bool {
+	return part.PriceMinor == 0 && part.Price != 0
+}
+
+// priceFromMajorOnUpdate проверяет, что маска обновляет только устаревшую цену в основных единицах
+func priceFromMajorOnUpdate(paths []string) bool {
+	return slices.Contains(paths, "price") &&
+		!slices.Contains(paths, "price_minor") &&
+		!slices.Contains(paths, fullMaskPath)
+}
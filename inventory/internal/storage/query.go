@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// PartsQuery запрос страницы деталей. Детали упорядочиваются по OrderBy, а при равенстве — по uuid,
+// поэтому порядок детерминирован и страницы не пересекаются
+type PartsQuery struct {
+	Filter     *inventoryV1.PartsFilter
+	OrderBy    inventoryV1.PartsOrderBy
+	Descending bool
+	// After курсор, после которого начинается страница, nil для первой страницы
+	After *PartCursor
+	// Limit максимальное количество деталей на странице, 0 — без ограничения
+	Limit int
+}
+
+// PartsPage страница деталей
+type PartsPage struct {
+	Parts []*inventoryV1.Part
+	// TotalSize количество деталей, подходящих под фильтр, без учета пагинации
+	TotalSize int
+}
+
+// PartCursor позиция последней детали предыдущей страницы
+type PartCursor struct {
+	// PriceMinor цена в минимальных единицах валюты: сравнение float цены зависит от ошибок округления
+	PriceMinor    int64
+	Name          string
+	CreatedAt     time.Time
+	StockQuantity int64
+	Uuid          string
+}
+
+// CursorOf возвращает курсор, указывающий на деталь
+func CursorOf(part *inventoryV1.Part) *PartCursor {
+	return &PartCursor{
+		PriceMinor:    part.GetPriceMinor(),
+		Name:          part.GetName(),
+		CreatedAt:     part.GetCreatedAt().AsTime(),
+		StockQuantity: part.GetStockQuantity(),
+		Uuid:          part.GetUuid(),
+	}
+}
+
+// compareParts сравнивает позиции двух деталей в порядке сортировки orderBy без учета направления
+func compareParts(orderBy inventoryV1.PartsOrderBy, a, b *PartCursor) int {
+	var c int
+	switch orderBy {
+	case inventoryV1.PartsOrderBy_PARTS_ORDER_BY_PRICE:
+		c = cmp.Compare(a.PriceMinor, b.PriceMinor)
+	case inventoryV1.PartsOrderBy_PARTS_ORDER_BY_NAME:
+		c = strings.Compare(a.Name, b.Name)
+	case inventoryV1.PartsOrderBy_PARTS_ORDER_BY_CREATED_AT:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	case inventoryV1.PartsOrderBy_PARTS_ORDER_BY_STOCK:
+		c = cmp.Compare(a.StockQuantity, b.StockQuantity)
+	}
+
+	if c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.Uuid, b.Uuid)
+}
+
+// comparePartsInQuery сравнивает позиции двух деталей с учетом направления сортировки запроса
+func comparePartsInQuery(query PartsQuery, a, b *PartCursor) int {
+	c := compareParts(query.OrderBy, a, b)
+	if query.Descending {
+		return -c
+	}
+
+	return c
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// pricedParts возвращает детали с заданными ценами в минимальных единицах, ключ — uuid детали
+func pricedParts(prices map[string]int64) map[string]*inventoryV1.Part {
+	parts := make(map[string]*inventoryV1.Part, len(prices))
+	for partUuid, price := range prices {
+		parts[partUuid] = &inventoryV1.Part{Uuid: partUuid, Name: partUuid, PriceMinor: price, Currency: "RUB"}
+	}
+	return parts
+}
+
+// partUuids возвращает uuid деталей в порядке следования
+func partUuids(parts []*inventoryV1.Part) []string {
+	uuids := make([]string, 0, len(parts))
+	for _, part := range parts {
+		uuids = append(uuids, part.GetUuid())
+	}
+	return uuids
+}
+
+func TestInventoryStorageInMemPartsPages(t *testing.T) {
+	parts := pricedParts(map[string]int64{"e": 100, "b": 200, "d": 100, "a": 100, "c": 200})
+
+	tests := []struct {
+		name       string
+		descending bool
+		want       [][]string
+	}{
+		{name: "ascending", want: [][]string{{"a", "d"}, {"e", "b"}, {"c"}}},
+		// Направление меняет и порядок деталей с одинаковой ценой
+		{name: "descending", descending: true, want: [][]string{{"c", "b"}, {"e", "d"}, {"a"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewInventoryStorageInMem(parts)
+			query := PartsQuery{
+				OrderBy:    inventoryV1.PartsOrderBy_PARTS_ORDER_BY_PRICE,
+				Descending: tt.descending,
+				Limit:      2,
+			}
+
+			for i, want := range tt.want {
+				page, err := s.Parts(context.Background(), query)
+				if err != nil {
+					t.Fatalf("Parts: %v", err)
+				}
+				if got := partUuids(page.Parts); !slices.Equal(got, want) {
+					t.Fatalf("Parts page %d: got %v, want %v", i, got, want)
+				}
+				if page.TotalSize != len(parts) {
+					t.Fatalf("Parts page %d: got total size %d, want %d", i, page.TotalSize, len(parts))
+				}
+				query.After = CursorOf(page.Parts[len(page.Parts)-1])
+			}
+
+			// После последней страницы деталей не остается
+			page, err := s.Parts(context.Background(), query)
+			if err != nil {
+				t.Fatalf("Parts after last page: %v", err)
+			}
+			if len(page.Parts) != 0 {
+				t.Fatalf("Parts after last page: got %v, want none", partUuids(page.Parts))
+			}
+		})
+	}
+}
+
+func TestInventoryStorageInMemPartsSortsByPriceMinor(t *testing.T) {
+	parts := pricedParts(map[string]int64{"a": 30, "b": 10, "c": 20})
+	// Цена с плавающей точкой не участвует в сортировке
+	parts["b"].Price = 1e9
+
+	page, err := NewInventoryStorageInMem(parts).Parts(context.Background(), PartsQuery{
+		OrderBy: inventoryV1.PartsOrderBy_PARTS_ORDER_BY_PRICE,
+	})
+	if err != nil {
+		t.Fatalf("Parts: %v", err)
+	}
+
+	if got, want := partUuids(page.Parts), []string{"b", "c", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("Parts: got %v, want %v", got, want)
+	}
+}
+
+func TestInventoryStorageInMemPartsCursorOfRemovedPart(t *testing.T) {
+	parts := pricedParts(map[string]int64{"a": 10, "b": 20, "c": 30})
+	s := NewInventoryStorageInMem(parts)
+	query := PartsQuery{OrderBy: inventoryV1.PartsOrderBy_PARTS_ORDER_BY_PRICE, Limit: 1}
+
+	page, err := s.Parts(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Parts: %v", err)
+	}
+	query.After = CursorOf(page.Parts[0])
+
+	// Курсор хранит позицию, а не индекс, поэтому удаление детали не сдвигает следующую страницу
+	delete(parts, "a")
+
+	page, err = s.Parts(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Parts: %v", err)
+	}
+	if got, want := partUuids(page.Parts), []string{"b"}; !slices.Equal(got, want) {
+		t.Fatalf("Parts: got %v, want %v", got, want)
+	}
+}
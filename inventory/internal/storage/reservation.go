@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// ReserveParts резервирует детали под заказ и уменьшает их остаток на складе.
+// Резерв создается только если на складе достаточно всех деталей
+func (s *InventoryStorageInMem) ReserveParts(
+	_ context.Context,
+	orderUuid string,
+	items []*inventoryV1.ReservationItem,
+	ttl time.Duration,
+) (*inventoryV1.Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.reservations[orderUuid]; ok && isActive(r) {
+		return nil, ErrReservationAlreadyExists
+	}
+
+	// Сначала проверяем остатки по всем позициям, чтобы не списать детали частично
+	for _, item := range items {
+		part, ok := s.parts[item.GetPartUuid()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrPartNotFound, item.GetPartUuid())
+		}
+		if part.StockQuantity < item.GetQuantity() {
+			return nil, fmt.Errorf("%w: part %s", ErrInsufficientStock, item.GetPartUuid())
+		}
+	}
+
+	now := time.Now()
+	for _, item := range items {
+		part := s.parts[item.GetPartUuid()]
+		previous := s.snapshot(part)
+		part.StockQuantity -= item.GetQuantity()
+		part.UpdatedAt = timestamppb.New(now)
+		s.notify(PartStockChanged, previous, part)
+	}
+
+	reservation := &inventoryV1.Reservation{
+		OrderUuid: orderUuid,
+		Items:     items,
+		Status:    inventoryV1.ReservationStatus_RESERVATION_STATUS_RESERVED,
+		CreatedAt: timestamppb.New(now),
+		ExpiresAt: timestamppb.New(now.Add(ttl)),
+	}
+	s.reservations[orderUuid] = reservation
+
+	return proto.CloneOf(reservation), nil
+}
+
+// CommitReservation подтверждает резерв после оплаты заказа, детали остаются списанными со склада.
+// Повторное подтверждение уже подтвержденного резерва не является ошибкой
+func (s *InventoryStorageInMem) CommitReservation(_ context.Context, orderUuid string) (*inventoryV1.Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reservation, ok := s.reservations[orderUuid]
+	if !ok {
+		return nil, ErrReservationNotFound
+	}
+
+	switch reservation.Status {
+	case inventoryV1.ReservationStatus_RESERVATION_STATUS_RESERVED:
+		reservation.Status = inventoryV1.ReservationStatus_RESERVATION_STATUS_COMMITTED
+	case inventoryV1.ReservationStatus_RESERVATION_STATUS_COMMITTED:
+	default:
+		return nil, ErrReservationNotActive
+	}
+
+	return proto.CloneOf(reservation), nil
+}
+
+// ReleaseReservation снимает резерв и возвращает детали на склад.
+// Повторное снятие уже снятого резерва не является ошибкой
+func (s *InventoryStorageInMem) ReleaseReservation(_ context.Context, orderUuid string) (*inventoryV1.Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reservation, ok := s.reservations[orderUuid]
+	if !ok {
+		return nil, ErrReservationNotFound
+	}
+
+	switch reservation.Status {
+	case inventoryV1.ReservationStatus_RESERVATION_STATUS_RESERVED:
+		s.restock(reservation)
+		reservation.Status = inventoryV1.ReservationStatus_RESERVATION_STATUS_RELEASED
+	case inventoryV1.ReservationStatus_RESERVATION_STATUS_COMMITTED:
+		return nil, ErrReservationCommitted
+	}
+
+	return proto.CloneOf(reservation), nil
+}
+
+// ReturnReservation возвращает на склад детали заказа независимо от того, был ли подтвержден резерв:
+// подтвержденный резерв переводится в RETURNED, неподтвержденный снимается. Если детали уже вернулись
+// на склад, резерв возвращается без изменений
+func (s *InventoryStorageInMem) ReturnReservation(_ context.Context, orderUuid string) (*inventoryV1.Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reservation, ok := s.reservations[orderUuid]
+	if !ok {
+		return nil, ErrReservationNotFound
+	}
+
+	switch reservation.Status {
+	case inventoryV1.ReservationStatus_RESERVATION_STATUS_RESERVED:
+		s.restock(reservation)
+		reservation.Status = inventoryV1.ReservationStatus_RESERVATION_STATUS_RELEASED
+	case inventoryV1.ReservationStatus_RESERVATION_STATUS_COMMITTED:
+		s.restock(reservation)
+		reservation.Status = inventoryV1.ReservationStatus_RESERVATION_STATUS_RETURNED
+	}
+
+	return proto.CloneOf(reservation), nil
+}
+
+// ExpireReservations снимает неподтвержденные резервы, срок действия которых истек к моменту now,
+// и возвращает количество снятых резервов
+func (s *InventoryStorageInMem) ExpireReservations(_ context.Context, now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := 0
+	for _, reservation := range s.reservations {
+		if reservation.Status != inventoryV1.ReservationStatus_RESERVATION_STATUS_RESERVED ||
+			reservation.ExpiresAt.AsTime().After(now) {
+			continue
+		}
+
+		s.restock(reservation)
+		reservation.Status = inventoryV1.ReservationStatus_RESERVATION_STATUS_EXPIRED
+		expired++
+	}
+
+	return expired
+}
+
+// restock возвращает на склад детали из резерва, вызывается под блокировкой на запись
+func (s *InventoryStorageInMem) restock(reservation *inventoryV1.Reservation) {
+	now := timestamppb.New(time.Now())
+	for _, item := range reservation.Items {
+		if part, ok := s.parts[item.GetPartUuid()]; ok {
+			previous := s.snapshot(part)
+			part.StockQuantity += item.GetQuantity()
+			part.UpdatedAt = now
+			s.notify(PartStockChanged, previous, part)
+		}
+	}
+}
+
+// isActive проверяет, что резерв еще удерживает детали или уже подтвержден
+func isActive(reservation *inventoryV1.Reservation) bool {
+	return reservation.Status == inventoryV1.ReservationStatus_RESERVATION_STATUS_RESERVED ||
+		reservation.Status == inventoryV1.ReservationStatus_RESERVATION_STATUS_COMMITTED
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+var (
+	ErrPartNotFound      = errors.New("part not found")
+	ErrPartsNotFound     = errors.New("parts not found")
+	ErrInvalidFilter     = errors.New("invalid filter")
+	ErrPartAlreadyExists = errors.New("part already exists")
+	ErrPartReserved      = errors.New("part is reserved")
+	ErrInvalidPart       = errors.New("invalid part")
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
+
+	ErrInsufficientStock        = errors.New("insufficient stock")
+	ErrReservationNotFound      = errors.New("reservation not found")
+	ErrReservationAlreadyExists = errors.New("reservation already exists")
+	ErrReservationNotActive     = errors.New("reservation is not active")
+	ErrReservationCommitted     = errors.New("reservation already committed")
+)
+
+// InventoryStorage описывает хранилище данных о деталях и их резервах
+type InventoryStorage interface {
+	Part(ctx context.Context, partUuid string) (*inventoryV1.Part, error)
+	// Parts возвращает страницу деталей, подходящих под фильтр запроса, или ErrPartsNotFound,
+	// если под фильтр не подходит ни одна деталь
+	Parts(ctx context.Context, query PartsQuery) (*PartsPage, error)
+
+	CreatePart(ctx context.Context, part *inventoryV1.Part) (*inventoryV1.Part, error)
+	UpdatePart(ctx context.Context, partUuid string, patch *inventoryV1.Part, paths []string) (*inventoryV1.Part, error)
+	DeletePart(ctx context.Context, partUuid string) error
+	AdjustStock(ctx context.Context, partUuid string, delta int64) (*inventoryV1.Part, error)
+
+	ReserveParts(ctx context.Context, orderUuid string, items []*inventoryV1.ReservationItem, ttl time.Duration) (*inventoryV1.Reservation, error)
+	CommitReservation(ctx context.Context, orderUuid string) (*inventoryV1.Reservation, error)
+	ReleaseReservation(ctx context.Context, orderUuid string) (*inventoryV1.Reservation, error)
+	// ReturnReservation возвращает на склад детали заказа, по которому оформлен возврат средств
+	ReturnReservation(ctx context.Context, orderUuid string) (*inventoryV1.Reservation, error)
+	ExpireReservations(ctx context.Context, now time.Time) int
+
+	// Subscribe регистрирует получателя уведомлений об изменении деталей
+	Subscribe(listener PartListener)
+}
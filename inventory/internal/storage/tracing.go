@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+	"github.com/Igorezka/rocket-factory/shared/pkg/tracing"
+)
+
+var tracer = otel.Tracer("github.com/Igorezka/rocket-factory/inventory/internal/storage")
+
+// tracingInventoryStorage создает span для каждого обращения к хранилищу в рамках трассируемого запроса
+type tracingInventoryStorage struct {
+	next InventoryStorage
+}
+
+// WithTracing оборачивает хранилище деталей и резервов трассировкой обращений
+func WithTracing(next InventoryStorage) InventoryStorage {
+	return &tracingInventoryStorage{next: next}
+}
+
+func (s *tracingInventoryStorage) Part(ctx context.Context, partUuid string) (*inventoryV1.Part, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.Part")
+	part, err := s.next.Part(ctx, partUuid)
+	endSpan(span, err)
+
+	return part, err
+}
+
+func (s *tracingInventoryStorage) Parts(ctx context.Context, query PartsQuery) (*PartsPage, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.Parts")
+	page, err := s.next.Parts(ctx, query)
+	endSpan(span, err)
+
+	return page, err
+}
+
+func (s *tracingInventoryStorage) CreatePart(ctx context.Context, part *inventoryV1.Part) (*inventoryV1.Part, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.CreatePart")
+	created, err := s.next.CreatePart(ctx, part)
+	endSpan(span, err)
+
+	return created, err
+}
+
+func (s *tracingInventoryStorage) UpdatePart(ctx context.Context, partUuid string, patch *inventoryV1.Part, paths []string) (*inventoryV1.Part, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.UpdatePart")
+	part, err := s.next.UpdatePart(ctx, partUuid, patch, paths)
+	endSpan(span, err)
+
+	return part, err
+}
+
+func (s *tracingInventoryStorage) DeletePart(ctx context.Context, partUuid string) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.DeletePart")
+	err := s.next.DeletePart(ctx, partUuid)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingInventoryStorage) AdjustStock(ctx context.Context, partUuid string, delta int64) (*inventoryV1.Part, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.AdjustStock")
+	part, err := s.next.AdjustStock(ctx, partUuid, delta)
+	endSpan(span, err)
+
+	return part, err
+}
+
+func (s *tracingInventoryStorage) ReserveParts(
+	ctx context.Context,
+	orderUuid string,
+	items []*inventoryV1.ReservationItem,
+	ttl time.Duration,
+) (*inventoryV1.Reservation, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.ReserveParts")
+	reservation, err := s.next.ReserveParts(ctx, orderUuid, items, ttl)
+	endSpan(span, err)
+
+	return reservation, err
+}
+
+func (s *tracingInventoryStorage) CommitReservation(ctx context.Context, orderUuid string) (*inventoryV1.Reservation, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.CommitReservation")
+	reservation, err := s.next.CommitReservation(ctx, orderUuid)
+	endSpan(span, err)
+
+	return reservation, err
+}
+
+func (s *tracingInventoryStorage) ReleaseReservation(ctx context.Context, orderUuid string) (*inventoryV1.Reservation, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.ReleaseReservation")
+	reservation, err := s.next.ReleaseReservation(ctx, orderUuid)
+	endSpan(span, err)
+
+	return reservation, err
+}
+
+func (s *tracingInventoryStorage) ReturnReservation(ctx context.Context, orderUuid string) (*inventoryV1.Reservation, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.ReturnReservation")
+	reservation, err := s.next.ReturnReservation(ctx, orderUuid)
+	endSpan(span, err)
+
+	return reservation, err
+}
+
+func (s *tracingInventoryStorage) ExpireReservations(ctx context.Context, now time.Time) int {
+	ctx, span := tracing.StartChild(ctx, tracer, "InventoryStorage.ExpireReservations")
+	expired := s.next.ExpireReservations(ctx, now)
+	endSpan(span, nil)
+
+	return expired
+}
+
+func (s *tracingInventoryStorage) Subscribe(listener PartListener) {
+	s.next.Subscribe(listener)
+}
+
+// endSpan завершает span обращения к хранилищу. Отсутствие записи — штатный результат обращения,
+// поэтому ошибкой в трассе не отмечается
+func endSpan(span trace.Span, err error) {
+	if errors.Is(err, ErrPartNotFound) ||
+		errors.Is(err, ErrPartsNotFound) ||
+		errors.Is(err, ErrReservationNotFound) {
+		err = nil
+	}
+
+	tracing.End(span, err)
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Igorezka/rocket-factory/shared/pkg/money"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+const (
+	// maxMetadataKeys максимальное количество ключей в метаданных детали
+	maxMetadataKeys = 64
+	// maxMetadataKeyLength максимальная длина ключа метаданных
+	maxMetadataKeyLength = 64
+	// maxMetadataStringLength максимальная длина строкового значения метаданных
+	maxMetadataStringLength = 1024
+)
+
+// ValidatePart проверяет корректность данных детали перед сохранением
+func ValidatePart(part *inventoryV1.Part) error {
+	if part.GetUuid() != "" {
+		if err := uuid.Validate(part.GetUuid()); err != nil {
+			return invalidPart("uuid must be a valid UUID")
+		}
+	}
+
+	if strings.TrimSpace(part.GetName()) == "" {
+		return invalidPart("name is required")
+	}
+
+	if !isFinite(part.GetPrice()) || part.GetPrice() < 0 {
+		return invalidPart("price must be a non-negative number")
+	}
+
+	if part.GetPriceMinor() < 0 {
+		return invalidPart("price_minor must be non-negative")
+	}
+
+	if !money.ValidCurrency(part.GetCurrency()) {
+		return invalidPart("currency must be an ISO 4217 code")
+	}
+
+	if part.GetStockQuantity() < 0 {
+		return invalidPart("stock_quantity must be non-negative")
+	}
+
+	if _, ok := inventoryV1.Category_name[int32(part.GetCategory())]; !ok {
+		return invalidPart("unknown category %d", part.GetCategory())
+	}
+
+	for _, tag := range part.GetTags() {
+		if strings.TrimSpace(tag) == "" {
+			return invalidPart("tags must not be empty")
+		}
+	}
+
+	if err := validateDimensions(part.GetDimensions()); err != nil {
+		return err
+	}
+
+	if err := validateManufacturer(part.GetManufacturer()); err != nil {
+		return err
+	}
+
+	return validateMetadata(part.GetMetadata())
+}
+
+// validateDimensions проверяет, что все размеры и вес детали заданы положительными числами
+func validateDimensions(dimensions *inventoryV1.Dimensions) error {
+	if dimensions == nil {
+		return invalidPart("dimensions are required")
+	}
+
+	values := []struct {
+		name  string
+		value float64
+	}{
+		{"length", dimensions.GetLength()},
+		{"width", dimensions.GetWidth()},
+		{"height", dimensions.GetHeight()},
+		{"weight", dimensions.GetWeight()},
+	}
+	for _, v := range values {
+		if !isFinite(v.value) || v.value <= 0 {
+			return invalidPart("dimensions.%s must be a positive number", v.name)
+		}
+	}
+
+	return nil
+}
+
+// validateManufacturer проверяет заполненность данных о производителе и корректность адреса сайта
+func validateManufacturer(manufacturer *inventoryV1.Manufacturer) error {
+	if manufacturer == nil {
+		return invalidPart("manufacturer is required")
+	}
+
+	if strings.TrimSpace(manufacturer.GetName()) == "" {
+		return invalidPart("manufacturer.name is required")
+	}
+
+	if strings.TrimSpace(manufacturer.GetCountry()) == "" {
+		return invalidPart("manufacturer.country is required")
+	}
+
+	if website := manufacturer.GetWebsite(); website != "" {
+		u, err := url.ParseRequestURI(website)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return invalidPart("manufacturer.website must be an http or https URL")
+		}
+	}
+
+	return nil
+}
+
+// validateMetadata проверяет ключи метаданных и то, что у каждого значения задан тип
+func validateMetadata(metadata map[string]*inventoryV1.Value) error {
+	if len(metadata) > maxMetadataKeys {
+		return invalidPart("metadata must contain at most %d keys", maxMetadataKeys)
+	}
+
+	for key, value := range metadata {
+		if strings.TrimSpace(key) == "" || len(key) > maxMetadataKeyLength {
+			return invalidPart("metadata key %q must be non-empty and at most %d characters", key, maxMetadataKeyLength)
+		}
+
+		switch v := value.GetValueType().(type) {
+		case *inventoryV1.Value_StringValue:
+			if len(v.StringValue) > maxMetadataStringLength {
+				return invalidPart("metadata.%s must be at most %d characters", key, maxMetadataStringLength)
+			}
+		case *inventoryV1.Value_DoubleValue:
+			if !isFinite(v.DoubleValue) {
+				return invalidPart("metadata.%s must be a finite number", key)
+			}
+		case *inventoryV1.Value_Int64Value, *inventoryV1.Value_BoolValue:
+		default:
+			return invalidPart("metadata.%s has no value", key)
+		}
+	}
+
+	return nil
+}
+
+// invalidPart создает ошибку валидации детали
+func invalidPart(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidPart, fmt.Sprintf(format, args...))
+}
+
+// isFinite проверяет, что число не является NaN или бесконечностью
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
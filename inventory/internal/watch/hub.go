@@ -0,0 +1,192 @@
+package watch
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Igorezka/rocket-factory/inventory/internal/storage"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+var (
+	// ErrInvalidResumeToken токен возобновления поврежден или не выдавался этим сервером
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+	// ErrResumeTokenExpired события после позиции токена уже удалены из журнала,
+	// либо токен выдан до перезапуска сервиса
+	ErrResumeTokenExpired = errors.New("resume token expired")
+)
+
+// Source хранилище, изменения которого записываются в журнал
+type Source interface {
+	Subscribe(listener storage.PartListener)
+}
+
+// Event событие журнала изменений деталей
+type Event struct {
+	Seq        uint64
+	Kind       storage.PartChangeKind
+	Part       *inventoryV1.Part
+	Previous   *inventoryV1.Part
+	OccurredAt time.Time
+}
+
+// Hub журнал изменений деталей в памяти. События хранятся в течение окна retention,
+// но не больше maxEvents штук, и читаются подписчиками по порядковому номеру
+type Hub struct {
+	mu sync.Mutex
+	// epoch идентификатор журнала, меняется при перезапуске, так как журнал не сохраняется
+	epoch     string
+	events    []Event
+	seq       uint64
+	retention time.Duration
+	maxEvents int
+	// wake закрывается при добавлении события и заменяется новым
+	wake chan struct{}
+	// replaying начальная загрузка деталей при подписке на хранилище, в журнал не попадает
+	replaying bool
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewHub создает журнал и подписывает его на изменения хранилища.
+// Детали, которые уже есть в хранилище, событиями не считаются
+func NewHub(source Source, retention time.Duration, maxEvents int) *Hub {
+	h := &Hub{
+		epoch:     uuid.NewString(),
+		retention: retention,
+		maxEvents: maxEvents,
+		wake:      make(chan struct{}),
+		done:      make(chan struct{}),
+		replaying: true,
+	}
+
+	source.Subscribe(h)
+
+	h.mu.Lock()
+	h.replaying = false
+	h.mu.Unlock()
+
+	return h
+}
+
+// PartChanged записывает изменение детали в журнал и будит ожидающих подписчиков
+func (h *Hub) PartChanged(change storage.PartChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.replaying {
+		return
+	}
+
+	now := time.Now()
+	h.seq++
+	h.events = append(h.events, Event{
+		Seq:        h.seq,
+		Kind:       change.Kind,
+		Part:       change.Part,
+		Previous:   change.Previous,
+		OccurredAt: now,
+	})
+	h.trim(now)
+
+	close(h.wake)
+	h.wake = make(chan struct{})
+}
+
+// Head возвращает номер последнего события, подписчик с этой позиции получит только новые события
+func (h *Hub) Head() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.seq
+}
+
+// Events возвращает события после позиции after и канал, который закроется при появлении следующего события.
+// Если часть событий после after уже удалена из журнала, возвращает ErrResumeTokenExpired
+func (h *Hub) Events(after uint64) ([]Event, <-chan struct{}, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if after > h.seq {
+		return nil, nil, ErrInvalidResumeToken
+	}
+
+	oldest := h.seq + 1
+	if len(h.events) > 0 {
+		oldest = h.events[0].Seq
+	}
+	if after+1 < oldest {
+		return nil, nil, ErrResumeTokenExpired
+	}
+
+	i := sort.Search(len(h.events), func(i int) bool {
+		return h.events[i].Seq > after
+	})
+	events := make([]Event, len(h.events)-i)
+	copy(events, h.events[i:])
+
+	return events, h.wake, nil
+}
+
+// Token возвращает токен возобновления для позиции seq
+func (h *Hub) Token(seq uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(h.epoch + ":" + strconv.FormatUint(seq, 10)))
+}
+
+// ParseToken возвращает позицию токена возобновления
+func (h *Hub) ParseToken(token string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidResumeToken, err)
+	}
+
+	epoch, seq, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return 0, ErrInvalidResumeToken
+	}
+
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidResumeToken, err)
+	}
+
+	if epoch != h.epoch {
+		return 0, ErrResumeTokenExpired
+	}
+
+	return n, nil
+}
+
+// Done возвращает канал, который закрывается при остановке журнала
+func (h *Hub) Done() <-chan struct{} {
+	return h.done
+}
+
+// Close останавливает журнал, подписчики должны завершить чтение
+func (h *Hub) Close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+}
+
+// trim удаляет события старше окна хранения и сверх максимального количества,
+// вызывается под блокировкой
+func (h *Hub) trim(now time.Time) {
+	cutoff := now.Add(-h.retention)
+
+	drop := 0
+	for drop < len(h.events) && (h.events[drop].OccurredAt.Before(cutoff) || len(h.events)-drop > h.maxEvents) {
+		drop++
+	}
+
+	h.events = h.events[drop:]
+}
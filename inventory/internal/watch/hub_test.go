@@ -0,0 +1,218 @@
+package watch
+
+import (
+	"encoding/base64"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Igorezka/rocket-factory/inventory/internal/storage"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// fakeSource хранилище с деталями, которые передаются журналу при подписке
+type fakeSource struct {
+	parts []*inventoryV1.Part
+}
+
+func (s fakeSource) Subscribe(listener storage.PartListener) {
+	for _, part := range s.parts {
+		listener.PartChanged(storage.PartChange{Kind: storage.PartCreated, Part: part})
+	}
+}
+
+// publish записывает в журнал n изменений детали
+func publish(h *Hub, n int) {
+	for range n {
+		h.PartChanged(storage.PartChange{Kind: storage.PartUpdated, Part: &inventoryV1.Part{Uuid: "a"}})
+	}
+}
+
+// eventSeqs возвращает номера событий
+func eventSeqs(events []Event) []uint64 {
+	seqs := make([]uint64, 0, len(events))
+	for _, event := range events {
+		seqs = append(seqs, event.Seq)
+	}
+	return seqs
+}
+
+func TestHubSkipsExistingParts(t *testing.T) {
+	h := NewHub(fakeSource{parts: []*inventoryV1.Part{{Uuid: "a"}, {Uuid: "b"}}}, time.Hour, 10)
+
+	if h.Head() != 0 {
+		t.Fatalf("Head: got %d, want 0", h.Head())
+	}
+
+	events, _, err := h.Events(0)
+	if err != nil {
+		t.Fatalf("Events: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Events: got %v, want none", eventSeqs(events))
+	}
+}
+
+func TestHubResume(t *testing.T) {
+	h := NewHub(fakeSource{}, time.Hour, 10)
+	publish(h, 3)
+
+	after, err := h.ParseToken(h.Token(1))
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+
+	events, wake, err := h.Events(after)
+	if err != nil {
+		t.Fatalf("Events: %v", err)
+	}
+	if got := eventSeqs(events); !slices.Equal(got, []uint64{2, 3}) {
+		t.Fatalf("Events: got %v, want [2 3]", got)
+	}
+
+	select {
+	case <-wake:
+		t.Fatal("Events: wake channel is closed before a new event")
+	default:
+	}
+
+	publish(h, 1)
+
+	select {
+	case <-wake:
+	default:
+		t.Fatal("Events: wake channel is not closed after a new event")
+	}
+
+	events, _, err = h.Events(h.Head())
+	if err != nil {
+		t.Fatalf("Events from head: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Events from head: got %v, want none", eventSeqs(events))
+	}
+}
+
+func TestHubParseToken(t *testing.T) {
+	h := NewHub(fakeSource{}, time.Hour, 10)
+	other := NewHub(fakeSource{}, time.Hour, 10)
+
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    uint64
+		wantErr error
+	}{
+		{name: "own token", token: h.Token(42), want: 42},
+		{name: "token of other epoch", token: other.Token(42), wantErr: ErrResumeTokenExpired},
+		{name: "not base64", token: "!!!", wantErr: ErrInvalidResumeToken},
+		{name: "no separator", token: encode("42"), wantErr: ErrInvalidResumeToken},
+		{name: "not a number", token: encode(h.epoch + ":x"), wantErr: ErrInvalidResumeToken},
+		{name: "negative position", token: encode(h.epoch + ":-1"), wantErr: ErrInvalidResumeToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.ParseToken(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseToken: got %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseToken: got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHubEventsAfterHead(t *testing.T) {
+	h := NewHub(fakeSource{}, time.Hour, 10)
+	publish(h, 1)
+
+	// Позиция впереди журнала не могла быть выдана этим журналом
+	if _, _, err := h.Events(2); !errors.Is(err, ErrInvalidResumeToken) {
+		t.Fatalf("Events: got %v, want %v", err, ErrInvalidResumeToken)
+	}
+}
+
+func TestHubTrimsByMaxEvents(t *testing.T) {
+	h := NewHub(fakeSource{}, time.Hour, 2)
+	publish(h, 4)
+
+	tests := []struct {
+		after   uint64
+		want    []uint64
+		wantErr error
+	}{
+		{after: 0, wantErr: ErrResumeTokenExpired},
+		{after: 1, wantErr: ErrResumeTokenExpired},
+		// Подписчик прочитал событие 2 до удаления и продолжает со следующего хранимого
+		{after: 2, want: []uint64{3, 4}},
+		{after: 3, want: []uint64{4}},
+		{after: 4, want: []uint64{}},
+	}
+
+	for _, tt := range tests {
+		events, _, err := h.Events(tt.after)
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("Events(%d): got %v, want %v", tt.after, err, tt.wantErr)
+		}
+		if got := eventSeqs(events); tt.wantErr == nil && !slices.Equal(got, tt.want) {
+			t.Fatalf("Events(%d): got %v, want %v", tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestHubTrimsByRetention(t *testing.T) {
+	h := NewHub(fakeSource{}, time.Minute, 10)
+	publish(h, 2)
+
+	// Подписчик сохранил токен первого события, после чего события вышли из окна хранения
+	token := h.Token(1)
+
+	h.mu.Lock()
+	h.trim(time.Now().Add(time.Minute + time.Second))
+	h.mu.Unlock()
+
+	after, err := h.ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if _, _, err = h.Events(after); !errors.Is(err, ErrResumeTokenExpired) {
+		t.Fatalf("Events after trim: got %v, want %v", err, ErrResumeTokenExpired)
+	}
+
+	// С последней позиции чтение продолжается, хотя журнал пуст
+	events, _, err := h.Events(h.Head())
+	if err != nil {
+		t.Fatalf("Events from head: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Events from head: got %v, want none", eventSeqs(events))
+	}
+
+	publish(h, 1)
+	events, _, err = h.Events(2)
+	if err != nil {
+		t.Fatalf("Events after new event: %v", err)
+	}
+	if got := eventSeqs(events); !slices.Equal(got, []uint64{3}) {
+		t.Fatalf("Events after new event: got %v, want [3]", got)
+	}
+}
+
+func TestHubClose(t *testing.T) {
+	h := NewHub(fakeSource{}, time.Hour, 10)
+	h.Close()
+	h.Close()
+
+	select {
+	case <-h.Done():
+	default:
+		t.Fatal("Done: channel is not closed after Close")
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Igorezka/rocket-factory/order/internal/metrics"
+	"github.com/Igorezka/rocket-factory/order/internal/statemachine"
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+	"github.com/Igorezka/rocket-factory/shared/pkg/tracing"
+)
+
+// expiryTracer трассирует отмену каждого неоплаченного заказа отдельно
+var expiryTracer = otel.Tracer("github.com/Igorezka/rocket-factory/order/cmd")
+
+const (
+	// expiryScanInterval интервал поиска неоплаченных заказов с истекшим временем ожидания оплаты
+	expiryScanInterval = 30 * time.Second
+	// expiryBatchSize размер страницы неоплаченных заказов при поиске
+	expiryBatchSize = 100
+)
+
+// ExpireUnpaidOrders периодически отменяет заказы, не оплаченные в течение ttl с момента создания,
+// пока не будет отменен контекст. Реплики могут искать одни и те же заказы одновременно:
+// отмена сохраняется только при неизменном статусе заказа, поэтому заказ отменяет одна реплика
+func (h *OrderHandler) ExpireUnpaidOrders(ctx context.Context, ttl time.Duration) {
+	ticker := time.NewTicker(expiryScanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			expired, err := h.expireUnpaidOrders(ctx, now.Add(-ttl), ttl)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to expire unpaid orders", logger.Err(err))
+			}
+
+			if expired > 0 {
+				slog.InfoContext(ctx, "cancelled unpaid orders", slog.Int("count", expired), slog.Duration("ttl", ttl))
+			}
+		}
+	}
+}
+
+// expireUnpaidOrders отменяет заказы, ожидающие оплаты и созданные раньше createdBefore,
+// и возвращает количество отмененных заказов
+func (h *OrderHandler) expireUnpaidOrders(ctx context.Context, createdBefore time.Time, ttl time.Duration) (int, error) {
+	query := storage.OrderQuery{
+		Filter: storage.OrderFilter{
+			Statuses:  []orderV1.OrderStatus{orderV1.OrderStatusPENDINGPAYMENT},
+			CreatedTo: createdBefore,
+		},
+		SortBy: storage.OrderSortByCreatedAt,
+		Limit:  expiryBatchSize,
+	}
+
+	var expired int
+	for {
+		orders, err := h.storage.ListOrders(ctx, query)
+		if err != nil {
+			return expired, err
+		}
+
+		for _, order := range orders {
+			ok, err := h.expireOrder(ctx, order, ttl)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to expire unpaid order", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+				continue
+			}
+
+			if ok {
+				expired++
+			}
+		}
+
+		if len(orders) < query.Limit {
+			return expired, nil
+		}
+
+		query.After = storage.CursorOf(orders[len(orders)-1])
+	}
+}
+
+// expireOrder отменяет неоплаченный заказ, снимает резерв его деталей и возвращает применение промокода.
+// Заказ, оплата которого еще идет, не отменяется: его статус определит сага оплаты.
+// Возвращает false, если заказ не был отменен этим вызовом
+func (h *OrderHandler) expireOrder(ctx context.Context, order *orderV1.OrderDto, ttl time.Duration) (expired bool, err error) {
+	ctx, span := expiryTracer.Start(ctx, "order.Expire", trace.WithAttributes(attribute.String("order.uuid", order.OrderUUID)))
+	defer func() {
+		span.SetAttributes(attribute.Bool("order.expired", expired))
+		tracing.End(span, err)
+	}()
+
+	_, err = h.sagaStorage.GetActiveSaga(ctx, order.OrderUUID, paySagaType)
+	if err == nil {
+		return false, nil
+	}
+
+	if !errors.Is(err, storage.ErrSagaNotFound) {
+		return false, err
+	}
+
+	transition, err := statemachine.Transit(
+		order,
+		orderV1.OrderStatusCANCELLED,
+		statemachine.ActorSystem,
+		"Order expired: not paid within "+ttl.String(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	err = h.storage.UpdateOrder(ctx, order, transition)
+	if err != nil {
+		// Заказ оплачен, отменен пользователем или другой репликой после поиска
+		if errors.Is(err, storage.ErrOrderStatusConflict) || errors.Is(err, storage.ErrOrderNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	// Резерв мог уже истечь в inventory service, в этом случае детали уже на складе
+	releaseCtx, cancel := context.WithTimeout(ctx, h.inventoryTimeout)
+	defer cancel()
+
+	h.metrics.OrderCancelled(ctx, metrics.CancelReasonExpired)
+	h.releaseReservation(releaseCtx, order.OrderUUID)
+	h.releasePromoCode(ctx, order)
+
+	return true, nil
+}
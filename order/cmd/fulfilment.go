@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Igorezka/rocket-factory/order/internal/statemachine"
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+// UpdateOrderFulfilment переводит оплаченный заказ на следующий этап выполнения.
+// Повтор перехода в текущий статус заказа ничего не меняет, чтобы склад мог безопасно повторить запрос
+func (h *OrderHandler) UpdateOrderFulfilment(
+	ctx context.Context,
+	req *orderV1.UpdateOrderFulfilmentRequest,
+	params orderV1.UpdateOrderFulfilmentParams,
+) (orderV1.UpdateOrderFulfilmentRes, error) {
+	order, err := h.storage.GetOrder(ctx, params.OrderUUID)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrderNotFound) {
+			return &orderV1.NotFoundError{
+				Code:    http.StatusNotFound,
+				Message: "Order by UUID " + params.OrderUUID + " not found",
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to get order", slog.String("order_uuid", params.OrderUUID), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	to := orderV1.OrderStatus(req.Status)
+	if order.Status == to {
+		return order, nil
+	}
+
+	transition, err := statemachine.Transit(order, to, statemachine.ActorFulfilment, req.Reason.Or("Order is "+string(to)))
+	if err != nil {
+		return &orderV1.ConflictError{
+			Code:    http.StatusConflict,
+			Message: "The order in status " + string(order.Status) + " cannot be moved to " + string(to),
+		}, nil
+	}
+
+	err = h.storage.UpdateOrder(ctx, order, transition)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrderStatusConflict) {
+			return &orderV1.ConflictError{
+				Code:    http.StatusConflict,
+				Message: "The order status changed during fulfilment update",
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to update order fulfilment", slog.String("order_uuid", order.OrderUUID),
+			slog.String("status", string(to)), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	return order, nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+// readinessCheckTimeout время ожидания ответа каждой зависимости при проверке готовности
+const readinessCheckTimeout = time.Second
+
+// Статусы проверок в ответах /healthz и /readyz
+const (
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+	healthStatusShutdown    = "shutting_down"
+)
+
+// healthCheck проверка одной зависимости order service
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// healthResponse тело ответа /healthz и /readyz
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// HealthChecker отвечает на проверки живости и готовности order service. Сервис готов принимать запросы,
+// если inventory service и payment service отвечают на стандартную проверку здоровья gRPC
+// и хранилище доступно. На время остановки сервис сообщает о неготовности, чтобы балансировщик
+// успел убрать его из ротации до закрытия соединений
+type HealthChecker struct {
+	checks       []healthCheck
+	shuttingDown atomic.Bool
+}
+
+// NewHealthChecker создает проверку готовности order service по клиентам проверки здоровья
+// inventory service и payment service и проверке доступности хранилища
+func NewHealthChecker(
+	inventoryHealth healthpb.HealthClient,
+	paymentHealth healthpb.HealthClient,
+	pingStorage func(ctx context.Context) error,
+) *HealthChecker {
+	return &HealthChecker{
+		checks: []healthCheck{
+			{name: "inventory", check: grpcHealthCheck(inventoryHealth, inventoryV1.InventoryService_ServiceDesc.ServiceName)},
+			{name: "payment", check: grpcHealthCheck(paymentHealth, paymentV1.PaymentService_ServiceDesc.ServiceName)},
+			{name: "storage", check: pingStorage},
+		},
+	}
+}
+
+// Shutdown переводит сервис в состояние остановки, после чего проверка готовности не проходит
+func (h *HealthChecker) Shutdown() {
+	h.shuttingDown.Store(true)
+}
+
+// Live отвечает на проверку живости: процесс запущен и обрабатывает запросы
+func (h *HealthChecker) Live(w http.ResponseWriter, r *http.Request) {
+	writeHealth(r.Context(), w, http.StatusOK, healthResponse{Status: healthStatusOK})
+}
+
+// Ready отвечает на проверку готовности, проверяя все зависимости одновременно
+func (h *HealthChecker) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.shuttingDown.Load() {
+		writeHealth(r.Context(), w, http.StatusServiceUnavailable, healthResponse{Status: healthStatusShutdown})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+	defer cancel()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+		checks = make(map[string]string, len(h.checks))
+	)
+	for _, c := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			status := healthStatusOK
+			if err := c.check(ctx); err != nil {
+				slog.WarnContext(ctx, "readiness check failed", slog.String("check", c.name), logger.Err(err))
+				status = healthStatusUnavailable
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			checks[c.name] = status
+			if status != healthStatusOK {
+				failed = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	if failed {
+		writeHealth(r.Context(), w, http.StatusServiceUnavailable, healthResponse{Status: healthStatusUnavailable, Checks: checks})
+		return
+	}
+
+	writeHealth(r.Context(), w, http.StatusOK, healthResponse{Status: healthStatusOK, Checks: checks})
+}
+
+// grpcHealthCheck проверяет, что сервис service отвечает на стандартную проверку здоровья gRPC со статусом SERVING
+func grpcHealthCheck(client healthpb.HealthClient, service string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+
+		if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("service %s is %s", service, res.GetStatus())
+		}
+
+		return nil
+	}
+}
+
+// writeHealth записывает ответ проверки в формате JSON
+func writeHealth(ctx context.Context, w http.ResponseWriter, code int, res healthResponse) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		slog.ErrorContext(ctx, "failed to write health response", logger.Err(err))
+	}
+}
@@ -2,120 +2,130 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
-	"log"
-	"net"
+	"fmt"
+	"log/slog"
+	"math"
 	"net/http"
 	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 
+	"github.com/Igorezka/rocket-factory/order/internal/broker"
+	"github.com/Igorezka/rocket-factory/order/internal/config"
+	"github.com/Igorezka/rocket-factory/order/internal/idempotency"
+	"github.com/Igorezka/rocket-factory/order/internal/metrics"
+	"github.com/Igorezka/rocket-factory/order/internal/outbox"
+	"github.com/Igorezka/rocket-factory/order/internal/pricing"
+	"github.com/Igorezka/rocket-factory/order/internal/saga"
+	"github.com/Igorezka/rocket-factory/order/internal/statemachine"
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	sharedConfig "github.com/Igorezka/rocket-factory/shared/pkg/config"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	sharedMetrics "github.com/Igorezka/rocket-factory/shared/pkg/metrics"
+	"github.com/Igorezka/rocket-factory/shared/pkg/money"
 	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
 	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
 	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+	"github.com/Igorezka/rocket-factory/shared/pkg/tracing"
 )
 
 const (
-	httpPort = "8080"
-	// Таймауты для HTTP-сервера
-	readHeaderTimeout = 5 * time.Second
-	shutdownTimeout   = 10 * time.Second
+	// partsPageSize размер страницы при получении деталей заказа из inventory service
+	partsPageSize = 1000
 
-	inventoryServerAddress = "localhost:50051"
-	paymentServerAddress   = "localhost:50052"
-)
-
-var ErrOrderNotFound = errors.New("order not found")
-
-type OrderStorage interface {
-	GetOrder(orderUuid string) (*orderV1.OrderDto, error)
-	CreateOrder(order *orderV1.OrderDto)
-	UpdateOrder(order *orderV1.OrderDto)
-}
-
-// OrderStorageInMem представляет потокобезопасное хранилище данных о заказах
-type OrderStorageInMem struct {
-	mu     sync.RWMutex
-	orders map[string]*orderV1.OrderDto
-}
-
-// NewOrderStorage создает новое хранилище данных о заказах
-func NewOrderStorage() OrderStorage {
-	return &OrderStorageInMem{
-		orders: make(map[string]*orderV1.OrderDto),
-	}
-}
-
-// GetOrder возвращает информацию о заказе по uuid из хранилища
-func (s *OrderStorageInMem) GetOrder(orderUuid string) (*orderV1.OrderDto, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	order, ok := s.orders[orderUuid]
-	if !ok {
-		return nil, ErrOrderNotFound
-	}
+	// defaultPageSize размер страницы списка заказов по умолчанию
+	defaultPageSize = 20
 
-	return order, nil
-}
-
-// CreateOrder сохраняет заказ в хранилище
-func (s *OrderStorageInMem) CreateOrder(order *orderV1.OrderDto) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.orders[order.OrderUUID] = order
-}
-
-// UpdateOrder обновляет заказ в хранилище
-func (s *OrderStorageInMem) UpdateOrder(order *orderV1.OrderDto) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	// Интервал удаления просроченных ключей идемпотентности
+	idempotencySweepInterval = 10 * time.Minute
+)
 
-	s.orders[order.OrderUUID] = order
+// storages хранилища order service, работающие поверх одного бэкенда
+type storages struct {
+	orders      storage.OrderStorage
+	idempotency storage.IdempotencyStorage
+	promos      storage.PromoStorage
+	outbox      storage.OutboxStorage
+	sagas       storage.SagaStorage
+	// ping проверяет доступность бэкенда хранилищ
+	ping func(ctx context.Context) error
 }
 
 // OrderHandler реализует интерфейс orderV1.Handler для обработки запросов к API заказов
 type OrderHandler struct {
-	storage         OrderStorage
+	storage         storage.OrderStorage
+	promos          storage.PromoStorage
+	sagaStorage     storage.SagaStorage
+	sagas           *saga.Orchestrator
 	inventoryClient inventoryV1.InventoryServiceClient
 	paymentClient   paymentV1.PaymentServiceClient
+	metrics         *metrics.Metrics
+	// Время ожидания ответа inventory service и payment service
+	inventoryTimeout time.Duration
+	paymentTimeout   time.Duration
 }
 
 // NewOrderHandler создает новый обработчик запросов к API заказов
 func NewOrderHandler(
-	storage OrderStorage,
+	orderStorage storage.OrderStorage,
+	promoStorage storage.PromoStorage,
+	sagaStorage storage.SagaStorage,
 	inventoryClient inventoryV1.InventoryServiceClient,
 	paymentClient paymentV1.PaymentServiceClient,
+	orderMetrics *metrics.Metrics,
+	inventoryTimeout time.Duration,
+	paymentTimeout time.Duration,
 ) *OrderHandler {
-	return &OrderHandler{
-		storage:         storage,
-		inventoryClient: inventoryClient,
-		paymentClient:   paymentClient,
+	h := &OrderHandler{
+		storage:          orderStorage,
+		promos:           promoStorage,
+		sagaStorage:      sagaStorage,
+		sagas:            saga.NewOrchestrator(sagaStorage),
+		inventoryClient:  inventoryClient,
+		paymentClient:    paymentClient,
+		metrics:          orderMetrics,
+		inventoryTimeout: inventoryTimeout,
+		paymentTimeout:   paymentTimeout,
 	}
+	h.sagas.Register(paySagaType, h.paySagaSteps()...)
+
+	return h
+}
+
+// ResumeSagas продолжает незавершенные саги заказов, пока не будет отменен контекст
+func (h *OrderHandler) ResumeSagas(ctx context.Context) {
+	h.sagas.Run(ctx)
 }
 
 // GetOrderByUUID обрабатывает запрос на получение данных о заказе по uuid
-func (h *OrderHandler) GetOrderByUUID(_ context.Context, params orderV1.GetOrderByUUIDParams) (orderV1.GetOrderByUUIDRes, error) {
-	order, err := h.storage.GetOrder(params.OrderUUID)
+func (h *OrderHandler) GetOrderByUUID(ctx context.Context, params orderV1.GetOrderByUUIDParams) (orderV1.GetOrderByUUIDRes, error) {
+	order, err := h.storage.GetOrder(ctx, params.OrderUUID)
 	if err != nil {
-		if errors.Is(err, ErrOrderNotFound) {
+		if errors.Is(err, storage.ErrOrderNotFound) {
 			return &orderV1.NotFoundError{
 				Code:    http.StatusNotFound,
 				Message: "Order by UUID " + params.OrderUUID + " not found",
 			}, nil
 		}
 
+		slog.ErrorContext(ctx, "failed to get order", slog.String("order_uuid", params.OrderUUID), logger.Err(err))
 		return &orderV1.InternalServerError{
 			Code:    http.StatusInternalServerError,
 			Message: "Internal Server Error",
@@ -125,25 +135,103 @@ func (h *OrderHandler) GetOrderByUUID(_ context.Context, params orderV1.GetOrder
 	return order, nil
 }
 
-// CreateOrder обрабатывает запрос на создание заказа с указанием необходимых запчастей
-func (h *OrderHandler) CreateOrder(ctx context.Context, req *orderV1.CreateOrderRequest) (orderV1.CreateOrderRes, error) {
-	if len(req.PartUuids) == 0 {
+// ListOrders обрабатывает запрос на поиск заказов по фильтрам с курсорной пагинацией
+func (h *OrderHandler) ListOrders(ctx context.Context, params orderV1.ListOrdersParams) (orderV1.ListOrdersRes, error) {
+	query := storage.OrderQuery{
+		Filter: storage.OrderFilter{
+			UserUuid:    params.UserUUID.Or(""),
+			Statuses:    params.Status,
+			CreatedFrom: params.CreatedFrom.Or(time.Time{}),
+			CreatedTo:   params.CreatedTo.Or(time.Time{}),
+			PartUuid:    params.PartUUID.Or(""),
+		},
+		SortBy:     storage.OrderSortField(params.SortBy.Or(orderV1.OrderSortFieldCreatedAt)),
+		Descending: params.SortOrder.Or(orderV1.SortOrderDesc) == orderV1.SortOrderDesc,
+	}
+
+	from, to := query.Filter.CreatedFrom, query.Filter.CreatedTo
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return &orderV1.BadRequestError{
+			Code:    http.StatusBadRequest,
+			Message: "created_from must be before created_to",
+		}, nil
+	}
+
+	if token, ok := params.PageToken.Get(); ok {
+		cursor, err := decodePageToken(token, query)
+		if err != nil {
+			return &orderV1.BadRequestError{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		}
+		query.After = cursor
+	}
+
+	// Запрашиваем на один заказ больше, чтобы узнать, есть ли следующая страница
+	pageSize := params.PageSize.Or(defaultPageSize)
+	query.Limit = pageSize + 1
+
+	orders, err := h.storage.ListOrders(ctx, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list orders", logger.Err(err))
 		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	res := &orderV1.ListOrdersResponse{
+		Orders: make([]orderV1.OrderDto, 0, min(len(orders), pageSize)),
+	}
+
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+
+		token, err := encodePageToken(query, orders[len(orders)-1])
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to encode page token", logger.Err(err))
+			return &orderV1.InternalServerError{
+				Code:    http.StatusInternalServerError,
+				Message: "Internal Server Error",
+			}, nil
+		}
+		res.NextPageToken = orderV1.NewOptString(token)
+	}
+
+	for _, order := range orders {
+		res.Orders = append(res.Orders, *order)
+	}
+
+	return res, nil
+}
+
+// CreateOrder обрабатывает запрос на создание заказа с указанием необходимых запчастей и их количества
+// Повторы запроса с заголовком Idempotency-Key обрабатывает idempotency.Middleware
+func (h *OrderHandler) CreateOrder(
+	ctx context.Context,
+	req *orderV1.CreateOrderRequest,
+	_ orderV1.CreateOrderParams,
+) (orderV1.CreateOrderRes, error) {
+	items, err := requestItems(req)
+	if err != nil {
+		return &orderV1.BadRequestError{
 			Code:    http.StatusBadRequest,
-			Message: "Details not provided",
+			Message: err.Error(),
 		}, nil
 	}
 
+	partUuids := make([]string, 0, len(items))
+	for _, item := range items {
+		partUuids = append(partUuids, item.PartUUID)
+	}
+
 	// Создаем таймаут на обращение
-	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, h.inventoryTimeout)
 	defer cancel()
 
 	// Получаем список запчастей по uuid
-	res, err := h.inventoryClient.ListParts(ctx, &inventoryV1.ListPartsRequest{
-		Filter: &inventoryV1.PartsFilter{
-			Uuids: req.PartUuids,
-		},
-	})
+	parts, err := h.listParts(ctx, partUuids)
 	if err != nil {
 		// Проверяем если не нашло ни одной запчасти
 		if status.Code(err) == codes.NotFound {
@@ -153,6 +241,7 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *orderV1.CreateOrder
 			}, nil
 		}
 
+		slog.ErrorContext(ctx, "failed to list parts", slog.String("user_uuid", req.UserUUID), logger.Err(err))
 		return &orderV1.InternalServerError{
 			Code:    http.StatusInternalServerError,
 			Message: "Internal Server Error",
@@ -163,93 +252,311 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *orderV1.CreateOrder
 	order := &orderV1.OrderDto{
 		OrderUUID: uuid.NewString(),
 		UserUUID:  req.UserUUID,
-		Status:    orderV1.OrderStatusPENDINGPAYMENT,
+		Items:     make([]orderV1.OrderLine, 0, len(items)),
+		PartUuids: partUuids,
 	}
+	created := statemachine.Create(order, statemachine.UserActor(req.UserUUID), "Order created")
 
-	// Проверяем на наличие всех необходимых запчастей, при нахождении добавляем в заказ и плюсуем цену,
-	// при не находе падаем в ошибку
-	for _, partUuid := range req.PartUuids {
-		part := containsPart(partUuid, res.Parts)
+	// Проверяем наличие всех необходимых запчастей в нужном количестве и сохраняем цену каждой позиции
+	// на момент создания заказа, при не находе или нехватке падаем в ошибку
+	for _, item := range items {
+		part := containsPart(item.PartUUID, parts)
 		if part == nil {
 			return &orderV1.NotFoundError{
 				Code:    http.StatusNotFound,
-				Message: "Part by UUID " + partUuid + " not found",
+				Message: "Part by UUID " + item.PartUUID + " not found",
+			}, nil
+		}
+
+		if part.StockQuantity < item.Quantity {
+			return &orderV1.ConflictError{
+				Code:    http.StatusConflict,
+				Message: "Not enough parts by UUID " + item.PartUUID + " in stock",
+			}, nil
+		}
+
+		if err = addOrderLine(order, item, part); err != nil {
+			return &orderV1.BadRequestError{
+				Code:    http.StatusBadRequest,
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	// Доставка тарифицируется только в одной валюте, заказ в другой валюте не оплатить
+	if err = pricing.CheckCurrency(order.Currency); err != nil {
+		return &orderV1.UnprocessableEntityError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		}, nil
+	}
+
+	// Применяем промокод, скидка распределяется по позициям заказа
+	if code, ok := req.PromoCode.Get(); ok {
+		if err = h.applyPromoCode(ctx, order, code, parts); err != nil {
+			if isPromoRejection(err) {
+				return &orderV1.UnprocessableEntityError{
+					Code:    http.StatusUnprocessableEntity,
+					Message: err.Error(),
+				}, nil
+			}
+
+			slog.ErrorContext(ctx, "failed to apply promo code", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+			return &orderV1.InternalServerError{
+				Code:    http.StatusInternalServerError,
+				Message: "Internal Server Error",
+			}, nil
+		}
+	}
+
+	// Начисляем доставку и НДС по стране покупателя
+	order.Country = req.Country.Or(pricing.DefaultCountry)
+	if err = priceOrder(order, parts); err != nil {
+		if isPricingRejection(err) {
+			return &orderV1.UnprocessableEntityError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: err.Error(),
 			}, nil
 		}
 
-		order.PartUuids = append(order.PartUuids, partUuid)
-		order.TotalPrice += part.Price
+		return &orderV1.BadRequestError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}, nil
+	}
+
+	// Учитываем применение промокода до резерва, лимит на пользователя проверяется атомарно
+	if code, ok := order.PromoCode.Get(); ok {
+		err = h.promos.RedeemPromoCode(ctx, code, order.UserUUID, order.OrderUUID)
+		if err != nil {
+			if isPromoRejection(err) {
+				return &orderV1.UnprocessableEntityError{
+					Code:    http.StatusUnprocessableEntity,
+					Message: err.Error(),
+				}, nil
+			}
+
+			slog.ErrorContext(ctx, "failed to redeem promo code", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+			return &orderV1.InternalServerError{
+				Code:    http.StatusInternalServerError,
+				Message: "Internal Server Error",
+			}, nil
+		}
+	}
+
+	// Резервируем детали на складе, чтобы их не забрал параллельный заказ
+	_, err = h.inventoryClient.ReserveParts(ctx, &inventoryV1.ReservePartsRequest{
+		OrderUuid: order.OrderUUID,
+		Items:     reservationItems(order.Items),
+	})
+	if err != nil {
+		// Заказ не будет создан, промокод можно применить повторно
+		h.releasePromoCode(ctx, order)
+
+		switch status.Code(err) {
+		case codes.NotFound:
+			return &orderV1.NotFoundError{
+				Code:    http.StatusNotFound,
+				Message: "Parts not found",
+			}, nil
+		case codes.FailedPrecondition:
+			return &orderV1.ConflictError{
+				Code:    http.StatusConflict,
+				Message: "Not enough parts in stock",
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to reserve parts", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
 	}
 
 	// Сохраняем заказ
-	h.storage.CreateOrder(order)
+	err = h.storage.CreateOrder(ctx, order, created)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create order", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+
+		// Заказ не сохранен, возвращаем детали на склад и промокод пользователю
+		h.releaseReservation(ctx, order.OrderUUID)
+		h.releasePromoCode(ctx, order)
+
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	h.metrics.OrderCreated(ctx)
 
 	return &orderV1.CreateOrderResponse{
-		OrderUUID:  order.OrderUUID,
-		TotalPrice: order.TotalPrice,
+		OrderUUID:        order.OrderUUID,
+		TotalPrice:       order.TotalPrice, //nolint:staticcheck // заполняем устаревшее поле для старых клиентов
+		SubtotalMinor:    order.SubtotalMinor,
+		DiscountMinor:    order.DiscountMinor,
+		ShippingWeightKg: order.ShippingWeightKg,
+		ShippingMinor:    order.ShippingMinor,
+		TaxRateBp:        order.TaxRateBp,
+		TaxMinor:         order.TaxMinor,
+		TotalPriceMinor:  order.TotalPriceMinor,
+		PromoCode:        order.PromoCode,
+		Country:          order.Country,
+		Currency:         order.Currency,
 	}, nil
 }
 
 // PayOrder обрабатывает запрос на оплату заказа
 func (h *OrderHandler) PayOrder(ctx context.Context, req *orderV1.PayOrderRequest, params orderV1.PayOrderParams) (orderV1.PayOrderRes, error) {
-	order, err := h.storage.GetOrder(params.OrderUUID)
+	order, err := h.storage.GetOrder(ctx, params.OrderUUID)
 	if err != nil {
-		if errors.Is(err, ErrOrderNotFound) {
+		if errors.Is(err, storage.ErrOrderNotFound) {
 			return &orderV1.NotFoundError{
 				Code:    http.StatusNotFound,
 				Message: "Order by UUID " + params.OrderUUID + " not found",
 			}, nil
 		}
 
+		slog.ErrorContext(ctx, "failed to get order", slog.String("order_uuid", params.OrderUUID), logger.Err(err))
 		return &orderV1.InternalServerError{
 			Code:    http.StatusInternalServerError,
 			Message: "Internal Server Error",
 		}, nil
 	}
 
-	if order.Status == orderV1.OrderStatusPAID {
-		return &orderV1.NotFoundError{
+	if !statemachine.CanTransit(order.Status, orderV1.OrderStatusPAID) {
+		return &orderV1.ConflictError{
 			Code:    http.StatusConflict,
-			Message: "Order UUID " + params.OrderUUID + " already paid",
+			Message: "Order UUID " + params.OrderUUID + " cannot be paid in status " + string(order.Status),
 		}, nil
 	}
 
-	// Оплачиваем заказ через payment service
-	res, err := h.paymentClient.PayOrder(ctx, &paymentV1.PayOrderRequest{
-		OrderUuid:     order.OrderUUID,
-		UserUuid:      order.UserUUID,
-		PaymentMethod: convertPaymentMethod(req.PaymentMethod),
-	})
+	// Пока идет предыдущая оплата, стоимость заказа не пересчитывается
+	_, err = h.sagaStorage.GetActiveSaga(ctx, order.OrderUUID, paySagaType)
+	switch {
+	case err == nil:
+		return payOrderError(ctx, order.OrderUUID, storage.ErrSagaAlreadyExists), nil
+	case !errors.Is(err, storage.ErrSagaNotFound):
+		slog.ErrorContext(ctx, "failed to get payment saga", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	// Пересчитываем доставку и НДС: тарифы и размеры деталей могли измениться с момента создания заказа.
+	// Новую стоимость пользователь подтверждает повторным запросом на оплату
+	previousTotal := order.TotalPriceMinor
+	repriced, err := h.repriceOrder(ctx, order)
 	if err != nil {
+		switch {
+		case isPricingRejection(err):
+			return &orderV1.UnprocessableEntityError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: err.Error(),
+			}, nil
+		case errors.Is(err, storage.ErrOrderStatusConflict):
+			return &orderV1.ConflictError{
+				Code:    http.StatusConflict,
+				Message: "Order UUID " + params.OrderUUID + " status changed during payment",
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to reprice order", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
 		return &orderV1.InternalServerError{
 			Code:    http.StatusInternalServerError,
 			Message: "Internal Server Error",
 		}, nil
 	}
 
-	// Обновляем платежную информацию
-	order.TransactionUUID = orderV1.OptString{Value: res.TransactionUuid, Set: true}
-	order.PaymentMethod = orderV1.OptPaymentMethod{Value: req.PaymentMethod, Set: true}
-	order.Status = orderV1.OrderStatusPAID
+	if repriced {
+		return &orderV1.ConflictError{
+			Code: http.StatusConflict,
+			Message: fmt.Sprintf("Order UUID %s total changed from %d to %d %s minor units, confirm payment again",
+				order.OrderUUID, previousTotal, order.TotalPriceMinor, order.Currency),
+		}, nil
+	}
 
-	h.storage.UpdateOrder(order)
+	// Списание, подтверждение резерва и смена статуса выполняются сагой: если заказ не удается
+	// перевести в PAID, средства возвращаются, а сага сохраняется и продолжается после сбоя
+	paySaga, err := h.sagas.Start(ctx, paySagaType, order.OrderUUID, storage.SagaData{
+		UserUuid:      order.UserUUID,
+		PaymentMethod: string(req.PaymentMethod),
+		Amount:        order.TotalPriceMinor,
+		Currency:      order.Currency,
+		RefundUuid:    uuid.NewString(),
+	})
+	if err != nil {
+		if paySaga != nil && paySaga.Status == storage.SagaStatusCompensated && paySaga.Data.TransactionUuid != "" {
+			return &orderV1.ConflictError{
+				Code:    http.StatusConflict,
+				Message: "Order UUID " + order.OrderUUID + " was cancelled and its payment refunded: " + paySaga.Error,
+			}, nil
+		}
+
+		return payOrderError(ctx, order.OrderUUID, err), nil
+	}
 
 	return &orderV1.PayOrderResponse{
-		TransactionUUID: res.TransactionUuid,
+		TransactionUUID: paySaga.Data.TransactionUuid,
 	}, nil
 }
 
+// payOrderError преобразует ошибку саги оплаты в ответ API
+func payOrderError(ctx context.Context, orderUuid string, err error) orderV1.PayOrderRes {
+	switch {
+	case errors.Is(err, storage.ErrSagaAlreadyExists):
+		return &orderV1.ConflictError{
+			Code:    http.StatusConflict,
+			Message: "Payment of order UUID " + orderUuid + " is already in progress",
+		}
+	case errors.Is(err, saga.ErrPending), errors.Is(err, storage.ErrSagaClaimLost):
+		return &orderV1.ConflictError{
+			Code:    http.StatusConflict,
+			Message: "Payment of order UUID " + orderUuid + " is in progress, check the order status later",
+		}
+	case errors.Is(err, errOrderNotPayable), errors.Is(err, storage.ErrOrderStatusConflict):
+		return &orderV1.ConflictError{
+			Code:    http.StatusConflict,
+			Message: "Order UUID " + orderUuid + " status changed during payment",
+		}
+	}
+
+	if st, ok := grpcStatus(err); ok {
+		switch st.Code() {
+		case codes.FailedPrecondition, codes.InvalidArgument:
+			return &orderV1.PaymentRequiredError{
+				Code:    http.StatusPaymentRequired,
+				Message: st.Message(),
+			}
+		case codes.AlreadyExists, codes.Aborted:
+			// Заказ уже оплачен другим запросом или оплата еще проводится
+			return &orderV1.ConflictError{
+				Code:    http.StatusConflict,
+				Message: st.Message(),
+			}
+		}
+	}
+
+	slog.ErrorContext(ctx, "failed to pay order", slog.String("order_uuid", orderUuid), logger.Err(err))
+	return &orderV1.InternalServerError{
+		Code:    http.StatusInternalServerError,
+		Message: "Internal Server Error",
+	}
+}
+
 // CancelOrder обрабатывает запрос на отмену заказа
-func (h *OrderHandler) CancelOrder(_ context.Context, params orderV1.CancelOrderParams) (orderV1.CancelOrderRes, error) {
-	order, err := h.storage.GetOrder(params.OrderUUID)
+func (h *OrderHandler) CancelOrder(ctx context.Context, params orderV1.CancelOrderParams) (orderV1.CancelOrderRes, error) {
+	order, err := h.storage.GetOrder(ctx, params.OrderUUID)
 	if err != nil {
-		if errors.Is(err, ErrOrderNotFound) {
+		if errors.Is(err, storage.ErrOrderNotFound) {
 			return &orderV1.NotFoundError{
 				Code:    http.StatusNotFound,
 				Message: "Order by UUID " + params.OrderUUID + " not found",
 			}, nil
 		}
 
+		slog.ErrorContext(ctx, "failed to get order", slog.String("order_uuid", params.OrderUUID), logger.Err(err))
 		return &orderV1.InternalServerError{
 			Code:    http.StatusInternalServerError,
 			Message: "Internal Server Error",
@@ -263,20 +570,147 @@ func (h *OrderHandler) CancelOrder(_ context.Context, params orderV1.CancelOrder
 		}, nil
 	}
 
-	if order.Status == orderV1.OrderStatusPAID {
+	if !statemachine.CanTransit(order.Status, orderV1.OrderStatusCANCELLED) {
 		return &orderV1.ConflictError{
 			Code:    http.StatusConflict,
-			Message: "The order has already been paid and cannot be cancelled",
+			Message: "The order in status " + string(order.Status) + " cannot be cancelled",
 		}, nil
 	}
 
-	order.Status = orderV1.OrderStatusCANCELLED
+	// Пока идет оплата, заказ нельзя отменить: списанные средства вернет компенсация саги
+	_, err = h.sagaStorage.GetActiveSaga(ctx, order.OrderUUID, paySagaType)
+	switch {
+	case err == nil:
+		return &orderV1.ConflictError{
+			Code:    http.StatusConflict,
+			Message: "The order payment is in progress",
+		}, nil
+	case !errors.Is(err, storage.ErrSagaNotFound):
+		slog.ErrorContext(ctx, "failed to get payment saga", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
 
-	h.storage.UpdateOrder(order)
+	transition, err := statemachine.Transit(
+		order,
+		orderV1.OrderStatusCANCELLED,
+		statemachine.UserActor(order.UserUUID),
+		"Order cancelled by user",
+	)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to mark order as cancelled", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	err = h.storage.UpdateOrder(ctx, order, transition)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrderStatusConflict) {
+			return &orderV1.ConflictError{
+				Code:    http.StatusConflict,
+				Message: "The order status changed during cancellation",
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to update cancelled order", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	// Резерв снимается только после сохранения отмены: если заказ успели оплатить или отменить,
+	// его детали остаются зарезервированными. Не снятый из-за ошибки резерв истечет в inventory service
+	releaseCtx, cancel := context.WithTimeout(ctx, h.inventoryTimeout)
+	defer cancel()
+
+	h.releaseReservation(releaseCtx, order.OrderUUID)
+	h.metrics.OrderCancelled(ctx, metrics.CancelReasonUser)
+
+	// Отмененный заказ не расходует лимит применений промокода
+	h.releasePromoCode(ctx, order)
 
 	return &orderV1.CancelOrderNoContent{}, nil
 }
 
+// GetOrderHistory обрабатывает запрос на получение истории статусов заказа
+func (h *OrderHandler) GetOrderHistory(ctx context.Context, params orderV1.GetOrderHistoryParams) (orderV1.GetOrderHistoryRes, error) {
+	history, err := h.storage.GetOrderHistory(ctx, params.OrderUUID)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrderNotFound) {
+			return &orderV1.NotFoundError{
+				Code:    http.StatusNotFound,
+				Message: "Order by UUID " + params.OrderUUID + " not found",
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to get order history", slog.String("order_uuid", params.OrderUUID), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	transitions := make([]orderV1.OrderTransition, 0, len(history))
+	for _, t := range history {
+		transition := orderV1.OrderTransition{
+			ToStatus:  t.To,
+			Actor:     t.Actor,
+			Reason:    t.Reason,
+			CreatedAt: t.CreatedAt,
+		}
+		if t.From != "" {
+			transition.FromStatus = orderV1.NewOptOrderStatus(t.From)
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	return &orderV1.GetOrderHistoryResponse{
+		OrderUUID:   params.OrderUUID,
+		Transitions: transitions,
+	}, nil
+}
+
+// listParts получает детали по uuid, обходя все страницы ответа inventory service
+func (h *OrderHandler) listParts(ctx context.Context, partUuids []string) ([]*inventoryV1.Part, error) {
+	req := &inventoryV1.ListPartsRequest{
+		Filter: &inventoryV1.PartsFilter{
+			Uuids: partUuids,
+		},
+		PageSize: partsPageSize,
+	}
+
+	parts := make([]*inventoryV1.Part, 0, len(partUuids))
+	for {
+		res, err := h.inventoryClient.ListParts(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, res.GetParts()...)
+		if res.GetNextPageToken() == "" {
+			return parts, nil
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+// releaseReservation снимает резерв деталей заказа, ошибка только логируется. Резерв мог уже истечь,
+// в этом случае детали уже на складе
+func (h *OrderHandler) releaseReservation(ctx context.Context, orderUuid string) {
+	_, err := h.inventoryClient.ReleaseReservation(ctx, &inventoryV1.ReleaseReservationRequest{
+		OrderUuid: orderUuid,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		slog.ErrorContext(ctx, "failed to release reservation", slog.String("order_uuid", orderUuid), logger.Err(err))
+	}
+}
+
 // NewError создает новую ошибку в формате GenericError
 func (h *OrderHandler) NewError(_ context.Context, err error) *orderV1.GenericErrorStatusCode {
 	return &orderV1.GenericErrorStatusCode{
@@ -289,76 +723,242 @@ func (h *OrderHandler) NewError(_ context.Context, err error) *orderV1.GenericEr
 }
 
 func main() {
-	// Создаем хранилище для данных о заказах
-	storage := NewOrderStorage()
+	logger.Init("order")
+
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("invalid config", logger.Err(err))
+		return
+	}
+	logger.SetLevel(cfg.Log.SlogLevel())
+	slog.Info("effective config", slog.String("config", cfg.String()))
+
+	shutdownTracing, err := tracing.Init(context.Background(), "order", cfg.Tracing)
+	if err != nil {
+		slog.Error("failed to init tracing", logger.Err(err))
+		return
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+		defer cancel()
+
+		if serr := shutdownTracing(ctx); serr != nil {
+			slog.Error("failed to shut down tracing", logger.Err(serr))
+		}
+	}()
+
+	metricsHandler, shutdownMetrics, err := sharedMetrics.Init(context.Background(), "order")
+	if err != nil {
+		slog.Error("failed to init metrics", logger.Err(err))
+		return
+	}
+	defer func() {
+		if serr := shutdownMetrics(context.Background()); serr != nil {
+			slog.Error("failed to shut down metrics", logger.Err(serr))
+		}
+	}()
+
+	orderMetrics, err := metrics.New()
+	if err != nil {
+		slog.Error("failed to create metrics", logger.Err(err))
+		return
+	}
+
+	serverTLS, err := cfg.HTTP.TLS.Config()
+	if err != nil {
+		slog.Error("failed to load tls config", logger.Err(err))
+		return
+	}
+
+	// Создаем хранилища для данных о заказах и ключей идемпотентности
+	stores, closeStorage, err := newStorages(context.Background(), cfg.Storage)
+	if err != nil {
+		slog.Error("failed to create order storage", logger.Err(err))
+		return
+	}
+	defer closeStorage()
+
+	// Запускаем удаление просроченных ключей идемпотентности
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	defer stopSweep()
+
+	go sweepIdempotencyKeys(sweepCtx, stores.idempotency)
+
+	// Создаем адаптер брокера и запускаем публикацию событий заказов из outbox
+	publisher, err := newEventsPublisher(cfg.Events)
+	if err != nil {
+		slog.Error("failed to create events publisher", logger.Err(err))
+		return
+	}
+	defer func() {
+		if cerr := publisher.Close(); cerr != nil {
+			slog.Error("failed to close events publisher", logger.Err(cerr))
+		}
+	}()
+
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		outbox.NewRelay(stores.outbox, publisher).Run(relayCtx)
+	}()
+	defer func() {
+		stopRelay()
+		<-relayDone
+	}()
 
 	// Создаем клиента к inventory service
+	inventoryCreds, err := cfg.Inventory.TLS.GRPCCredentials()
+	if err != nil {
+		slog.Error("failed to load inventory service tls config", logger.Err(err))
+		return
+	}
+
 	inventoryConn, err := grpc.NewClient(
-		inventoryServerAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		cfg.Inventory.Address,
+		grpc.WithTransportCredentials(inventoryCreds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.WithUnaryInterceptor(logger.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(logger.StreamClientInterceptor()),
 	)
 	if err != nil {
-		log.Printf("failed to connect to inventory service: %v\n", err)
+		slog.Error("failed to connect to inventory service", logger.Err(err))
 		return
 	}
 	defer func() {
 		if cerr := inventoryConn.Close(); cerr != nil {
-			log.Printf("failed to close connect: %v", cerr)
+			slog.Error("failed to close connection", logger.Err(cerr))
 		}
 	}()
 
 	inventoryClient := inventoryV1.NewInventoryServiceClient(inventoryConn)
 
 	// Создаем клиента к payment service
+	paymentCreds, err := cfg.Payment.TLS.GRPCCredentials()
+	if err != nil {
+		slog.Error("failed to load payment service tls config", logger.Err(err))
+		return
+	}
+
 	paymentConn, err := grpc.NewClient(
-		paymentServerAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		cfg.Payment.Address,
+		grpc.WithTransportCredentials(paymentCreds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.WithUnaryInterceptor(logger.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(logger.StreamClientInterceptor()),
 	)
 	if err != nil {
-		log.Printf("failed to connect to payment service: %v\n", err)
+		slog.Error("failed to connect to payment service", logger.Err(err))
 		return
 	}
 	defer func() {
 		if cerr := paymentConn.Close(); cerr != nil {
-			log.Printf("failed to close connect: %v", cerr)
+			slog.Error("failed to close connection", logger.Err(cerr))
 		}
 	}()
 
 	paymentClient := paymentV1.NewPaymentServiceClient(paymentConn)
 
 	// Создаем обработчик API заказов
-	orderHandler := NewOrderHandler(storage, inventoryClient, paymentClient)
+	orderHandler := NewOrderHandler(
+		stores.orders,
+		stores.promos,
+		stores.sagas,
+		inventoryClient,
+		paymentClient,
+		orderMetrics,
+		cfg.Inventory.Timeout,
+		cfg.Payment.Timeout,
+	)
 
-	orderServer, err := orderV1.NewServer(orderHandler)
+	// Продолжаем саги, прерванные сбоем шага или остановкой реплики
+	sagaCtx, stopSagas := context.WithCancel(context.Background())
+	sagasDone := make(chan struct{})
+	go func() {
+		defer close(sagasDone)
+		orderHandler.ResumeSagas(sagaCtx)
+	}()
+	defer func() {
+		stopSagas()
+		<-sagasDone
+	}()
+
+	// Отменяем заказы, не оплаченные в течение cfg.PaymentTTL
+	expiryCtx, stopExpiry := context.WithCancel(context.Background())
+	expiryDone := make(chan struct{})
+	go func() {
+		defer close(expiryDone)
+		orderHandler.ExpireUnpaidOrders(expiryCtx, cfg.PaymentTTL)
+	}()
+	defer func() {
+		stopExpiry()
+		<-expiryDone
+	}()
+
+	orderServer, err := orderV1.NewServer(orderHandler,
+		orderV1.WithTracerProvider(otel.GetTracerProvider()),
+		orderV1.WithMeterProvider(otel.GetMeterProvider()),
+	)
 	if err != nil {
-		log.Printf("Ошибка создания сервера OpenAPI: %v", err)
+		slog.Error("failed to create openapi server", logger.Err(err))
 		return
 	}
 
+	// Проверка готовности опрашивает inventory service и payment service через уже созданные соединения
+	healthChecker := NewHealthChecker(
+		healthpb.NewHealthClient(inventoryConn),
+		healthpb.NewHealthClient(paymentConn),
+		stores.ping,
+	)
+
 	// Инициализируем роутер Chi
 	r := chi.NewRouter()
 
-	// Добавляем middleware
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(10 * time.Second))
+	// Проверки живости и готовности обслуживаются без middleware API,
+	// чтобы частые запросы оркестратора не попадали в логи и трассы
+	r.Get("/healthz", healthChecker.Live)
+	r.Get("/readyz", healthChecker.Ready)
+
+	// Добавляем middleware API. Span запроса начинается до остальных middleware,
+	// чтобы в трассу попали и запросы, отклоненные до обработчика OpenAPI
+	api := chi.NewRouter()
+	api.Use(otelhttp.NewMiddleware("order", otelhttp.WithSpanNameFormatter(httpSpanName(orderServer))))
+	api.Use(logger.HTTPMiddleware)
+	api.Use(middleware.Timeout(cfg.HTTP.RequestTimeout))
+	api.Use(idempotency.Middleware(stores.idempotency, cfg.IdempotencyTTL, cfg.IdempotencyStaleTimeout))
 
 	// Монтируем обработчик OpenAPI
-	r.Mount("/", orderServer)
+	api.Mount("/", orderServer)
+	r.Mount("/", api)
 
 	// Запускаем HTTP-сервер
 	server := &http.Server{
-		Addr:              net.JoinHostPort("localhost", httpPort),
+		Addr:              cfg.HTTP.Address,
 		Handler:           r,
-		ReadHeaderTimeout: readHeaderTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		TLSConfig:         serverTLS,
 	}
 
 	// Запускаем сервер в отдельной горутине
 	go func() {
-		log.Printf("🚀 HTTP-сервер запущен на порту %s\n", httpPort)
-		err = server.ListenAndServe()
+		slog.Info("http server listening", slog.String("address", cfg.HTTP.Address))
+		if serverTLS != nil {
+			// Сертификат уже загружен в TLSConfig
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Printf("❌ Ошибка запуска сервера: %v\n", err)
+			slog.Error("failed to serve", logger.Err(err))
+		}
+	}()
+
+	// Запускаем сервер метрик
+	metricsServer := sharedMetrics.NewServer(cfg.Metrics.Address, metricsHandler)
+	go func() {
+		slog.Info("metrics server listening", slog.String("address", cfg.Metrics.Address))
+		if serr := metricsServer.ListenAndServe(); serr != nil && !errors.Is(serr, http.ErrServerClosed) {
+			slog.Error("failed to serve metrics", logger.Err(serr))
 		}
 	}()
 
@@ -367,30 +967,303 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("🛑 Завершение работы сервера...")
+	// Сначала перестаем проходить проверку готовности и ждем, пока балансировщик уберет сервер из ротации,
+	// затем завершаем обрабатываемые запросы. Повторный сигнал прерывает ожидание
+	healthChecker.Shutdown()
+	slog.Info("draining http server", slog.String("drain_delay", cfg.HTTP.DrainDelay.String()))
+
+	drain := time.NewTimer(cfg.HTTP.DrainDelay)
+	select {
+	case <-drain.C:
+	case sig := <-quit:
+		drain.Stop()
+		slog.Warn("drain interrupted", slog.String("signal", sig.String()))
+	}
+
+	slog.Info("shutting down http server")
 
 	// Создаем контекст с таймаутом для остановки сервера
-	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 	defer cancel()
 
 	err = server.Shutdown(ctx)
 	if err != nil {
-		log.Printf("❌ Ошибка при остановке сервера: %v\n", err)
+		slog.Error("failed to shut down http server", logger.Err(err))
+	}
+
+	if err = metricsServer.Close(); err != nil {
+		slog.Error("failed to close metrics server", logger.Err(err))
+	}
+
+	slog.Info("server stopped")
+}
+
+// httpSpanName возвращает имя span HTTP запроса из метода и шаблона пути операции OpenAPI,
+// для путей вне API — только из метода, чтобы имена span не зависели от идентификаторов в пути
+func httpSpanName(server *orderV1.Server) func(string, *http.Request) string {
+	return func(_ string, r *http.Request) string {
+		if route, ok := server.FindRoute(r.Method, r.URL.Path); ok {
+			return r.Method + " " + route.PathPattern()
+		}
+
+		return r.Method
+	}
+}
+
+// newStorages создает хранилища выбранного в конфигурации типа,
+// для PostgreSQL перед началом работы применяются миграции
+func newStorages(ctx context.Context, cfg sharedConfig.Storage) (*storages, func(), error) {
+	switch cfg.Type {
+	case sharedConfig.StorageTypeInMem:
+		slog.Info("using in-memory order storage")
+		orders := storage.NewOrderStorageInMem()
+		return withTracing(&storages{
+			orders:      orders,
+			idempotency: storage.NewIdempotencyStorageInMem(),
+			promos:      storage.NewPromoStorageInMem(),
+			outbox:      orders,
+			sagas:       storage.NewSagaStorageInMem(),
+			ping:        func(context.Context) error { return nil },
+		}), func() {}, nil
+	case sharedConfig.StorageTypePostgres:
+		pool, err := pgxpool.New(ctx, cfg.PostgresDSN)
+		if err != nil {
+			return nil, nil, fmt.Errorf("connect to postgres: %w", err)
+		}
+
+		if err = pool.Ping(ctx); err != nil {
+			pool.Close()
+			return nil, nil, fmt.Errorf("ping postgres: %w", err)
+		}
+
+		if err = storage.Migrate(ctx, pool); err != nil {
+			pool.Close()
+			return nil, nil, err
+		}
+
+		slog.Info("using postgres order storage")
+		orders := storage.NewOrderStoragePostgres(pool)
+		return withTracing(&storages{
+			orders:      orders,
+			idempotency: storage.NewIdempotencyStoragePostgres(pool),
+			promos:      storage.NewPromoStoragePostgres(pool),
+			outbox:      orders,
+			sagas:       storage.NewSagaStoragePostgres(pool),
+			ping:        pool.Ping,
+		}), pool.Close, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown storage type %q", cfg.Type)
+}
+
+// withTracing оборачивает хранилища трассировкой обращений. Проверка доступности не трассируется,
+// чтобы частые проверки готовности не засоряли трассы
+func withTracing(s *storages) *storages {
+	return &storages{
+		orders:      storage.OrderStorageWithTracing(s.orders),
+		idempotency: storage.IdempotencyStorageWithTracing(s.idempotency),
+		promos:      storage.PromoStorageWithTracing(s.promos),
+		outbox:      storage.OutboxStorageWithTracing(s.outbox),
+		sagas:       storage.SagaStorageWithTracing(s.sagas),
+		ping:        s.ping,
+	}
+}
+
+// newEventsPublisher создает адаптер брокера событий выбранного в конфигурации типа
+func newEventsPublisher(cfg config.Events) (broker.Publisher, error) {
+	switch cfg.Broker {
+	case config.EventsBrokerMemory:
+		slog.Info("order events are kept in memory")
+		return broker.NewMemoryPublisher(), nil
+	case config.EventsBrokerFile:
+		publisher, err := broker.NewFilePublisher(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+
+		slog.Info("order events are written to file", slog.String("path", cfg.File))
+		return publisher, nil
+	case config.EventsBrokerKafka:
+		slog.Info("order events are published to kafka", slog.Any("brokers", cfg.KafkaBrokers))
+		return broker.NewKafkaPublisher(cfg.KafkaBrokers), nil
 	}
 
-	log.Println("✅ Сервер остановлен")
+	return nil, fmt.Errorf("unknown events broker %q", cfg.Broker)
+}
+
+// sweepIdempotencyKeys периодически удаляет просроченные ключи идемпотентности
+func sweepIdempotencyKeys(ctx context.Context, store storage.IdempotencyStorage) {
+	ticker := time.NewTicker(idempotencySweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			deleted, err := store.DeleteExpiredIdempotencyKeys(ctx, now)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to delete expired idempotency keys", logger.Err(err))
+				continue
+			}
+
+			if deleted > 0 {
+				slog.InfoContext(ctx, "deleted expired idempotency keys", slog.Int64("count", deleted))
+			}
+		}
+	}
+}
+
+// pageToken содержимое курсора страницы списка заказов. Вместе с позицией последнего заказа
+// сохраняется сортировка, чтобы курсор нельзя было применить к запросу с другим порядком
+type pageToken struct {
+	SortBy     storage.OrderSortField `json:"sort_by"`
+	Descending bool                   `json:"desc"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+	TotalPrice int64                  `json:"total_price"`
+	OrderUuid  string                 `json:"order_uuid"`
+}
+
+// encodePageToken создает курсор страницы, начинающейся после заказа
+func encodePageToken(query storage.OrderQuery, last *orderV1.OrderDto) (string, error) {
+	cursor := storage.CursorOf(last)
+
+	data, err := json.Marshal(pageToken{
+		SortBy:     query.SortBy,
+		Descending: query.Descending,
+		CreatedAt:  cursor.CreatedAt,
+		UpdatedAt:  cursor.UpdatedAt,
+		TotalPrice: cursor.TotalPriceMinor,
+		OrderUuid:  cursor.OrderUuid,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken разбирает курсор страницы и проверяет, что он выдан для той же сортировки
+func decodePageToken(token string, query storage.OrderQuery) (*storage.OrderCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid page_token")
+	}
+
+	var t pageToken
+	if err = json.Unmarshal(data, &t); err != nil || t.OrderUuid == "" {
+		return nil, errors.New("invalid page_token")
+	}
+
+	if t.SortBy != query.SortBy || t.Descending != query.Descending {
+		return nil, errors.New("page_token was issued for a different sorting")
+	}
+
+	return &storage.OrderCursor{
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+		TotalPriceMinor: t.TotalPrice,
+		OrderUuid:       t.OrderUuid,
+	}, nil
 }
 
 // containsPart ищет запчасть по uuid и возвращает ее
 func containsPart(partUuid string, parts []*inventoryV1.Part) *inventoryV1.Part {
 	for _, p := range parts {
-		if p.Uuid == partUuid && p.StockQuantity > 0 {
+		if p.Uuid == partUuid {
 			return p
 		}
 	}
 	return nil
 }
 
+// requestItems собирает позиции заказа из запроса, поддерживая устаревший плоский список деталей,
+// в котором каждая деталь заказывается в количестве одной штуки. Одинаковые детали объединяются в одну позицию
+func requestItems(req *orderV1.CreateOrderRequest) ([]orderV1.OrderItem, error) {
+	legacyPartUuids := req.PartUuids //nolint:staticcheck // поддерживаем устаревший формат на время перехода на items
+
+	if len(req.Items) > 0 && len(legacyPartUuids) > 0 {
+		return nil, errors.New("items and part_uuids cannot be used together")
+	}
+
+	requested := req.Items
+	for _, partUuid := range legacyPartUuids {
+		requested = append(requested, orderV1.OrderItem{PartUUID: partUuid, Quantity: 1})
+	}
+
+	if len(requested) == 0 {
+		return nil, errors.New("details not provided")
+	}
+
+	items := make([]orderV1.OrderItem, 0, len(requested))
+	indexByPart := make(map[string]int, len(requested))
+	for _, item := range requested {
+		i, ok := indexByPart[item.PartUUID]
+		if !ok {
+			indexByPart[item.PartUUID] = len(items)
+			items = append(items, item)
+			continue
+		}
+
+		if items[i].Quantity > math.MaxInt64-item.Quantity {
+			return nil, errors.New("quantity of part " + item.PartUUID + " is too large")
+		}
+		items[i].Quantity += item.Quantity
+	}
+
+	return items, nil
+}
+
+// addOrderLine добавляет в заказ позицию с ценой детали на момент создания заказа.
+// Валюта заказа определяется первой деталью, детали в разных валютах в одном заказе не допускаются
+func addOrderLine(order *orderV1.OrderDto, item orderV1.OrderItem, part *inventoryV1.Part) error {
+	currency := part.GetCurrency()
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+
+	if order.Currency == "" {
+		order.Currency = currency
+	}
+	if order.Currency != currency {
+		return fmt.Errorf("parts priced in %s and %s cannot be ordered together", order.Currency, currency)
+	}
+
+	lineTotal, err := money.Mul(part.GetPriceMinor(), item.Quantity)
+	if err != nil {
+		return errors.New("cost of part " + item.PartUUID + " is too large")
+	}
+
+	subtotal, err := money.Add(order.SubtotalMinor, lineTotal)
+	if err != nil {
+		return errors.New("order total is too large")
+	}
+
+	order.SubtotalMinor = subtotal
+	order.Items = append(order.Items, orderV1.OrderLine{
+		PartUUID:       item.PartUUID,
+		Quantity:       item.Quantity,
+		UnitPriceMinor: orderV1.NewOptInt64(part.GetPriceMinor()),
+		LineTotalMinor: orderV1.NewOptInt64(lineTotal),
+	})
+
+	return nil
+}
+
+// reservationItems преобразует позиции заказа в позиции резерва
+func reservationItems(items []orderV1.OrderLine) []*inventoryV1.ReservationItem {
+	reservation := make([]*inventoryV1.ReservationItem, 0, len(items))
+	for _, item := range items {
+		reservation = append(reservation, &inventoryV1.ReservationItem{
+			PartUuid: item.PartUUID,
+			Quantity: item.Quantity,
+		})
+	}
+
+	return reservation
+}
+
 // convertPaymentMethod преобразует enum сгенерированный openapi в enum сгенерированный из proto
 func convertPaymentMethod(method orderV1.PaymentMethod) paymentV1.PaymentMethod {
 	switch method {
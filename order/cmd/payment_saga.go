@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Igorezka/rocket-factory/order/internal/metrics"
+	"github.com/Igorezka/rocket-factory/order/internal/saga"
+	"github.com/Igorezka/rocket-factory/order/internal/statemachine"
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+// paySagaType сага оплаты заказа: списание средств, подтверждение резерва деталей и перевод заказа в PAID.
+// Если после списания заказ не удается перевести в PAID, детали возвращаются на склад, средства
+// возвращаются покупателю, а заказ отменяется
+const paySagaType = "pay_order"
+
+// errOrderNotPayable заказ больше не ожидает оплаты
+var errOrderNotPayable = errors.New("order is not pending payment")
+
+// paySagaSteps возвращает шаги саги оплаты заказа
+func (h *OrderHandler) paySagaSteps() []saga.Step {
+	return []saga.Step{
+		{Name: "check order", Action: h.checkOrderPayable, Compensate: h.cancelRefundedOrder},
+		{Name: "charge payment", Action: h.chargePayment, Compensate: h.refundSagaPayment},
+		{Name: "commit reservation", Action: h.commitReservation, Compensate: h.returnSagaReservation},
+		{Name: "mark order paid", Action: h.markOrderPaid},
+	}
+}
+
+// checkOrderPayable проверяет, что заказ ожидает оплаты
+func (h *OrderHandler) checkOrderPayable(ctx context.Context, s *storage.Saga) error {
+	order, err := h.storage.GetOrder(ctx, s.OrderUuid)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrderNotFound) {
+			return saga.Permanent(err)
+		}
+
+		return err
+	}
+
+	if !statemachine.CanTransit(order.Status, orderV1.OrderStatusPAID) {
+		return saga.Permanent(fmt.Errorf("%w: status %s", errOrderNotPayable, order.Status))
+	}
+
+	return nil
+}
+
+// cancelRefundedOrder снимает резерв деталей и отменяет заказ, средства за который были списаны
+// и возвращены при компенсации. Если списания не было, заказ остается в ожидании оплаты
+// и его можно оплатить повторно
+func (h *OrderHandler) cancelRefundedOrder(ctx context.Context, s *storage.Saga) error {
+	if s.Data.TransactionUuid == "" {
+		return nil
+	}
+
+	order, err := h.storage.GetOrder(ctx, s.OrderUuid)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != orderV1.OrderStatusPENDINGPAYMENT {
+		return nil
+	}
+
+	if err = h.returnSagaReservation(ctx, s); err != nil {
+		return err
+	}
+
+	transition, err := statemachine.Transit(order, orderV1.OrderStatusCANCELLED, statemachine.ActorSystem,
+		"Order cancelled, payment "+s.Data.TransactionUuid+" refunded: "+s.Error)
+	if err != nil {
+		return saga.Permanent(err)
+	}
+
+	if err = h.storage.UpdateOrder(ctx, order, transition); err != nil {
+		return err
+	}
+
+	h.metrics.OrderCancelled(ctx, metrics.CancelReasonPaymentFailed)
+	h.releasePromoCode(ctx, order)
+
+	return nil
+}
+
+// chargePayment списывает средства через payment service. Оплата идемпотентна по uuid заказа,
+// поэтому повтор шага после сбоя не списывает средства повторно. Если ответ не получен,
+// результат оплаты выясняется отдельным запросом
+func (h *OrderHandler) chargePayment(ctx context.Context, s *storage.Saga) error {
+	payCtx, cancel := context.WithTimeout(ctx, h.paymentTimeout)
+	defer cancel()
+
+	res, err := h.paymentClient.PayOrder(payCtx, &paymentV1.PayOrderRequest{
+		OrderUuid:     s.OrderUuid,
+		UserUuid:      s.Data.UserUuid,
+		PaymentMethod: convertPaymentMethod(orderV1.PaymentMethod(s.Data.PaymentMethod)),
+		Amount:        s.Data.Amount,
+		Currency:      s.Data.Currency,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition, codes.InvalidArgument, codes.AlreadyExists:
+			return saga.Permanent(err)
+		}
+
+		return h.resolveSagaPayment(ctx, s, err)
+	}
+
+	s.Data.TransactionUuid = res.GetTransactionUuid()
+
+	return nil
+}
+
+// resolveSagaPayment выясняет результат оплаты, запрос которой завершился ошибкой: средства могли быть
+// списаны, даже если ответ не получен. Проведенная оплата сохраняется в саге, чтобы при компенсации
+// средства были возвращены. Если средства не списаны, шаг повторяется как обычно, а пока результат
+// неизвестен — без ограничения попыток, чтобы сага не компенсировала оплату, которая могла пройти
+func (h *OrderHandler) resolveSagaPayment(ctx context.Context, s *storage.Saga, cause error) error {
+	lookupCtx, cancel := context.WithTimeout(ctx, h.paymentTimeout)
+	defer cancel()
+
+	res, err := h.paymentClient.GetOrderPayment(lookupCtx, &paymentV1.GetOrderPaymentRequest{
+		OrderUuid: s.OrderUuid,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return cause
+		}
+
+		return saga.Indeterminate(fmt.Errorf("%w; get order payment: %w", cause, err))
+	}
+
+	if res.GetStatus() != paymentV1.TransactionStatus_TRANSACTION_STATUS_SUCCEEDED {
+		return saga.Indeterminate(fmt.Errorf("%w; transaction %s is %s", cause, res.GetTransactionUuid(), res.GetStatus()))
+	}
+
+	s.Data.TransactionUuid = res.GetTransactionUuid()
+
+	return nil
+}
+
+// refundSagaPayment возвращает списанные средства. Uuid возврата задан при создании саги,
+// поэтому повтор компенсации не возвращает средства повторно
+func (h *OrderHandler) refundSagaPayment(ctx context.Context, s *storage.Saga) error {
+	if s.Data.TransactionUuid == "" {
+		return nil
+	}
+
+	refundCtx, cancel := context.WithTimeout(ctx, h.paymentTimeout)
+	defer cancel()
+
+	_, err := h.paymentClient.RefundPayment(refundCtx, &paymentV1.RefundPaymentRequest{
+		RefundUuid:      s.Data.RefundUuid,
+		TransactionUuid: s.Data.TransactionUuid,
+		Reason:          "Payment of order cancelled: " + s.Error,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition, codes.InvalidArgument, codes.AlreadyExists, codes.NotFound:
+			return saga.Permanent(err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// commitReservation подтверждает резерв деталей оплаченного заказа. Резерв, который истек
+// или был снят, подтвердить нельзя, в этом случае оплата компенсируется
+func (h *OrderHandler) commitReservation(ctx context.Context, s *storage.Saga) error {
+	commitCtx, cancel := context.WithTimeout(ctx, h.inventoryTimeout)
+	defer cancel()
+
+	_, err := h.inventoryClient.CommitReservation(commitCtx, &inventoryV1.CommitReservationRequest{
+		OrderUuid: s.OrderUuid,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound, codes.FailedPrecondition:
+			return saga.Permanent(err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// returnSagaReservation возвращает детали резерва на склад, подтвержденный резерв переводится в RETURNED,
+// неподтвержденный снимается
+func (h *OrderHandler) returnSagaReservation(ctx context.Context, s *storage.Saga) error {
+	returnCtx, cancel := context.WithTimeout(ctx, h.inventoryTimeout)
+	defer cancel()
+
+	_, err := h.inventoryClient.ReturnReservation(returnCtx, &inventoryV1.ReturnReservationRequest{
+		OrderUuid: s.OrderUuid,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return err
+	}
+
+	return nil
+}
+
+// markOrderPaid сохраняет платежную информацию и переводит заказ в PAID.
+// Если заказ уже оплачен этой транзакцией, шаг считается выполненным
+func (h *OrderHandler) markOrderPaid(ctx context.Context, s *storage.Saga) error {
+	order, err := h.storage.GetOrder(ctx, s.OrderUuid)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrderNotFound) {
+			return saga.Permanent(err)
+		}
+
+		return err
+	}
+
+	if order.Status == orderV1.OrderStatusPAID && order.TransactionUUID.Value == s.Data.TransactionUuid {
+		return nil
+	}
+
+	order.TransactionUUID = orderV1.NewOptString(s.Data.TransactionUuid)
+	order.PaymentMethod = orderV1.NewOptPaymentMethod(orderV1.PaymentMethod(s.Data.PaymentMethod))
+
+	transition, err := statemachine.Transit(
+		order,
+		orderV1.OrderStatusPAID,
+		statemachine.UserActor(s.Data.UserUuid),
+		"Order paid, transaction "+s.Data.TransactionUuid,
+	)
+	if err != nil {
+		return saga.Permanent(err)
+	}
+
+	err = h.storage.UpdateOrder(ctx, order, transition)
+	if errors.Is(err, storage.ErrOrderStatusConflict) || errors.Is(err, storage.ErrOrderNotFound) {
+		return saga.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	h.metrics.OrderPaid(ctx)
+
+	return nil
+}
+
+// grpcStatus возвращает статус gRPC ошибки, в том числе обернутой в ошибку шага саги
+func grpcStatus(err error) (*status.Status, bool) {
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		return se.GRPCStatus(), true
+	}
+
+	return nil, false
+}
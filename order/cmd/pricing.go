@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Igorezka/rocket-factory/order/internal/pricing"
+	"github.com/Igorezka/rocket-factory/order/internal/statemachine"
+	"github.com/Igorezka/rocket-factory/shared/pkg/money"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// errPartNotFound деталь заказа больше не продается в inventory service, доставку заказа не рассчитать
+var errPartNotFound = errors.New("order part not found")
+
+// priceOrder рассчитывает доставку по весу и объему деталей, НДС страны доставки и итоговую стоимость заказа.
+// Позиции и скидка заказа должны быть уже рассчитаны
+func priceOrder(order *orderV1.OrderDto, parts []*inventoryV1.Part) error {
+	items := make([]pricing.Item, 0, len(order.Items))
+	for _, line := range order.Items {
+		part := containsPart(line.PartUUID, parts)
+		if part == nil {
+			return fmt.Errorf("%w: %s", errPartNotFound, line.PartUUID)
+		}
+
+		dimensions := part.GetDimensions()
+		items = append(items, pricing.Item{
+			LengthCm: dimensions.GetLength(),
+			WidthCm:  dimensions.GetWidth(),
+			HeightCm: dimensions.GetHeight(),
+			WeightKg: dimensions.GetWeight(),
+			Quantity: line.Quantity,
+		})
+	}
+
+	quote, err := pricing.Calculate(order.Country, order.Currency, order.SubtotalMinor-order.DiscountMinor, items)
+	if err != nil {
+		return err
+	}
+
+	total := order.SubtotalMinor - order.DiscountMinor
+	for _, amount := range []int64{quote.ShippingMinor, quote.TaxMinor} {
+		if total, err = money.Add(total, amount); err != nil {
+			return fmt.Errorf("order total is too large: %w", err)
+		}
+	}
+
+	order.ShippingWeightKg = quote.ShippingWeightKg
+	order.ShippingMinor = quote.ShippingMinor
+	order.TaxRateBp = quote.TaxRateBp
+	order.TaxMinor = quote.TaxMinor
+	order.TotalPriceMinor = total
+	order.TotalPrice = money.FromMinor(total, order.Currency) //nolint:staticcheck // заполняем устаревшее поле для старых клиентов
+
+	return nil
+}
+
+// repriceOrder пересчитывает доставку и НДС заказа по текущим размерам деталей перед оплатой.
+// Цены позиций и скидка не меняются. Если итоговая стоимость изменилась, заказ сохраняется
+// с записью в историю и возвращается true
+func (h *OrderHandler) repriceOrder(ctx context.Context, order *orderV1.OrderDto) (bool, error) {
+	listCtx, cancel := context.WithTimeout(ctx, h.inventoryTimeout)
+	defer cancel()
+
+	partUuids := make([]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		partUuids = append(partUuids, item.PartUUID)
+	}
+
+	parts, err := h.listParts(listCtx, partUuids)
+	if err != nil {
+		// Inventory service отвечает NotFound, если не нашлась ни одна деталь
+		if status.Code(err) == codes.NotFound {
+			return false, fmt.Errorf("%w: %s", errPartNotFound, strings.Join(partUuids, ", "))
+		}
+		return false, fmt.Errorf("list parts: %w", err)
+	}
+
+	previousTotal := order.TotalPriceMinor
+	if err = priceOrder(order, parts); err != nil {
+		return false, err
+	}
+
+	if order.TotalPriceMinor == previousTotal {
+		return false, nil
+	}
+
+	transition := statemachine.Amend(order, statemachine.ActorSystem,
+		fmt.Sprintf("Order repriced before payment, total %d -> %d %s", previousTotal, order.TotalPriceMinor, order.Currency))
+
+	if err = h.storage.UpdateOrder(ctx, order, transition); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// isPricingRejection проверяет, что стоимость заказа не рассчитать по его условиям: страна доставки
+// или валюта не поддерживаются, либо деталь заказа больше не продается. Повтор запроса не поможет
+func isPricingRejection(err error) bool {
+	return errors.Is(err, pricing.ErrUnsupportedCountry) ||
+		errors.Is(err, pricing.ErrUnsupportedCurrency) ||
+		errors.Is(err, errPartNotFound)
+}
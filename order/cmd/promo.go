@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Igorezka/rocket-factory/order/internal/promo"
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+)
+
+// ListPromoCodes обрабатывает запрос на получение всех промокодов
+func (h *OrderHandler) ListPromoCodes(ctx context.Context) (orderV1.ListPromoCodesRes, error) {
+	promoCodes, err := h.promos.ListPromoCodes(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list promo codes", logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	res := &orderV1.ListPromoCodesResponse{
+		PromoCodes: make([]orderV1.PromoCodeDto, 0, len(promoCodes)),
+	}
+	for _, promoCode := range promoCodes {
+		res.PromoCodes = append(res.PromoCodes, *promoCode)
+	}
+
+	return res, nil
+}
+
+// GetPromoCode обрабатывает запрос на получение промокода
+func (h *OrderHandler) GetPromoCode(ctx context.Context, params orderV1.GetPromoCodeParams) (orderV1.GetPromoCodeRes, error) {
+	code := promo.NormalizeCode(params.Code)
+
+	promoCode, err := h.promos.GetPromoCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, storage.ErrPromoCodeNotFound) {
+			return &orderV1.NotFoundError{
+				Code:    http.StatusNotFound,
+				Message: "Promo code " + code + " not found",
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to get promo code", slog.String("promo_code", code), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	return promoCode, nil
+}
+
+// PutPromoCode обрабатывает запрос на создание или замену промокода
+func (h *OrderHandler) PutPromoCode(
+	ctx context.Context,
+	req *orderV1.PromoCodeRequest,
+	params orderV1.PutPromoCodeParams,
+) (orderV1.PutPromoCodeRes, error) {
+	promoCode := &orderV1.PromoCodeDto{
+		Code:           promo.NormalizeCode(params.Code),
+		DiscountType:   req.DiscountType,
+		Percent:        req.Percent,
+		AmountMinor:    req.AmountMinor,
+		Currency:       req.Currency,
+		Category:       req.Category,
+		MaxUsesPerUser: req.MaxUsesPerUser.Or(0),
+		StartsAt:       req.StartsAt,
+		ExpiresAt:      req.ExpiresAt,
+		Active:         req.Active.Or(true),
+	}
+
+	if err := promo.Validate(promoCode); err != nil {
+		return &orderV1.BadRequestError{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		}, nil
+	}
+
+	stored, err := h.promos.PutPromoCode(ctx, promoCode)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to save promo code", slog.String("promo_code", promoCode.Code), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	return stored, nil
+}
+
+// DeletePromoCode обрабатывает запрос на удаление промокода, скидки в созданных заказах сохраняются
+func (h *OrderHandler) DeletePromoCode(ctx context.Context, params orderV1.DeletePromoCodeParams) (orderV1.DeletePromoCodeRes, error) {
+	code := promo.NormalizeCode(params.Code)
+
+	err := h.promos.DeletePromoCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, storage.ErrPromoCodeNotFound) {
+			return &orderV1.NotFoundError{
+				Code:    http.StatusNotFound,
+				Message: "Promo code " + code + " not found",
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to delete promo code", slog.String("promo_code", code), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	return &orderV1.DeletePromoCodeNoContent{}, nil
+}
+
+// applyPromoCode рассчитывает скидку промокода и распределяет ее по позициям заказа.
+// Ошибки, при которых промокод нельзя применить к заказу, проверяются через isPromoRejection
+func (h *OrderHandler) applyPromoCode(ctx context.Context, order *orderV1.OrderDto, code string, parts []*inventoryV1.Part) error {
+	promoCode, err := h.promos.GetPromoCode(ctx, promo.NormalizeCode(code))
+	if err != nil {
+		return err
+	}
+
+	lines := make([]promo.Line, 0, len(order.Items))
+	for _, item := range order.Items {
+		lines = append(lines, promo.Line{
+			Category:   orderV1.PartCategory(containsPart(item.PartUUID, parts).GetCategory().String()),
+			TotalMinor: item.LineTotalMinor.Value,
+		})
+	}
+
+	discounts, err := promo.Apply(promoCode, order.Currency, lines, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i, discount := range discounts {
+		if discount > 0 {
+			order.Items[i].DiscountMinor = orderV1.NewOptInt64(discount)
+			order.DiscountMinor += discount
+		}
+	}
+	order.PromoCode = orderV1.NewOptString(promoCode.Code)
+
+	return nil
+}
+
+// releasePromoCode отменяет применение промокода к заказу, ошибка только логируется
+func (h *OrderHandler) releasePromoCode(ctx context.Context, order *orderV1.OrderDto) {
+	if !order.PromoCode.Set {
+		return
+	}
+
+	if err := h.promos.ReleasePromoCode(ctx, order.OrderUUID); err != nil {
+		slog.ErrorContext(ctx, "failed to release promo code", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+	}
+}
+
+// isPromoRejection проверяет, что промокод не может быть применен к заказу по вине клиента
+func isPromoRejection(err error) bool {
+	return errors.Is(err, storage.ErrPromoCodeNotFound) ||
+		errors.Is(err, storage.ErrPromoCodeUsageLimitReached) ||
+		errors.Is(err, promo.ErrPromoCodeInactive) ||
+		errors.Is(err, promo.ErrPromoCodeNotStarted) ||
+		errors.Is(err, promo.ErrPromoCodeExpired) ||
+		errors.Is(err, promo.ErrPromoCodeNotApplicable)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Igorezka/rocket-factory/order/internal/statemachine"
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+	inventoryV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1"
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+// refundNamespace пространство имен uuid возвратов, получаемых из параметров запроса возврата
+var refundNamespace = uuid.MustParse("a91b9654-8f88-47b9-9cc3-ba27c5ecbe4d")
+
+// RefundOrder обрабатывает запрос на возврат средств по заказу. Частичный возврат записывается
+// в историю без смены статуса, после возврата всей суммы заказ переходит в REFUNDED,
+// а детали заказа возвращаются на склад
+func (h *OrderHandler) RefundOrder(
+	ctx context.Context,
+	req *orderV1.RefundOrderRequest,
+	params orderV1.RefundOrderParams,
+) (orderV1.RefundOrderRes, error) {
+	order, err := h.storage.GetOrder(ctx, params.OrderUUID)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrderNotFound) {
+			return &orderV1.NotFoundError{
+				Code:    http.StatusNotFound,
+				Message: "Order by UUID " + params.OrderUUID + " not found",
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to get order", slog.String("order_uuid", params.OrderUUID), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	transactionUuid, paid := order.TransactionUUID.Get()
+	if !paid || !statemachine.CanTransit(order.Status, orderV1.OrderStatusREFUNDED) {
+		return &orderV1.ConflictError{
+			Code:    http.StatusConflict,
+			Message: "Order UUID " + params.OrderUUID + " cannot be refunded in status " + string(order.Status),
+		}, nil
+	}
+
+	remaining := order.TotalPriceMinor - order.RefundedMinor
+	if amount, ok := req.AmountMinor.Get(); ok && amount > remaining {
+		return &orderV1.UnprocessableEntityError{
+			Code: http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf("Refund amount %d exceeds refundable amount %d %s minor units",
+				amount, remaining, order.Currency),
+		}, nil
+	}
+
+	// Возвращаем средства через payment service. Повтор запроса получает тот же uuid возврата,
+	// и payment service возвращает уже проведенный возврат вместо повторного зачисления
+	refundCtx, cancel := context.WithTimeout(ctx, h.paymentTimeout)
+	defer cancel()
+
+	res, err := h.paymentClient.RefundPayment(refundCtx, &paymentV1.RefundPaymentRequest{
+		RefundUuid:      refundUuid(order, params.IdempotencyKey.Or(""), req.AmountMinor.Or(0)),
+		TransactionUuid: transactionUuid,
+		Amount:          req.AmountMinor.Or(0),
+		Reason:          req.Reason.Or(""),
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			return &orderV1.PaymentRequiredError{
+				Code:    http.StatusPaymentRequired,
+				Message: status.Convert(err).Message(),
+			}, nil
+		case codes.AlreadyExists, codes.Aborted:
+			return &orderV1.ConflictError{
+				Code:    http.StatusConflict,
+				Message: status.Convert(err).Message(),
+			}, nil
+		}
+
+		slog.ErrorContext(ctx, "failed to refund order", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	// Сумму возвратов берем из payment service, он учитывает все проведенные по транзакции возвраты
+	order.RefundedMinor = res.GetRefundedTotal()
+
+	reason := req.Reason.Or("requested by user")
+	actor := statemachine.UserActor(order.UserUUID)
+
+	var transition *storage.OrderTransition
+	if res.GetRemaining() > 0 {
+		transition = statemachine.Amend(order, actor, fmt.Sprintf("Partial refund %d %s minor units, refund %s: %s",
+			res.GetAmount(), order.Currency, res.GetRefundUuid(), reason))
+	} else {
+		h.returnReservation(ctx, order.OrderUUID)
+
+		transition, err = statemachine.Transit(order, orderV1.OrderStatusREFUNDED, actor,
+			fmt.Sprintf("Order refunded, refund %s: %s", res.GetRefundUuid(), reason))
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to mark order as refunded", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+			return &orderV1.InternalServerError{
+				Code:    http.StatusInternalServerError,
+				Message: "Internal Server Error",
+			}, nil
+		}
+	}
+
+	err = h.storage.UpdateOrder(ctx, order, transition)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update refunded order", slog.String("order_uuid", order.OrderUUID), logger.Err(err))
+
+		if errors.Is(err, storage.ErrOrderStatusConflict) {
+			return &orderV1.ConflictError{
+				Code:    http.StatusConflict,
+				Message: "Order UUID " + params.OrderUUID + " status changed during refund",
+			}, nil
+		}
+
+		return &orderV1.InternalServerError{
+			Code:    http.StatusInternalServerError,
+			Message: "Internal Server Error",
+		}, nil
+	}
+
+	return &orderV1.RefundOrderResponse{
+		RefundUUID:     res.GetRefundUuid(),
+		AmountMinor:    res.GetAmount(),
+		RefundedMinor:  res.GetRefundedTotal(),
+		RemainingMinor: res.GetRemaining(),
+		Status:         order.Status,
+	}, nil
+}
+
+// refundUuid возвращает uuid возврата, одинаковый для повторов одного запроса. С ключом идемпотентности
+// uuid получается из ключа. Без ключа — из суммы уже проведенных возвратов заказа и запрошенной суммы:
+// повтор после сбоя до сохранения заказа получает тот же uuid, а после сохранения сумма возвратов
+// меняется и запрос считается новым возвратом, поэтому повторять частичный возврат безопасно только с ключом
+func refundUuid(order *orderV1.OrderDto, idempotencyKey string, amountMinor int64) string {
+	if idempotencyKey != "" {
+		return uuid.NewSHA1(refundNamespace, []byte(order.OrderUUID+"\nkey\n"+idempotencyKey)).String()
+	}
+
+	name := fmt.Sprintf("%s\nrefunded\n%d\n%d", order.OrderUUID, order.RefundedMinor, amountMinor)
+	return uuid.NewSHA1(refundNamespace, []byte(name)).String()
+}
+
+// returnReservation возвращает детали заказа на склад. Средства уже возвращены, поэтому ошибка
+// только логируется, у заказа могло не остаться резерва, если он истек до оплаты
+func (h *OrderHandler) returnReservation(ctx context.Context, orderUuid string) {
+	returnCtx, cancel := context.WithTimeout(ctx, h.inventoryTimeout)
+	defer cancel()
+
+	_, err := h.inventoryClient.ReturnReservation(returnCtx, &inventoryV1.ReturnReservationRequest{
+		OrderUuid: orderUuid,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		slog.ErrorContext(ctx, "failed to return reservation for refunded order", slog.String("order_uuid", orderUuid), logger.Err(err))
+	}
+}
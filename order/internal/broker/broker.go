@@ -0,0 +1,27 @@
+// Package broker содержит адаптеры брокеров сообщений, в которые order service публикует доменные события
+package broker
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClosed публикация в закрытый адаптер
+var ErrClosed = errors.New("publisher is closed")
+
+// Message сообщение для публикации
+type Message struct {
+	Topic string
+	// Key ключ партиционирования, сообщения с одинаковым ключом сохраняют порядок
+	Key     string
+	Value   []byte
+	Headers map[string]string
+}
+
+// Publisher описывает адаптер брокера сообщений
+type Publisher interface {
+	// Publish публикует сообщения и возвращает nil, только если брокер подтвердил запись всех сообщений.
+	// При ошибке часть сообщений могла быть записана, поэтому повтор публикации может создать дубликаты
+	Publish(ctx context.Context, messages []Message) error
+	Close() error
+}
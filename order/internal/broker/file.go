@@ -0,0 +1,90 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// fileRecord строка файла с опубликованным сообщением, значение сообщения кодируется в base64
+type fileRecord struct {
+	Topic       string            `json:"topic"`
+	Key         string            `json:"key"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Value       []byte            `json:"value"`
+	PublishedAt time.Time         `json:"published_at"`
+}
+
+// FilePublisher дописывает сообщения в файл в формате JSON Lines, используется для разработки и тестов
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFilePublisher создает адаптер, дописывающий сообщения в файл path
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open events file: %w", err)
+	}
+
+	return &FilePublisher{
+		file: file,
+	}, nil
+}
+
+// Publish дописывает сообщения в файл и сбрасывает его на диск
+func (p *FilePublisher) Publish(_ context.Context, messages []Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.file == nil {
+		return ErrClosed
+	}
+
+	now := time.Now().UTC()
+
+	var buf []byte
+	for _, m := range messages {
+		line, err := json.Marshal(fileRecord{
+			Topic:       m.Topic,
+			Key:         m.Key,
+			Headers:     m.Headers,
+			Value:       m.Value,
+			PublishedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal message: %w", err)
+		}
+
+		buf = append(append(buf, line...), '\n')
+	}
+
+	if _, err := p.file.Write(buf); err != nil {
+		return fmt.Errorf("write events file: %w", err)
+	}
+
+	if err := p.file.Sync(); err != nil {
+		return fmt.Errorf("sync events file: %w", err)
+	}
+
+	return nil
+}
+
+// Close закрывает файл
+func (p *FilePublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.file == nil {
+		return nil
+	}
+
+	err := p.file.Close()
+	p.file = nil
+
+	return err
+}
@@ -0,0 +1,61 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	p, err := NewFilePublisher(path)
+	if err != nil {
+		t.Fatalf("NewFilePublisher: %v", err)
+	}
+
+	messages := []Message{
+		{Topic: "orders", Key: "a", Value: []byte{0, 1}, Headers: map[string]string{"event-type": "created"}},
+		{Topic: "orders", Key: "b", Value: []byte("paid")},
+	}
+	if err = p.Publish(context.Background(), messages); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if err = p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err = p.Publish(context.Background(), messages); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Publish: got %v, want %v", err, ErrClosed)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer file.Close()
+
+	var records []fileRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record fileRecord
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != len(messages) {
+		t.Fatalf("Publish: got %d records, want %d", len(records), len(messages))
+	}
+	for i, record := range records {
+		m := messages[i]
+		if record.Topic != m.Topic || record.Key != m.Key || string(record.Value) != string(m.Value) ||
+			record.Headers["event-type"] != m.Headers["event-type"] || record.PublishedAt.IsZero() {
+			t.Fatalf("Publish: got record %+v, want message %+v", record, m)
+		}
+	}
+}
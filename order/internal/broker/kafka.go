@@ -0,0 +1,59 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaBatchTimeout время накопления пачки сообщений перед отправкой в Kafka
+const kafkaBatchTimeout = 10 * time.Millisecond
+
+// KafkaPublisher публикует сообщения в Kafka. Партиция выбирается по хешу ключа, запись
+// подтверждается всеми синхронными репликами партиции
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher создает адаптер Kafka для списка адресов брокеров
+func NewKafkaPublisher(brokers []string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchTimeout: kafkaBatchTimeout,
+		},
+	}
+}
+
+// Publish записывает сообщения в Kafka и ждет подтверждения
+func (p *KafkaPublisher) Publish(ctx context.Context, messages []Message) error {
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, m := range messages {
+		headers := make([]kafka.Header, 0, len(m.Headers))
+		for k, v := range m.Headers {
+			headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+
+		kafkaMessages = append(kafkaMessages, kafka.Message{
+			Topic:   m.Topic,
+			Key:     []byte(m.Key),
+			Value:   m.Value,
+			Headers: headers,
+		})
+	}
+
+	if err := p.writer.WriteMessages(ctx, kafkaMessages...); err != nil {
+		return fmt.Errorf("write messages to kafka: %w", err)
+	}
+
+	return nil
+}
+
+// Close отправляет накопленные сообщения и закрывает соединения с брокерами
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
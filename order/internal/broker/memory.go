@@ -0,0 +1,67 @@
+package broker
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// MemoryPublisher хранит опубликованные сообщения в памяти, используется для разработки и тестов
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+	closed   bool
+}
+
+// NewMemoryPublisher создает адаптер, хранящий сообщения в памяти
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Publish сохраняет копии сообщений
+func (p *MemoryPublisher) Publish(_ context.Context, messages []Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrClosed
+	}
+
+	for _, m := range messages {
+		p.messages = append(p.messages, copyMessage(m))
+	}
+
+	return nil
+}
+
+// Messages возвращает копии опубликованных сообщений в порядке публикации
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	messages := make([]Message, 0, len(p.messages))
+	for _, m := range p.messages {
+		messages = append(messages, copyMessage(m))
+	}
+
+	return messages
+}
+
+// Close закрывает адаптер, опубликованные сообщения остаются доступны
+func (p *MemoryPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+
+	return nil
+}
+
+// copyMessage возвращает глубокую копию сообщения
+func copyMessage(m Message) Message {
+	m.Value = slices.Clone(m.Value)
+	m.Headers = maps.Clone(m.Headers)
+
+	return m
+}
@@ -0,0 +1,40 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryPublisher(t *testing.T) {
+	p := NewMemoryPublisher()
+
+	value := []byte("value")
+	headers := map[string]string{"event-type": "created"}
+	if err := p.Publish(context.Background(), []Message{{Topic: "orders", Key: "k", Value: value, Headers: headers}}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	// Изменение исходного сообщения и полученных копий не меняет сохраненное
+	value[0] = 'V'
+	headers["event-type"] = "paid"
+	p.Messages()[0].Headers["event-type"] = "cancelled"
+
+	messages := p.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Messages: got %d messages, want 1", len(messages))
+	}
+	if string(messages[0].Value) != "value" || messages[0].Headers["event-type"] != "created" {
+		t.Fatalf("Messages: got value %q and headers %v", messages[0].Value, messages[0].Headers)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := p.Publish(context.Background(), []Message{{Topic: "orders"}}); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Publish: got %v, want %v", err, ErrClosed)
+	}
+	if got := len(p.Messages()); got != 1 {
+		t.Fatalf("Messages: got %d messages after close, want 1", got)
+	}
+}
@@ -0,0 +1,161 @@
+// Package config описывает конфигурацию order service
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	sharedConfig "github.com/Igorezka/rocket-factory/shared/pkg/config"
+)
+
+// EnvPrefix префикс переменных окружения order service
+const EnvPrefix = "ORDER"
+
+// Брокеры, в которые публикуются события заказов
+const (
+	// EventsBrokerMemory события только хранятся в памяти процесса
+	EventsBrokerMemory = "memory"
+	// EventsBrokerFile события записываются в файл в формате JSON Lines
+	EventsBrokerFile  = "file"
+	EventsBrokerKafka = "kafka"
+)
+
+// Config конфигурация order service
+type Config struct {
+	Log       sharedConfig.Log        `yaml:"log" env:"LOG"`
+	Tracing   sharedConfig.Tracing    `yaml:"tracing" env:"TRACING"`
+	Metrics   sharedConfig.Metrics    `yaml:"metrics" env:"METRICS"`
+	HTTP      sharedConfig.HTTPServer `yaml:"http" env:"HTTP"`
+	Inventory sharedConfig.GRPCClient `yaml:"inventory" env:"INVENTORY"`
+	Payment   sharedConfig.GRPCClient `yaml:"payment" env:"PAYMENT"`
+	Storage   sharedConfig.Storage    `yaml:"storage"`
+	// IdempotencyTTL время хранения ответов на запросы с ключом идемпотентности
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl" env:"IDEMPOTENCY_TTL"`
+	// IdempotencyStaleTimeout время, после которого незавершенная обработка запроса с ключом идемпотентности
+	// считается прерванной и ключ может занять повтор. Должно быть больше http.request_timeout,
+	// иначе повтор выполнится параллельно с еще идущим запросом
+	IdempotencyStaleTimeout time.Duration `yaml:"idempotency_stale_timeout" env:"IDEMPOTENCY_STALE_TIMEOUT"`
+	// PaymentTTL время, в течение которого заказ ожидает оплаты. Должно быть строго меньше ReservationTTL,
+	// иначе заказ можно оплатить после того, как его резерв истек
+	PaymentTTL time.Duration `yaml:"payment_ttl" env:"PAYMENT_TTL"`
+	// ReservationTTL время резерва деталей в inventory service. Должно совпадать с его reservation_ttl,
+	// используется только для проверки PaymentTTL
+	ReservationTTL time.Duration `yaml:"reservation_ttl" env:"RESERVATION_TTL"`
+	Events         Events        `yaml:"events"`
+}
+
+// Events настройки публикации событий заказов
+type Events struct {
+	// Broker брокер событий: memory, file или kafka
+	Broker string `yaml:"broker" env:"EVENTS_BROKER"`
+	// File файл событий для брокера file
+	File string `yaml:"file" env:"EVENTS_FILE"`
+	// KafkaBrokers адреса брокеров Kafka
+	KafkaBrokers []string `yaml:"kafka_brokers" env:"KAFKA_BROKERS"`
+}
+
+// Validate проверяет, что для выбранного брокера заданы его настройки
+func (e *Events) Validate() error {
+	switch e.Broker {
+	case EventsBrokerMemory:
+		return nil
+	case EventsBrokerFile:
+		if e.File == "" {
+			return fmt.Errorf("file must be set for %s broker", EventsBrokerFile)
+		}
+		return nil
+	case EventsBrokerKafka:
+		if len(e.KafkaBrokers) == 0 {
+			return fmt.Errorf("kafka_brokers must be set for %s broker", EventsBrokerKafka)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown events broker %q", e.Broker)
+}
+
+// Default возвращает конфигурацию по умолчанию
+func Default() *Config {
+	return &Config{
+		Log: sharedConfig.Log{
+			Level: "info",
+		},
+		Tracing: sharedConfig.Tracing{
+			Exporter:    sharedConfig.TracingExporterNone,
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			File:        "order-traces.jsonl",
+			SampleRatio: 1,
+		},
+		Metrics: sharedConfig.Metrics{
+			Address: "localhost:9090",
+		},
+		HTTP: sharedConfig.HTTPServer{
+			Address:           "localhost:8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			RequestTimeout:    10 * time.Second,
+			DrainDelay:        5 * time.Second,
+			ShutdownTimeout:   10 * time.Second,
+		},
+		Inventory: sharedConfig.GRPCClient{
+			Address: "localhost:50051",
+			Timeout: 2 * time.Second,
+		},
+		Payment: sharedConfig.GRPCClient{
+			Address: "localhost:50052",
+			Timeout: 10 * time.Second,
+		},
+		Storage: sharedConfig.Storage{
+			Type: sharedConfig.StorageTypeInMem,
+		},
+		IdempotencyTTL:          24 * time.Hour,
+		IdempotencyStaleTimeout: time.Minute,
+		// Меньше времени резерва деталей с запасом на оплату, начатую перед самой отменой заказа
+		PaymentTTL: 10 * time.Minute,
+		// Время резерва деталей в inventory service по умолчанию
+		ReservationTTL: 15 * time.Minute,
+		Events: Events{
+			Broker: EventsBrokerMemory,
+			File:   "order-events.jsonl",
+		},
+	}
+}
+
+// Load загружает конфигурацию из YAML файла ORDER_CONFIG_FILE и переменных окружения ORDER_*
+func Load() (*Config, error) {
+	cfg := Default()
+	if err := sharedConfig.Load(EnvPrefix, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate проверяет время хранения ответов, то, что ключ идемпотентности не освобождается, пока запрос
+// еще может обрабатываться, и то, что заказ отменяется раньше, чем истекает резерв его деталей
+func (c *Config) Validate() error {
+	var staleErr error
+	if c.IdempotencyStaleTimeout <= c.HTTP.RequestTimeout {
+		staleErr = fmt.Errorf("idempotency_stale_timeout (%s) must be greater than http.request_timeout (%s)",
+			c.IdempotencyStaleTimeout, c.HTTP.RequestTimeout)
+	}
+
+	var paymentErr error
+	if c.PaymentTTL >= c.ReservationTTL {
+		paymentErr = fmt.Errorf("payment_ttl (%s) must be less than reservation_ttl (%s)", c.PaymentTTL, c.ReservationTTL)
+	}
+
+	return errors.Join(
+		sharedConfig.Positive("idempotency_ttl", c.IdempotencyTTL),
+		staleErr,
+		sharedConfig.Positive("payment_ttl", c.PaymentTTL),
+		sharedConfig.Positive("reservation_ttl", c.ReservationTTL),
+		paymentErr,
+	)
+}
+
+// String возвращает итоговую конфигурацию в формате YAML
+func (c *Config) String() string {
+	return sharedConfig.Format(EnvPrefix, c)
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr bool
+	}{
+		{name: "default", modify: func(*Config) {}},
+		{name: "zero idempotency ttl", modify: func(c *Config) { c.IdempotencyTTL = 0 }, wantErr: true},
+		{
+			name:    "stale timeout equals request timeout",
+			modify:  func(c *Config) { c.IdempotencyStaleTimeout = c.HTTP.RequestTimeout },
+			wantErr: true,
+		},
+		{
+			name: "stale timeout below request timeout",
+			modify: func(c *Config) {
+				c.HTTP.RequestTimeout = 2 * time.Minute
+				c.IdempotencyStaleTimeout = time.Minute
+			},
+			wantErr: true,
+		},
+		{name: "zero payment ttl", modify: func(c *Config) { c.PaymentTTL = 0 }, wantErr: true},
+		{name: "zero reservation ttl", modify: func(c *Config) { c.ReservationTTL = 0 }, wantErr: true},
+		{
+			name:    "payment ttl equals reservation ttl",
+			modify:  func(c *Config) { c.PaymentTTL = c.ReservationTTL },
+			wantErr: true,
+		},
+		{
+			name: "payment ttl above reservation ttl",
+			modify: func(c *Config) {
+				c.PaymentTTL = 20 * time.Minute
+				c.ReservationTTL = 15 * time.Minute
+			},
+			wantErr: true,
+		},
+		{
+			name: "payment ttl below longer reservation ttl",
+			modify: func(c *Config) {
+				c.PaymentTTL = 20 * time.Minute
+				c.ReservationTTL = 30 * time.Minute
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr && err == nil {
+				t.Fatal("Validate: got nil, want error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+		})
+	}
+}
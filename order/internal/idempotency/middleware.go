@@ -0,0 +1,166 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+)
+
+const (
+	// HeaderKey заголовок с ключом идемпотентности
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed заголовок, которым помечается повторно отданный сохраненный ответ
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+	// maxBodySize ограничение на размер тела запроса с ключом идемпотентности
+	maxBodySize = 1 << 20
+)
+
+// errorResponse тело ошибки в формате API заказов
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Middleware делает POST-запросы с заголовком Idempotency-Key идемпотентными: первый запрос выполняется
+// и его ответ сохраняется на время ttl, повторы с тем же ключом и телом получают сохраненный ответ.
+// Повтор с тем же ключом, но другим запросом отклоняется с кодом 422, а повтор во время обработки
+// первого запроса — с кодом 409. Обработка, не завершившаяся за staleTimeout, считается прерванной,
+// и ключ может занять повтор. Ответы с кодами 409 и 5xx не сохраняются, чтобы запрос можно было повторить
+func Middleware(store storage.IdempotencyStorage, ttl, staleTimeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderKey)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxKeyLength {
+				writeError(r.Context(), w, http.StatusBadRequest, HeaderKey+" is too long")
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+			if err != nil {
+				writeError(r.Context(), w, http.StatusBadRequest, "failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			now := time.Now()
+			record := &storage.IdempotencyRecord{
+				Key:         key,
+				RequestHash: requestHash(r, body),
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(ttl),
+			}
+
+			existing, err := store.BeginIdempotentRequest(r.Context(), record, now.Add(-staleTimeout))
+			if err != nil {
+				slog.ErrorContext(r.Context(), "failed to acquire idempotency key", slog.String("idempotency_key", key), logger.Err(err))
+				writeError(r.Context(), w, http.StatusInternalServerError, "Internal Server Error")
+				return
+			}
+
+			if existing != nil {
+				replay(r.Context(), w, existing, record.RequestHash)
+				return
+			}
+
+			// Ответ сохраняется и после отмены запроса клиентом, иначе ключ останется занятым
+			storeCtx := context.WithoutCancel(r.Context())
+
+			completed := false
+			defer func() {
+				if !completed {
+					abort(storeCtx, store, key)
+				}
+			}()
+
+			var buf bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+
+			next.ServeHTTP(ww, r)
+
+			if !storable(ww.Status()) {
+				return
+			}
+
+			err = store.CompleteIdempotentRequest(storeCtx, key, ww.Status(), ww.Header().Get("Content-Type"), buf.Bytes())
+			if err != nil {
+				slog.ErrorContext(storeCtx, "failed to save idempotent response", slog.String("idempotency_key", key), logger.Err(err))
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// storable проверяет, что ответ с кодом status сохраняется для повторов. Конфликт описывает временное
+// состояние заказа, например идущую оплату или изменившуюся стоимость, и повтор должен выполниться заново
+func storable(status int) bool {
+	return status != http.StatusConflict && status < http.StatusInternalServerError
+}
+
+// replay отдает сохраненный ответ или ошибку, если повтор не совпадает с исходным запросом
+func replay(ctx context.Context, w http.ResponseWriter, record *storage.IdempotencyRecord, requestHash string) {
+	if record.RequestHash != requestHash {
+		writeError(ctx, w, http.StatusUnprocessableEntity, HeaderKey+" is already used with a different request")
+		return
+	}
+
+	if record.InProgress() {
+		writeError(ctx, w, http.StatusConflict, "Request with this "+HeaderKey+" is still being processed")
+		return
+	}
+
+	if record.ContentType != "" {
+		w.Header().Set("Content-Type", record.ContentType)
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(record.StatusCode)
+
+	if _, err := w.Write(record.Body); err != nil {
+		slog.ErrorContext(ctx, "failed to write replayed response", slog.String("idempotency_key", record.Key), logger.Err(err))
+	}
+}
+
+// abort освобождает ключ, чтобы неудавшийся запрос можно было повторить
+func abort(ctx context.Context, store storage.IdempotencyStorage, key string) {
+	if err := store.AbortIdempotentRequest(ctx, key); err != nil {
+		slog.ErrorContext(ctx, "failed to release idempotency key", slog.String("idempotency_key", key), logger.Err(err))
+	}
+}
+
+// requestHash считает отпечаток запроса по методу, пути и телу
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeError пишет ошибку в формате API заказов
+func writeError(ctx context.Context, w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(errorResponse{Code: code, Message: message}); err != nil {
+		slog.ErrorContext(ctx, "failed to write error response", logger.Err(err))
+	}
+}
@@ -0,0 +1,146 @@
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+)
+
+// countingHandler отвечает кодом status и считает вызовы
+type countingHandler struct {
+	status int
+	calls  atomic.Int32
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	n := h.calls.Add(1)
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(h.status)
+	_, _ = w.Write([]byte("call " + strconv.Itoa(int(n))))
+}
+
+// send выполняет запрос через обработчик и возвращает ответ
+func send(handler http.Handler, method, key, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "/api/v1/orders", strings.NewReader(body))
+	if key != "" {
+		r.Header.Set(HeaderKey, key)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestMiddlewareStoresResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantCalls int32
+	}{
+		{name: "success is replayed", status: http.StatusCreated, wantCalls: 1},
+		{name: "client error is replayed", status: http.StatusUnprocessableEntity, wantCalls: 1},
+		{name: "conflict is executed again", status: http.StatusConflict, wantCalls: 2},
+		{name: "server error is executed again", status: http.StatusServiceUnavailable, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &countingHandler{status: tt.status}
+			handler := Middleware(storage.NewIdempotencyStorageInMem(), time.Hour, time.Minute)(next)
+
+			first := send(handler, http.MethodPost, "key", `{"a":1}`)
+			second := send(handler, http.MethodPost, "key", `{"a":1}`)
+
+			if got := next.calls.Load(); got != tt.wantCalls {
+				t.Fatalf("Middleware: handler called %d times, want %d", got, tt.wantCalls)
+			}
+			if first.Code != tt.status || second.Code != tt.status {
+				t.Fatalf("Middleware: got statuses %d and %d, want %d", first.Code, second.Code, tt.status)
+			}
+
+			replayed := second.Header().Get(HeaderReplayed) == "true"
+			if replayed != (tt.wantCalls == 1) {
+				t.Fatalf("Middleware: got %s %v, want %v", HeaderReplayed, replayed, tt.wantCalls == 1)
+			}
+			if replayed && second.Body.String() != first.Body.String() {
+				t.Fatalf("Middleware: got replayed body %q, want %q", second.Body.String(), first.Body.String())
+			}
+		})
+	}
+}
+
+func TestMiddlewareRejectsOtherRequestWithSameKey(t *testing.T) {
+	next := &countingHandler{status: http.StatusOK}
+	handler := Middleware(storage.NewIdempotencyStorageInMem(), time.Hour, time.Minute)(next)
+
+	send(handler, http.MethodPost, "key", `{"a":1}`)
+	if w := send(handler, http.MethodPost, "key", `{"a":2}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Middleware: got %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if got := next.calls.Load(); got != 1 {
+		t.Fatalf("Middleware: handler called %d times, want 1", got)
+	}
+}
+
+func TestMiddlewareSkipsRequestsWithoutKey(t *testing.T) {
+	next := &countingHandler{status: http.StatusOK}
+	handler := Middleware(storage.NewIdempotencyStorageInMem(), time.Hour, time.Minute)(next)
+
+	send(handler, http.MethodPost, "", `{}`)
+	send(handler, http.MethodPost, "", `{}`)
+	send(handler, http.MethodGet, "key", "")
+	send(handler, http.MethodGet, "key", "")
+
+	if got := next.calls.Load(); got != 4 {
+		t.Fatalf("Middleware: handler called %d times, want 4", got)
+	}
+
+	if w := send(handler, http.MethodPost, strings.Repeat("k", maxKeyLength+1), `{}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("Middleware: got %d for too long key, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestMiddlewareInProgress(t *testing.T) {
+	tests := []struct {
+		name         string
+		staleTimeout time.Duration
+		wantStatus   int
+	}{
+		{name: "repeat is rejected while first request runs", staleTimeout: time.Minute, wantStatus: http.StatusConflict},
+		{name: "stale request is taken over", staleTimeout: 0, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				handler http.Handler
+				repeat  *httptest.ResponseRecorder
+				started bool
+			)
+
+			// Повтор отправляется, пока первый запрос еще обрабатывается
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if !started {
+					started = true
+					repeat = send(handler, http.MethodPost, "key", `{}`)
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+			handler = Middleware(storage.NewIdempotencyStorageInMem(), time.Hour, tt.staleTimeout)(next)
+
+			if w := send(handler, http.MethodPost, "key", `{}`); w.Code != http.StatusOK {
+				t.Fatalf("Middleware: got %d for first request, want %d", w.Code, http.StatusOK)
+			}
+			if repeat.Code != tt.wantStatus {
+				t.Fatalf("Middleware: got %d for repeat, want %d", repeat.Code, tt.wantStatus)
+			}
+		})
+	}
+}
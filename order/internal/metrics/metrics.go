@@ -0,0 +1,77 @@
+// Package metrics описывает бизнес-метрики order service: созданные, оплаченные и отмененные заказы
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// CancelReason причина отмены заказа в метрике отмененных заказов
+type CancelReason string
+
+const (
+	// CancelReasonUser заказ отменен пользователем
+	CancelReasonUser CancelReason = "user"
+	// CancelReasonPaymentFailed оплата заказа не завершилась и списанные средства возвращены
+	CancelReasonPaymentFailed CancelReason = "payment_failed"
+	// CancelReasonExpired заказ не оплачен вовремя
+	CancelReasonExpired CancelReason = "expired"
+)
+
+// Metrics бизнес-метрики order service
+type Metrics struct {
+	created   metric.Int64Counter
+	paid      metric.Int64Counter
+	cancelled metric.Int64Counter
+}
+
+// New создает метрики order service в провайдере метрик по умолчанию
+func New() (*Metrics, error) {
+	meter := otel.Meter("github.com/Igorezka/rocket-factory/order/internal/metrics")
+
+	var (
+		m   Metrics
+		err error
+	)
+
+	m.created, err = meter.Int64Counter("orders.created",
+		metric.WithDescription("Orders created"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.paid, err = meter.Int64Counter("orders.paid",
+		metric.WithDescription("Orders paid"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.cancelled, err = meter.Int64Counter("orders.cancelled",
+		metric.WithDescription("Orders cancelled by reason"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// OrderCreated учитывает созданный заказ
+func (m *Metrics) OrderCreated(ctx context.Context) {
+	m.created.Add(ctx, 1)
+}
+
+// OrderPaid учитывает оплаченный заказ
+func (m *Metrics) OrderPaid(ctx context.Context) {
+	m.paid.Add(ctx, 1)
+}
+
+// OrderCancelled учитывает отмененный заказ
+func (m *Metrics) OrderCancelled(ctx context.Context, reason CancelReason) {
+	m.cancelled.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", string(reason))))
+}
@@ -0,0 +1,131 @@
+// Package outbox переносит доменные события из outbox хранилища заказов в брокер сообщений
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Igorezka/rocket-factory/order/internal/broker"
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+)
+
+const (
+	// pollInterval интервал проверки outbox на новые сообщения
+	pollInterval = 500 * time.Millisecond
+	// batchSize максимальное количество сообщений в одной публикации
+	batchSize = 100
+	// claimLease время, на которое реплика занимает сообщения. Публикация ограничена половиной аренды,
+	// чтобы сообщения не были заняты другой репликой, пока эта еще ждет ответа брокера
+	claimLease = 30 * time.Second
+	// retention время хранения опубликованных сообщений
+	retention = 24 * time.Hour
+	// sweepInterval интервал удаления опубликованных сообщений
+	sweepInterval = 10 * time.Minute
+)
+
+// Заголовки публикуемых сообщений
+const (
+	HeaderMessageUuid = "message-uuid"
+	HeaderEventType   = "event-type"
+	HeaderContentType = "content-type"
+
+	contentTypeProtobuf = "application/x-protobuf"
+)
+
+// Relay периодически публикует неопубликованные сообщения outbox. Сообщение отмечается опубликованным
+// только после подтверждения брокера, поэтому при сбое оно будет опубликовано повторно
+type Relay struct {
+	storage   storage.OutboxStorage
+	publisher broker.Publisher
+}
+
+// NewRelay создает relay сообщений из outbox в брокер
+func NewRelay(outboxStorage storage.OutboxStorage, publisher broker.Publisher) *Relay {
+	return &Relay{
+		storage:   outboxStorage,
+		publisher: publisher,
+	}
+}
+
+// Run публикует сообщения, пока не будет отменен контекст
+func (r *Relay) Run(ctx context.Context) {
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+
+	sweep := time.NewTicker(sweepInterval)
+	defer sweep.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+			r.drain(ctx)
+		case now := <-sweep.C:
+			deleted, err := r.storage.DeletePublishedOutboxMessages(ctx, now.Add(-retention))
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to delete published outbox messages", logger.Err(err))
+				continue
+			}
+
+			if deleted > 0 {
+				slog.InfoContext(ctx, "deleted published outbox messages", slog.Int64("count", deleted))
+			}
+		}
+	}
+}
+
+// drain публикует сообщения пачками, пока outbox не опустеет или публикация не завершится ошибкой
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		published, err := r.publishBatch(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to publish outbox messages", logger.Err(err))
+			return
+		}
+
+		if published < batchSize {
+			return
+		}
+	}
+}
+
+// publishBatch занимает и публикует одну пачку сообщений и возвращает их количество
+func (r *Relay) publishBatch(ctx context.Context) (int, error) {
+	messages, err := r.storage.ClaimOutboxMessages(ctx, time.Now(), claimLease, batchSize)
+	if err != nil || len(messages) == 0 {
+		return 0, err
+	}
+
+	batch := make([]broker.Message, 0, len(messages))
+	uuids := make([]string, 0, len(messages))
+	for _, m := range messages {
+		batch = append(batch, broker.Message{
+			Topic: m.Topic,
+			Key:   m.Key,
+			Value: m.Payload,
+			Headers: map[string]string{
+				HeaderMessageUuid: m.Uuid,
+				HeaderEventType:   m.EventType,
+				HeaderContentType: contentTypeProtobuf,
+			},
+		})
+		uuids = append(uuids, m.Uuid)
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, claimLease/2)
+	defer cancel()
+
+	if err = r.publisher.Publish(publishCtx, batch); err != nil {
+		return 0, err
+	}
+
+	// Сообщения уже в брокере, поэтому отметку сохраняем даже при остановке relay
+	if err = r.storage.MarkOutboxMessagesPublished(context.WithoutCancel(ctx), uuids, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return len(messages), nil
+}
@@ -0,0 +1,170 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Igorezka/rocket-factory/order/internal/broker"
+	"github.com/Igorezka/rocket-factory/order/internal/statemachine"
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+var errBrokerUnavailable = errors.New("broker unavailable")
+
+// flakyPublisher отклоняет первые failures публикаций, а затем сохраняет сообщения в памяти
+type flakyPublisher struct {
+	*broker.MemoryPublisher
+	failures int
+	attempts int
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, messages []broker.Message) error {
+	p.attempts++
+	if p.failures > 0 {
+		p.failures--
+		return errBrokerUnavailable
+	}
+
+	return p.MemoryPublisher.Publish(ctx, messages)
+}
+
+// shiftedStorage занимает сообщения outbox так, будто прошло shift времени
+type shiftedStorage struct {
+	storage.OutboxStorage
+	shift time.Duration
+}
+
+func (s *shiftedStorage) ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*storage.OutboxMessage, error) {
+	return s.OutboxStorage.ClaimOutboxMessages(ctx, now.Add(s.shift), lease, limit)
+}
+
+// createOrders создает n заказов, каждый из которых записывает в outbox событие о создании
+func createOrders(t *testing.T, orderStorage *storage.OrderStorageInMem, n int) []string {
+	t.Helper()
+
+	uuids := make([]string, 0, n)
+	for i := range n {
+		order := &orderV1.OrderDto{
+			OrderUUID: "order-" + strconv.Itoa(i),
+			UserUUID:  "user",
+			Currency:  "RUB",
+		}
+		transition := statemachine.Create(order, statemachine.ActorSystem, "Order created")
+		if err := orderStorage.CreateOrder(context.Background(), order, transition); err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+		uuids = append(uuids, order.OrderUUID)
+	}
+
+	return uuids
+}
+
+func TestRelayPublishesAndMarksMessages(t *testing.T) {
+	orderStorage := storage.NewOrderStorageInMem()
+	orderUuids := createOrders(t, orderStorage, 2)
+	publisher := broker.NewMemoryPublisher()
+	relay := NewRelay(orderStorage, publisher)
+
+	published, err := relay.publishBatch(context.Background())
+	if err != nil {
+		t.Fatalf("publishBatch: %v", err)
+	}
+	if published != 2 {
+		t.Fatalf("publishBatch: got %d messages, want 2", published)
+	}
+
+	messages := publisher.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("Messages: got %d messages, want 2", len(messages))
+	}
+	for i, m := range messages {
+		if m.Topic != storage.OrderEventsTopic || m.Key != orderUuids[i] {
+			t.Fatalf("Messages: got topic %q and key %q, want %q and %q", m.Topic, m.Key, storage.OrderEventsTopic, orderUuids[i])
+		}
+		if m.Headers[HeaderMessageUuid] == "" || m.Headers[HeaderEventType] == "" || m.Headers[HeaderContentType] != contentTypeProtobuf {
+			t.Fatalf("Messages: got headers %v", m.Headers)
+		}
+		if len(m.Value) == 0 {
+			t.Fatal("Messages: got empty value")
+		}
+	}
+
+	// Опубликованные сообщения отмечены и не публикуются повторно даже после окончания аренды
+	relay.storage = &shiftedStorage{OutboxStorage: orderStorage, shift: claimLease}
+	if published, err = relay.publishBatch(context.Background()); err != nil || published != 0 {
+		t.Fatalf("publishBatch: got %d, %v, want 0, nil", published, err)
+	}
+	if got := len(publisher.Messages()); got != 2 {
+		t.Fatalf("Messages: got %d messages, want 2", got)
+	}
+}
+
+func TestRelayRetriesAfterPublishFailure(t *testing.T) {
+	orderStorage := storage.NewOrderStorageInMem()
+	createOrders(t, orderStorage, 2)
+	publisher := &flakyPublisher{MemoryPublisher: broker.NewMemoryPublisher(), failures: 1}
+	outboxStorage := &shiftedStorage{OutboxStorage: orderStorage}
+	relay := NewRelay(outboxStorage, publisher)
+
+	if _, err := relay.publishBatch(context.Background()); !errors.Is(err, errBrokerUnavailable) {
+		t.Fatalf("publishBatch: got %v, want %v", err, errBrokerUnavailable)
+	}
+
+	// Сообщения остаются занятыми до окончания аренды, поэтому сразу не повторяются
+	published, err := relay.publishBatch(context.Background())
+	if err != nil || published != 0 {
+		t.Fatalf("publishBatch: got %d, %v, want 0, nil", published, err)
+	}
+	if publisher.attempts != 1 {
+		t.Fatalf("Publish: got %d attempts, want 1", publisher.attempts)
+	}
+
+	outboxStorage.shift = claimLease
+	if published, err = relay.publishBatch(context.Background()); err != nil || published != 2 {
+		t.Fatalf("publishBatch: got %d, %v, want 2, nil", published, err)
+	}
+	if got := len(publisher.Messages()); got != 2 {
+		t.Fatalf("Messages: got %d messages, want 2", got)
+	}
+
+	outboxStorage.shift = 2 * claimLease
+	if published, err = relay.publishBatch(context.Background()); err != nil || published != 0 {
+		t.Fatalf("publishBatch: got %d, %v, want 0, nil", published, err)
+	}
+}
+
+func TestRelayDrainPublishesAllBatches(t *testing.T) {
+	orderStorage := storage.NewOrderStorageInMem()
+	createOrders(t, orderStorage, batchSize+1)
+	publisher := broker.NewMemoryPublisher()
+
+	NewRelay(orderStorage, publisher).drain(context.Background())
+
+	messages := publisher.Messages()
+	if len(messages) != batchSize+1 {
+		t.Fatalf("drain: got %d messages, want %d", len(messages), batchSize+1)
+	}
+	// Сообщения публикуются в порядке записи
+	if messages[batchSize].Key != "order-"+strconv.Itoa(batchSize) {
+		t.Fatalf("drain: got last key %q, want %q", messages[batchSize].Key, "order-"+strconv.Itoa(batchSize))
+	}
+}
+
+func TestRelayDrainStopsOnPublishFailure(t *testing.T) {
+	orderStorage := storage.NewOrderStorageInMem()
+	createOrders(t, orderStorage, batchSize+1)
+	publisher := &flakyPublisher{MemoryPublisher: broker.NewMemoryPublisher(), failures: 1}
+
+	NewRelay(orderStorage, publisher).drain(context.Background())
+
+	if publisher.attempts != 1 {
+		t.Fatalf("drain: got %d attempts, want 1", publisher.attempts)
+	}
+	if got := len(publisher.Messages()); got != 0 {
+		t.Fatalf("drain: got %d messages, want 0", got)
+	}
+}
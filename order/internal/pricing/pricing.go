@@ -0,0 +1,190 @@
+// Package pricing рассчитывает надбавки к стоимости заказа: доставку по весу и объему деталей
+// и НДС по стране покупателя. Цены деталей считаются без НДС
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/Igorezka/rocket-factory/shared/pkg/money"
+)
+
+// DefaultCountry страна доставки, если покупатель ее не указал
+const DefaultCountry = "RU"
+
+// Currency валюта тарифов доставки. Заказы в других валютах не принимаются, пока для них нет тарифов
+const Currency = "RUB"
+
+const (
+	// volumetricDivisor объем отправления в см³, соответствующий одному килограмму объемного веса
+	volumetricDivisor = 5000
+	// basisPointsScale количество базисных пунктов в единице, ставка 2200 означает 22%
+	basisPointsScale = 10000
+	gramsPerKg       = 1000
+)
+
+var (
+	ErrUnsupportedCountry = errors.New("delivery to the country is not supported")
+	// ErrUnsupportedCurrency доставка не тарифицируется в валюте заказа
+	ErrUnsupportedCurrency = errors.New("orders are accepted only in " + Currency)
+	ErrInvalidItem         = errors.New("invalid item dimensions")
+)
+
+// tariff тариф доставки в валюте Currency: фиксированная часть и стоимость каждого начатого килограмма
+type tariff struct {
+	baseMinor int64
+	perKg     int64
+}
+
+var (
+	domesticTariff      = tariff{baseMinor: 50000, perKg: 3000}
+	eaeuTariff          = tariff{baseMinor: 150000, perKg: 12000}
+	internationalTariff = tariff{baseMinor: 400000, perKg: 35000}
+)
+
+// country ставка НДС и тариф доставки страны
+type country struct {
+	vatBasisPoints int32
+	tariff         tariff
+}
+
+// countries страны, в которые выполняется доставка
+var countries = map[string]country{
+	"RU": {vatBasisPoints: 2200, tariff: domesticTariff},
+	"BY": {vatBasisPoints: 2000, tariff: eaeuTariff},
+	"KZ": {vatBasisPoints: 1200, tariff: eaeuTariff},
+	"AM": {vatBasisPoints: 2000, tariff: eaeuTariff},
+	"KG": {vatBasisPoints: 1200, tariff: eaeuTariff},
+	"AE": {vatBasisPoints: 500, tariff: internationalTariff},
+	"CN": {vatBasisPoints: 1300, tariff: internationalTariff},
+	"DE": {vatBasisPoints: 1900, tariff: internationalTariff},
+	"ES": {vatBasisPoints: 2100, tariff: internationalTariff},
+	"FR": {vatBasisPoints: 2000, tariff: internationalTariff},
+	"GB": {vatBasisPoints: 2000, tariff: internationalTariff},
+	"IN": {vatBasisPoints: 1800, tariff: internationalTariff},
+	"IT": {vatBasisPoints: 2200, tariff: internationalTariff},
+	"JP": {vatBasisPoints: 1000, tariff: internationalTariff},
+	"TR": {vatBasisPoints: 2000, tariff: internationalTariff},
+	"US": {vatBasisPoints: 0, tariff: internationalTariff},
+}
+
+// Item позиция отправления, размеры указаны для одной детали
+type Item struct {
+	LengthCm float64
+	WidthCm  float64
+	HeightCm float64
+	WeightKg float64
+	Quantity int64
+}
+
+// Quote надбавки к стоимости заказа
+type Quote struct {
+	// ShippingWeightKg расчетный вес отправления, по нему начисляется доставка за каждый начатый килограмм
+	ShippingWeightKg float64
+	ShippingMinor    int64
+	TaxRateBp        int32
+	// TaxMinor НДС на стоимость позиций со скидкой и доставку
+	TaxMinor int64
+}
+
+// Calculate рассчитывает доставку и НДС заказа стоимостью goodsMinor (с учетом скидки) в валюте currency
+func Calculate(countryCode, currency string, goodsMinor int64, items []Item) (Quote, error) {
+	if err := CheckCurrency(currency); err != nil {
+		return Quote{}, err
+	}
+
+	c, ok := countries[countryCode]
+	if !ok {
+		return Quote{}, fmt.Errorf("%w: %s", ErrUnsupportedCountry, countryCode)
+	}
+
+	weight, err := chargeableWeight(items)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	shipping, err := c.tariff.cost(weight)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	taxBase, err := money.Add(goodsMinor, shipping)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	return Quote{
+		ShippingWeightKg: weight,
+		ShippingMinor:    shipping,
+		TaxRateBp:        c.vatBasisPoints,
+		TaxMinor:         applyRate(taxBase, c.vatBasisPoints),
+	}, nil
+}
+
+// CheckCurrency проверяет, что доставка тарифицируется в валюте заказа
+func CheckCurrency(currency string) error {
+	if currency != Currency {
+		return fmt.Errorf("%w, parts are priced in %s", ErrUnsupportedCurrency, currency)
+	}
+
+	return nil
+}
+
+// chargeableWeight возвращает расчетный вес отправления: для каждой детали берется
+// наибольший из фактического и объемного веса
+func chargeableWeight(items []Item) (float64, error) {
+	var total float64
+	for _, item := range items {
+		// Каждый параметр проверяется отдельно: отрицательный вес скрылся бы за объемным весом,
+		// а произведение двух отрицательных размеров положительно
+		if item.WeightKg < 0 || item.LengthCm < 0 || item.WidthCm < 0 || item.HeightCm < 0 || item.Quantity < 0 {
+			return 0, ErrInvalidItem
+		}
+
+		volumetric := item.LengthCm * item.WidthCm * item.HeightCm / volumetricDivisor
+		weight := max(item.WeightKg, volumetric)
+		if math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return 0, ErrInvalidItem
+		}
+
+		total += weight * float64(item.Quantity)
+	}
+
+	if math.IsInf(total, 0) {
+		return 0, money.ErrAmountOverflow
+	}
+
+	// Вес отправления определяется с точностью до грамма
+	return math.Round(total*gramsPerKg) / gramsPerKg, nil
+}
+
+// cost возвращает стоимость доставки отправления, каждый начатый килограмм оплачивается полностью
+func (t tariff) cost(weightKg float64) (int64, error) {
+	kg := math.Ceil(weightKg)
+	if kg >= math.MaxInt64 {
+		return 0, money.ErrAmountOverflow
+	}
+
+	perWeight, err := money.Mul(t.perKg, int64(kg))
+	if err != nil {
+		return 0, err
+	}
+
+	return money.Add(t.baseMinor, perWeight)
+}
+
+// applyRate возвращает долю rate базисных пунктов от суммы с округлением до ближайшего без переполнения
+func applyRate(minor int64, rate int32) int64 {
+	if minor <= 0 || rate <= 0 {
+		return 0
+	}
+
+	hi, lo := bits.Mul64(uint64(minor), uint64(rate))
+	lo, carry := bits.Add64(lo, basisPointsScale/2, 0)
+	hi += carry
+	q, _ := bits.Div64(hi, lo, basisPointsScale)
+
+	return int64(q) //nolint:gosec // ставка не больше 100%, результат не больше minor
+}
@@ -0,0 +1,172 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Igorezka/rocket-factory/shared/pkg/money"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name     string
+		country  string
+		currency string
+		goods    int64
+		items    []Item
+		want     Quote
+		wantErr  error
+	}{
+		{
+			name:     "domestic by actual weight",
+			country:  "RU",
+			currency: "RUB",
+			goods:    100000,
+			items:    []Item{{LengthCm: 10, WidthCm: 10, HeightCm: 10, WeightKg: 2.3, Quantity: 2}},
+			want:     Quote{ShippingWeightKg: 4.6, ShippingMinor: 65000, TaxRateBp: 2200, TaxMinor: 36300},
+		},
+		{
+			name:     "volumetric weight exceeds actual",
+			country:  "RU",
+			currency: "RUB",
+			items:    []Item{{LengthCm: 100, WidthCm: 50, HeightCm: 40, WeightKg: 5, Quantity: 1}},
+			want:     Quote{ShippingWeightKg: 40, ShippingMinor: 170000, TaxRateBp: 2200, TaxMinor: 37400},
+		},
+		{
+			name:     "started kilogram is charged in full",
+			country:  "KZ",
+			currency: "RUB",
+			goods:    10000,
+			items:    []Item{{WeightKg: 1.0006, Quantity: 1}},
+			want:     Quote{ShippingWeightKg: 1.001, ShippingMinor: 174000, TaxRateBp: 1200, TaxMinor: 22080},
+		},
+		{
+			name:     "weight is rounded to gram",
+			country:  "RU",
+			currency: "RUB",
+			items:    []Item{{WeightKg: 1.0004, Quantity: 1}},
+			want:     Quote{ShippingWeightKg: 1, ShippingMinor: 53000, TaxRateBp: 2200, TaxMinor: 11660},
+		},
+		{
+			name:     "no items pays base tariff",
+			country:  "DE",
+			currency: "RUB",
+			goods:    1000,
+			want:     Quote{ShippingMinor: 400000, TaxRateBp: 1900, TaxMinor: 76190},
+		},
+		{
+			name:     "zero vat",
+			country:  "US",
+			currency: "RUB",
+			goods:    1000,
+			items:    []Item{{WeightKg: 0.5, Quantity: 1}},
+			want:     Quote{ShippingWeightKg: 0.5, ShippingMinor: 435000},
+		},
+		{
+			name:     "unsupported country",
+			country:  "XX",
+			currency: "RUB",
+			wantErr:  ErrUnsupportedCountry,
+		},
+		{
+			name:     "order in other currency",
+			country:  "RU",
+			currency: "USD",
+			wantErr:  ErrUnsupportedCurrency,
+		},
+		{
+			name:     "currency is checked before country",
+			country:  "XX",
+			currency: "USD",
+			wantErr:  ErrUnsupportedCurrency,
+		},
+		{
+			name:     "negative weight",
+			country:  "RU",
+			currency: "RUB",
+			items:    []Item{{WeightKg: -1, Quantity: 1}},
+			wantErr:  ErrInvalidItem,
+		},
+		{
+			name:     "negative dimensions",
+			country:  "RU",
+			currency: "RUB",
+			items:    []Item{{LengthCm: -100, WidthCm: -100, HeightCm: 100, WeightKg: 1, Quantity: 1}},
+			wantErr:  ErrInvalidItem,
+		},
+		{
+			name:     "negative quantity",
+			country:  "RU",
+			currency: "RUB",
+			items:    []Item{{WeightKg: 1, Quantity: -1}},
+			wantErr:  ErrInvalidItem,
+		},
+		{
+			name:     "not a number weight",
+			country:  "RU",
+			currency: "RUB",
+			items:    []Item{{WeightKg: math.NaN(), Quantity: 1}},
+			wantErr:  ErrInvalidItem,
+		},
+		{
+			name:     "infinite total weight",
+			country:  "RU",
+			currency: "RUB",
+			items:    []Item{{WeightKg: math.MaxFloat64, Quantity: 2}},
+			wantErr:  money.ErrAmountOverflow,
+		},
+		{
+			name:     "shipping overflow",
+			country:  "RU",
+			currency: "RUB",
+			items:    []Item{{WeightKg: 1e18, Quantity: 1}},
+			wantErr:  money.ErrAmountOverflow,
+		},
+		{
+			name:     "tax base overflow",
+			country:  "RU",
+			currency: "RUB",
+			goods:    math.MaxInt64,
+			wantErr:  money.ErrAmountOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Calculate(tt.country, tt.currency, tt.goods, tt.items)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Calculate: got %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Calculate: got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRate(t *testing.T) {
+	tests := []struct {
+		minor int64
+		rate  int32
+		want  int64
+	}{
+		{minor: 12345, rate: 2200, want: 2716},
+		{minor: 1, rate: 5000, want: 1},
+		{minor: 1, rate: 4999, want: 0},
+		{minor: 25, rate: 2000, want: 5},
+		{minor: 3, rate: 1250, want: 0},
+		{minor: 4, rate: 1250, want: 1},
+		{minor: math.MaxInt64, rate: basisPointsScale, want: math.MaxInt64},
+		{minor: math.MaxInt64, rate: 2200, want: 2029141848108050678},
+		{minor: 0, rate: 2200, want: 0},
+		{minor: -100, rate: 2200, want: 0},
+		{minor: 100, rate: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := applyRate(tt.minor, tt.rate); got != tt.want {
+			t.Errorf("applyRate(%d, %d): got %d, want %d", tt.minor, tt.rate, got, tt.want)
+		}
+	}
+}
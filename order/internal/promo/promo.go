@@ -0,0 +1,166 @@
+package promo
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Igorezka/rocket-factory/shared/pkg/money"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+var (
+	// ErrInvalidPromoCode параметры промокода некорректны
+	ErrInvalidPromoCode = errors.New("invalid promo code")
+
+	ErrPromoCodeInactive   = errors.New("promo code is not active")
+	ErrPromoCodeNotStarted = errors.New("promo code is not active yet")
+	ErrPromoCodeExpired    = errors.New("promo code has expired")
+	// ErrPromoCodeNotApplicable в заказе нет позиций, на которые действует промокод
+	ErrPromoCodeNotApplicable = errors.New("promo code is not applicable to the order")
+)
+
+// codePattern допустимый формат промокода после приведения к верхнему регистру
+var codePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// Line позиция заказа, к которой может быть применена скидка
+type Line struct {
+	Category orderV1.PartCategory
+	// TotalMinor стоимость позиции без скидки в минимальных единицах валюты заказа
+	TotalMinor int64
+}
+
+// NormalizeCode приводит промокод к каноническому виду, регистр промокода не учитывается
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate проверяет согласованность параметров промокода перед сохранением
+func Validate(promo *orderV1.PromoCodeDto) error {
+	if !codePattern.MatchString(promo.Code) {
+		return fmt.Errorf("%w: code must be 3-32 latin letters, digits, '-' or '_'", ErrInvalidPromoCode)
+	}
+
+	switch promo.DiscountType {
+	case orderV1.DiscountTypePERCENTAGE:
+		percent, ok := promo.Percent.Get()
+		if !ok || percent < 1 || percent > 100 {
+			return fmt.Errorf("%w: percent from 1 to 100 is required for %s discount", ErrInvalidPromoCode, promo.DiscountType)
+		}
+		if promo.AmountMinor.Set || promo.Currency.Set {
+			return fmt.Errorf("%w: amount_minor and currency are not used for %s discount", ErrInvalidPromoCode, promo.DiscountType)
+		}
+	case orderV1.DiscountTypeFIXED:
+		amount, ok := promo.AmountMinor.Get()
+		if !ok || amount < 1 {
+			return fmt.Errorf("%w: positive amount_minor is required for %s discount", ErrInvalidPromoCode, promo.DiscountType)
+		}
+		if currency, ok := promo.Currency.Get(); !ok || !money.ValidCurrency(currency) {
+			return fmt.Errorf("%w: currency is required for %s discount", ErrInvalidPromoCode, promo.DiscountType)
+		}
+		if promo.Percent.Set {
+			return fmt.Errorf("%w: percent is not used for %s discount", ErrInvalidPromoCode, promo.DiscountType)
+		}
+	default:
+		return fmt.Errorf("%w: unknown discount type %q", ErrInvalidPromoCode, promo.DiscountType)
+	}
+
+	if promo.MaxUsesPerUser < 0 {
+		return fmt.Errorf("%w: max_uses_per_user must be non-negative", ErrInvalidPromoCode)
+	}
+
+	startsAt, hasStart := promo.StartsAt.Get()
+	expiresAt, hasExpiry := promo.ExpiresAt.Get()
+	if hasStart && hasExpiry && !startsAt.Before(expiresAt) {
+		return fmt.Errorf("%w: starts_at must be before expires_at", ErrInvalidPromoCode)
+	}
+
+	return nil
+}
+
+// CheckActive проверяет, что промокод включен и действует в момент now
+func CheckActive(promo *orderV1.PromoCodeDto, now time.Time) error {
+	if !promo.Active {
+		return ErrPromoCodeInactive
+	}
+	if startsAt, ok := promo.StartsAt.Get(); ok && now.Before(startsAt) {
+		return ErrPromoCodeNotStarted
+	}
+	if expiresAt, ok := promo.ExpiresAt.Get(); ok && !now.Before(expiresAt) {
+		return ErrPromoCodeExpired
+	}
+
+	return nil
+}
+
+// Apply рассчитывает скидку промокода для каждой позиции заказа в валюте currency.
+// Процентная скидка округляется вниз для каждой позиции. Фиксированная скидка распределяется
+// по подходящим позициям пропорционально их стоимости и не превышает их суммарную стоимость
+func Apply(promo *orderV1.PromoCodeDto, currency string, lines []Line, now time.Time) ([]int64, error) {
+	if err := CheckActive(promo, now); err != nil {
+		return nil, err
+	}
+
+	discounts := make([]int64, len(lines))
+
+	var eligible []int
+	var eligibleTotal int64
+	for i, line := range lines {
+		if category, ok := promo.Category.Get(); ok && line.Category != category {
+			continue
+		}
+		if line.TotalMinor <= 0 {
+			continue
+		}
+
+		sum, err := money.Add(eligibleTotal, line.TotalMinor)
+		if err != nil {
+			return nil, err
+		}
+		eligibleTotal = sum
+		eligible = append(eligible, i)
+	}
+
+	if len(eligible) == 0 {
+		return nil, ErrPromoCodeNotApplicable
+	}
+
+	switch promo.DiscountType {
+	case orderV1.DiscountTypePERCENTAGE:
+		percent := int64(promo.Percent.Value)
+		for _, i := range eligible {
+			total := lines[i].TotalMinor
+			// Делим до умножения, чтобы не переполнить int64 на больших суммах
+			discounts[i] = total/100*percent + total%100*percent/100
+		}
+	case orderV1.DiscountTypeFIXED:
+		if promo.Currency.Value != currency {
+			return nil, fmt.Errorf("%w: discount is in %s, order is in %s", ErrPromoCodeNotApplicable, promo.Currency.Value, currency)
+		}
+
+		amount := min(promo.AmountMinor.Value, eligibleTotal)
+		distributed := int64(0)
+		for _, i := range eligible {
+			discounts[i] = proportion(amount, lines[i].TotalMinor, eligibleTotal)
+			distributed += discounts[i]
+		}
+		// Остаток от округления относим на последнюю подходящую позицию, он меньше количества позиций
+		discounts[eligible[len(eligible)-1]] += amount - distributed
+	default:
+		return nil, fmt.Errorf("%w: unknown discount type %q", ErrInvalidPromoCode, promo.DiscountType)
+	}
+
+	return discounts, nil
+}
+
+// proportion возвращает amount * part / total с округлением вниз без переполнения.
+// Все значения неотрицательны, part и amount не больше total
+func proportion(amount, part, total int64) int64 {
+	hi, lo := bits.Mul64(uint64(amount), uint64(part))
+	q, _ := bits.Div64(hi, lo, uint64(total))
+
+	return int64(q) //nolint:gosec // результат не больше amount
+}
@@ -0,0 +1,286 @@
+package promo
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+var now = time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// percentPromo возвращает активный процентный промокод
+func percentPromo(percent int32) *orderV1.PromoCodeDto {
+	return &orderV1.PromoCodeDto{
+		Code:         "SPRING",
+		DiscountType: orderV1.DiscountTypePERCENTAGE,
+		Percent:      orderV1.NewOptInt32(percent),
+		Active:       true,
+	}
+}
+
+// fixedPromo возвращает активный промокод с фиксированной скидкой
+func fixedPromo(amount int64, currency string) *orderV1.PromoCodeDto {
+	return &orderV1.PromoCodeDto{
+		Code:         "MINUS",
+		DiscountType: orderV1.DiscountTypeFIXED,
+		AmountMinor:  orderV1.NewOptInt64(amount),
+		Currency:     orderV1.NewOptString(currency),
+		Active:       true,
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	if got := NormalizeCode("  spring_2026 "); got != "SPRING_2026" {
+		t.Fatalf("NormalizeCode: got %q, want %q", got, "SPRING_2026")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		promo   func() *orderV1.PromoCodeDto
+		wantErr bool
+	}{
+		{name: "percentage", promo: func() *orderV1.PromoCodeDto { return percentPromo(100) }},
+		{name: "fixed", promo: func() *orderV1.PromoCodeDto { return fixedPromo(500, "RUB") }},
+		{
+			name: "lowercase code",
+			promo: func() *orderV1.PromoCodeDto {
+				p := percentPromo(10)
+				p.Code = "spring"
+				return p
+			},
+			wantErr: true,
+		},
+		{
+			name: "too short code",
+			promo: func() *orderV1.PromoCodeDto {
+				p := percentPromo(10)
+				p.Code = "AB"
+				return p
+			},
+			wantErr: true,
+		},
+		{name: "zero percent", promo: func() *orderV1.PromoCodeDto { return percentPromo(0) }, wantErr: true},
+		{name: "percent over 100", promo: func() *orderV1.PromoCodeDto { return percentPromo(101) }, wantErr: true},
+		{
+			name: "percentage with amount",
+			promo: func() *orderV1.PromoCodeDto {
+				p := percentPromo(10)
+				p.AmountMinor = orderV1.NewOptInt64(100)
+				return p
+			},
+			wantErr: true,
+		},
+		{name: "zero amount", promo: func() *orderV1.PromoCodeDto { return fixedPromo(0, "RUB") }, wantErr: true},
+		{name: "invalid currency", promo: func() *orderV1.PromoCodeDto { return fixedPromo(100, "rub") }, wantErr: true},
+		{
+			name: "fixed with percent",
+			promo: func() *orderV1.PromoCodeDto {
+				p := fixedPromo(100, "RUB")
+				p.Percent = orderV1.NewOptInt32(10)
+				return p
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown discount type",
+			promo: func() *orderV1.PromoCodeDto {
+				p := percentPromo(10)
+				p.DiscountType = "BOGO"
+				return p
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative uses per user",
+			promo: func() *orderV1.PromoCodeDto {
+				p := percentPromo(10)
+				p.MaxUsesPerUser = -1
+				return p
+			},
+			wantErr: true,
+		},
+		{
+			name: "starts after expiry",
+			promo: func() *orderV1.PromoCodeDto {
+				p := percentPromo(10)
+				p.StartsAt = orderV1.NewOptDateTime(now)
+				p.ExpiresAt = orderV1.NewOptDateTime(now)
+				return p
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.promo())
+			if tt.wantErr && !errors.Is(err, ErrInvalidPromoCode) {
+				t.Fatalf("Validate: got %v, want %v", err, ErrInvalidPromoCode)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckActive(t *testing.T) {
+	tests := []struct {
+		name      string
+		active    bool
+		startsAt  time.Time
+		expiresAt time.Time
+		wantErr   error
+	}{
+		{name: "no period", active: true},
+		{name: "inside period", active: true, startsAt: now.Add(-time.Hour), expiresAt: now.Add(time.Hour)},
+		{name: "starts now", active: true, startsAt: now},
+		{name: "disabled", active: false, wantErr: ErrPromoCodeInactive},
+		{name: "not started", active: true, startsAt: now.Add(time.Second), wantErr: ErrPromoCodeNotStarted},
+		{name: "expires now", active: true, expiresAt: now, wantErr: ErrPromoCodeExpired},
+		{name: "expired", active: true, expiresAt: now.Add(-time.Hour), wantErr: ErrPromoCodeExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := percentPromo(10)
+			p.Active = tt.active
+			if !tt.startsAt.IsZero() {
+				p.StartsAt = orderV1.NewOptDateTime(tt.startsAt)
+			}
+			if !tt.expiresAt.IsZero() {
+				p.ExpiresAt = orderV1.NewOptDateTime(tt.expiresAt)
+			}
+
+			if err := CheckActive(p, now); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckActive: got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	engine := orderV1.PartCategoryCATEGORYENGINE
+	wing := orderV1.PartCategoryCATEGORYWING
+
+	tests := []struct {
+		name     string
+		promo    *orderV1.PromoCodeDto
+		category orderV1.PartCategory
+		currency string
+		lines    []Line
+		want     []int64
+		wantErr  error
+	}{
+		{
+			name:     "percentage rounds down per line",
+			promo:    percentPromo(15),
+			currency: "RUB",
+			lines:    []Line{{Category: engine, TotalMinor: 999}, {Category: wing, TotalMinor: 250}},
+			want:     []int64{149, 37},
+		},
+		{
+			name:     "percentage does not overflow",
+			promo:    percentPromo(100),
+			currency: "RUB",
+			lines:    []Line{{Category: engine, TotalMinor: math.MaxInt64}},
+			want:     []int64{math.MaxInt64},
+		},
+		{
+			name:     "category limits discounted lines",
+			promo:    percentPromo(50),
+			category: engine,
+			currency: "RUB",
+			lines:    []Line{{Category: engine, TotalMinor: 1000}, {Category: wing, TotalMinor: 1000}},
+			want:     []int64{500, 0},
+		},
+		{
+			name:     "fixed is split by line total",
+			promo:    fixedPromo(1000, "RUB"),
+			currency: "RUB",
+			lines:    []Line{{Category: engine, TotalMinor: 3000}, {Category: wing, TotalMinor: 1000}},
+			want:     []int64{750, 250},
+		},
+		{
+			name:     "fixed rounding remainder goes to last line",
+			promo:    fixedPromo(10, "RUB"),
+			currency: "RUB",
+			lines:    []Line{{TotalMinor: 10}, {TotalMinor: 10}, {TotalMinor: 10}},
+			want:     []int64{3, 3, 4},
+		},
+		{
+			name:     "fixed is capped by eligible total",
+			promo:    fixedPromo(5000, "RUB"),
+			category: wing,
+			currency: "RUB",
+			lines:    []Line{{Category: wing, TotalMinor: 1000}, {Category: engine, TotalMinor: 9000}, {Category: wing, TotalMinor: 500}},
+			want:     []int64{1000, 0, 500},
+		},
+		{
+			name:     "free lines are skipped",
+			promo:    fixedPromo(100, "RUB"),
+			currency: "RUB",
+			lines:    []Line{{TotalMinor: 400}, {TotalMinor: 0}},
+			want:     []int64{100, 0},
+		},
+		{
+			name:     "fixed in other currency",
+			promo:    fixedPromo(100, "RUB"),
+			currency: "USD",
+			lines:    []Line{{TotalMinor: 1000}},
+			wantErr:  ErrPromoCodeNotApplicable,
+		},
+		{
+			name:     "no lines of category",
+			promo:    percentPromo(10),
+			category: engine,
+			currency: "RUB",
+			lines:    []Line{{Category: wing, TotalMinor: 1000}},
+			wantErr:  ErrPromoCodeNotApplicable,
+		},
+		{
+			name: "inactive",
+			promo: func() *orderV1.PromoCodeDto {
+				p := percentPromo(10)
+				p.Active = false
+				return p
+			}(),
+			currency: "RUB",
+			lines:    []Line{{TotalMinor: 1000}},
+			wantErr:  ErrPromoCodeInactive,
+		},
+		{
+			name: "expired",
+			promo: func() *orderV1.PromoCodeDto {
+				p := fixedPromo(100, "RUB")
+				p.ExpiresAt = orderV1.NewOptDateTime(now.Add(-time.Minute))
+				return p
+			}(),
+			currency: "RUB",
+			lines:    []Line{{TotalMinor: 1000}},
+			wantErr:  ErrPromoCodeExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.category != "" {
+				tt.promo.Category = orderV1.NewOptPartCategory(tt.category)
+			}
+
+			got, err := Apply(tt.promo, tt.currency, tt.lines, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Apply: got %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Apply: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+// Package saga выполняет многошаговые операции над заказом с сохранением состояния после каждого шага.
+// Если шаг завершился неповторяемой ошибкой, выполненные шаги компенсируются в обратном порядке.
+// Незавершенные саги продолжаются фоновым обработчиком, в том числе после перезапуска сервиса
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	"github.com/Igorezka/rocket-factory/shared/pkg/tracing"
+)
+
+var (
+	// ErrPending шаг саги завершился повторяемой ошибкой, сага будет продолжена позже
+	ErrPending     = errors.New("saga is pending retry")
+	ErrUnknownType = errors.New("unknown saga type")
+)
+
+var tracer = otel.Tracer("github.com/Igorezka/rocket-factory/order/internal/saga")
+
+const (
+	// maxAttempts количество попыток шага, после которого ошибка считается неповторяемой.
+	// Компенсации и шаги с неизвестным результатом повторяются без ограничения
+	maxAttempts = 10
+	// Задержка перед повтором растет вдвое с каждой попыткой от baseBackoff до maxBackoff
+	baseBackoff = time.Second
+	maxBackoff  = time.Minute
+
+	// claimLease время, на которое реплика занимает сагу, продлевается при каждом сохранении саги
+	claimLease = 30 * time.Second
+	// pollInterval интервал поиска саг, ожидающих продолжения
+	pollInterval = time.Second
+	// claimBatchSize максимальное количество саг, занимаемых за один раз
+	claimBatchSize = 10
+)
+
+// Step шаг саги. Действие и компенсация должны быть идемпотентными: после сбоя они выполняются повторно
+type Step struct {
+	Name string
+	// Action выполняет шаг, nil — шаг без действия
+	Action func(ctx context.Context, saga *storage.Saga) error
+	// Compensate отменяет результат выполненного шага, nil — шаг не требует компенсации
+	Compensate func(ctx context.Context, saga *storage.Saga) error
+}
+
+// permanentError ошибка шага, повтор которого не изменит результат
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent помечает ошибку шага как неповторяемую: вместо повтора шага сага переходит к компенсации,
+// а для компенсации — завершается в статусе FAILED
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+// isPermanent проверяет, что ошибка помечена как неповторяемая
+func isPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// indeterminateError ошибка шага, после которой неизвестно, выполнено ли действие
+type indeterminateError struct {
+	err error
+}
+
+func (e *indeterminateError) Error() string {
+	return e.err.Error()
+}
+
+func (e *indeterminateError) Unwrap() error {
+	return e.err
+}
+
+// Indeterminate помечает ошибку шага, после которой неизвестно, выполнено ли действие. Такой шаг повторяется
+// без ограничения количества попыток: пока результат неизвестен, нельзя решить, что компенсировать
+func Indeterminate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &indeterminateError{err: err}
+}
+
+// isIndeterminate проверяет, что результат шага неизвестен
+func isIndeterminate(err error) bool {
+	var i *indeterminateError
+	return errors.As(err, &i)
+}
+
+// Orchestrator выполняет саги зарегистрированных типов
+type Orchestrator struct {
+	storage     storage.SagaStorage
+	definitions map[string][]Step
+}
+
+// NewOrchestrator создает оркестратор саг
+func NewOrchestrator(sagaStorage storage.SagaStorage) *Orchestrator {
+	return &Orchestrator{
+		storage:     sagaStorage,
+		definitions: make(map[string][]Step),
+	}
+}
+
+// Register регистрирует шаги саги типа sagaType
+func (o *Orchestrator) Register(sagaType string, steps ...Step) {
+	o.definitions[sagaType] = steps
+}
+
+// Start сохраняет новую сагу заказа и выполняет ее шаги. Если у заказа уже есть незавершенная сага
+// того же типа, возвращается storage.ErrSagaAlreadyExists
+func (o *Orchestrator) Start(ctx context.Context, sagaType, orderUuid string, data storage.SagaData) (*storage.Saga, error) {
+	if _, ok := o.definitions[sagaType]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, sagaType)
+	}
+
+	now := time.Now()
+	saga := &storage.Saga{
+		Uuid:          uuid.NewString(),
+		OrderUuid:     orderUuid,
+		Type:          sagaType,
+		Status:        storage.SagaStatusRunning,
+		Data:          data,
+		NextAttemptAt: now,
+		ClaimedUntil:  now.Add(claimLease),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := o.storage.CreateSaga(ctx, saga); err != nil {
+		return nil, err
+	}
+
+	return saga, o.Execute(ctx, saga)
+}
+
+// Execute продолжает сагу с сохраненного шага. Возвращает nil, если все шаги выполнены, ошибку шага,
+// из-за которой сага компенсирована, и ошибку, обернутую в ErrPending, если шаг будет повторен позже
+func (o *Orchestrator) Execute(ctx context.Context, saga *storage.Saga) error {
+	steps, ok := o.definitions[saga.Type]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownType, saga.Type)
+	}
+
+	var cause error
+	for saga.Status == storage.SagaStatusRunning {
+		if saga.Step == len(steps) {
+			saga.Status = storage.SagaStatusCompleted
+			saga.ClaimedUntil = time.Time{}
+			return o.save(ctx, saga)
+		}
+
+		step := steps[saga.Step]
+		if err := run(ctx, step.Action, saga); err != nil {
+			if !isPermanent(err) && (saga.Attempts+1 < maxAttempts || isIndeterminate(err)) {
+				return o.retryLater(ctx, saga, step.Name, err)
+			}
+
+			slog.WarnContext(ctx, "saga step failed, compensating", sagaAttrs(saga), slog.String("step", step.Name), logger.Err(err))
+			cause = err
+			saga.Status = storage.SagaStatusCompensating
+			saga.Error = fmt.Sprintf("%s: %v", step.Name, err)
+			saga.Attempts = 0
+		} else {
+			saga.Step++
+			saga.Attempts = 0
+			saga.Error = ""
+		}
+
+		if err := o.save(ctx, saga); err != nil {
+			return err
+		}
+	}
+
+	if saga.Status != storage.SagaStatusCompensating {
+		return nil
+	}
+
+	for saga.Step > 0 {
+		step := steps[saga.Step-1]
+		if err := run(ctx, step.Compensate, saga); err != nil {
+			if !isPermanent(err) {
+				return o.retryLater(ctx, saga, "compensate "+step.Name, err)
+			}
+
+			slog.ErrorContext(ctx, "failed to compensate saga step, manual intervention required",
+				sagaAttrs(saga), slog.String("step", step.Name), logger.Err(err))
+			saga.Status = storage.SagaStatusFailed
+			saga.Error = fmt.Sprintf("compensate %s: %v", step.Name, err)
+			saga.ClaimedUntil = time.Time{}
+			if serr := o.save(ctx, saga); serr != nil {
+				return serr
+			}
+
+			return err
+		}
+
+		saga.Step--
+		saga.Attempts = 0
+		if err := o.save(ctx, saga); err != nil {
+			return err
+		}
+	}
+
+	saga.Status = storage.SagaStatusCompensated
+	saga.ClaimedUntil = time.Time{}
+	if err := o.save(ctx, saga); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "saga compensated", sagaAttrs(saga), slog.String("cause", saga.Error))
+
+	if cause == nil {
+		// Компенсация продолжена после перезапуска, исходная ошибка сохранена только текстом
+		cause = errors.New(saga.Error)
+	}
+
+	return cause
+}
+
+// Run продолжает саги, ожидающие повтора шага или брошенные остановленной репликой,
+// пока не будет отменен контекст
+func (o *Orchestrator) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			sagas, err := o.storage.ClaimSagas(ctx, now, claimLease, claimBatchSize)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to claim sagas", logger.Err(err))
+				continue
+			}
+
+			for _, saga := range sagas {
+				o.resume(ctx, saga)
+			}
+		}
+	}
+}
+
+// resume продолжает сагу в отдельной трассе, объединяющей вызовы сервисов и обращения к хранилищу
+func (o *Orchestrator) resume(ctx context.Context, saga *storage.Saga) {
+	ctx, span := tracer.Start(ctx, "saga.Resume", trace.WithAttributes(
+		attribute.String("saga.uuid", saga.Uuid),
+		attribute.String("saga.type", saga.Type),
+		attribute.String("order.uuid", saga.OrderUuid),
+	))
+
+	slog.InfoContext(ctx, "resuming saga", sagaAttrs(saga))
+
+	err := o.Execute(ctx, saga)
+	switch {
+	case errors.Is(err, storage.ErrSagaClaimLost):
+		slog.WarnContext(ctx, "saga is continued by another replica", sagaAttrs(saga))
+	case err != nil && !errors.Is(err, ErrPending):
+		slog.WarnContext(ctx, "saga finished with error", sagaAttrs(saga), logger.Err(err))
+	}
+
+	tracing.End(span, err)
+}
+
+// retryLater сохраняет ошибку шага и время следующей попытки, после чего сагу может занять любая реплика
+func (o *Orchestrator) retryLater(ctx context.Context, saga *storage.Saga, stepName string, err error) error {
+	saga.Attempts++
+	saga.Error = fmt.Sprintf("%s: %v", stepName, err)
+	saga.NextAttemptAt = time.Now().Add(backoff(saga.Attempts))
+	saga.ClaimedUntil = time.Time{}
+
+	if serr := o.save(ctx, saga); serr != nil {
+		return serr
+	}
+
+	slog.WarnContext(ctx, "saga step will be retried", sagaAttrs(saga), slog.String("step", stepName),
+		slog.Time("next_attempt_at", saga.NextAttemptAt), logger.Err(err))
+
+	return fmt.Errorf("%w: %s: %w", ErrPending, stepName, err)
+}
+
+// save сохраняет состояние саги даже если контекст запроса уже отменен. Сохранение продлевает занятие
+// саги, освобожденная сага остается свободной. Если сагу заняла другая реплика, возвращается
+// ошибка storage.ErrSagaClaimLost и выполнение саги должно прекратиться
+func (o *Orchestrator) save(ctx context.Context, saga *storage.Saga) error {
+	saga.UpdatedAt = time.Now()
+	if !saga.ClaimedUntil.IsZero() {
+		saga.ClaimedUntil = saga.UpdatedAt.Add(claimLease)
+	}
+
+	if err := o.storage.UpdateSaga(context.WithoutCancel(ctx), saga); err != nil {
+		return fmt.Errorf("save saga %s: %w", saga.Uuid, err)
+	}
+
+	return nil
+}
+
+// run выполняет действие шага, отсутствующее действие считается выполненным
+func run(ctx context.Context, action func(context.Context, *storage.Saga) error, saga *storage.Saga) error {
+	if action == nil {
+		return nil
+	}
+
+	return action(ctx, saga)
+}
+
+// sagaAttrs возвращает атрибуты саги для записи в лог
+func sagaAttrs(saga *storage.Saga) slog.Attr {
+	return slog.Group("saga",
+		slog.String("uuid", saga.Uuid),
+		slog.String("type", saga.Type),
+		slog.String("order_uuid", saga.OrderUuid),
+		slog.String("status", string(saga.Status)),
+		slog.Int("step", saga.Step),
+		slog.Int("attempts", saga.Attempts),
+	)
+}
+
+// backoff возвращает задержку перед попыткой номер attempt
+func backoff(attempt int) time.Duration {
+	delay := baseBackoff
+	for range attempt - 1 {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return delay
+}
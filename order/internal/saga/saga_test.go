@@ -0,0 +1,480 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+)
+
+const testSagaType = "test"
+
+var (
+	errRetryable = errors.New("service unavailable")
+	errFatal     = errors.New("order not found")
+	errDeclined  = errors.New("payment declined")
+)
+
+// recorder запоминает порядок вызова действий и компенсаций шагов
+type recorder struct {
+	calls []string
+}
+
+// step возвращает шаг, действие и компенсация которого записываются в recorder и возвращают
+// очередную ошибку из actionErrs и compensateErrs, после их окончания — nil
+func (r *recorder) step(name string, actionErrs, compensateErrs []error) Step {
+	return Step{
+		Name: name,
+		Action: func(context.Context, *storage.Saga) error {
+			r.calls = append(r.calls, name)
+			return next(&actionErrs)
+		},
+		Compensate: func(context.Context, *storage.Saga) error {
+			r.calls = append(r.calls, "compensate "+name)
+			return next(&compensateErrs)
+		},
+	}
+}
+
+// next извлекает первую ошибку из очереди
+func next(errs *[]error) error {
+	if len(*errs) == 0 {
+		return nil
+	}
+
+	err := (*errs)[0]
+	*errs = (*errs)[1:]
+
+	return err
+}
+
+// newTestOrchestrator создает оркестратор над хранилищем саг в памяти с одним типом саги
+func newTestOrchestrator(sagaStorage storage.SagaStorage, steps ...Step) *Orchestrator {
+	o := NewOrchestrator(sagaStorage)
+	o.Register(testSagaType, steps...)
+
+	return o
+}
+
+// storedSaga возвращает сохраненное состояние незавершенной саги заказа или nil, если сага завершена
+func storedSaga(t *testing.T, sagaStorage *storage.SagaStorageInMem, saga *storage.Saga) *storage.Saga {
+	t.Helper()
+
+	stored, err := sagaStorage.GetActiveSaga(context.Background(), saga.OrderUuid, saga.Type)
+	if err != nil {
+		if errors.Is(err, storage.ErrSagaNotFound) {
+			return nil
+		}
+		t.Fatalf("GetActiveSaga: %v", err)
+	}
+
+	return stored
+}
+
+// newStoredSaga сохраняет незавершенную сагу, у которой выполнено step шагов
+func newStoredSaga(t *testing.T, o *Orchestrator, step int) *storage.Saga {
+	t.Helper()
+
+	now := time.Now()
+	saga := &storage.Saga{
+		Uuid:          uuid.NewString(),
+		OrderUuid:     uuid.NewString(),
+		Type:          testSagaType,
+		Status:        storage.SagaStatusRunning,
+		Step:          step,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := o.storage.CreateSaga(context.Background(), saga); err != nil {
+		t.Fatalf("CreateSaga: %v", err)
+	}
+
+	return saga
+}
+
+func TestExecuteCompletes(t *testing.T) {
+	var r recorder
+	sagaStorage := storage.NewSagaStorageInMem()
+	o := newTestOrchestrator(sagaStorage,
+		r.step("reserve", nil, nil),
+		r.step("charge", nil, nil),
+		r.step("confirm", nil, nil),
+	)
+
+	saga, err := o.Start(context.Background(), testSagaType, uuid.NewString(), storage.SagaData{})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if saga.Status != storage.SagaStatusCompleted || saga.Step != 3 {
+		t.Fatalf("saga: got status %s step %d, want %s step 3", saga.Status, saga.Step, storage.SagaStatusCompleted)
+	}
+	if want := []string{"reserve", "charge", "confirm"}; !slices.Equal(r.calls, want) {
+		t.Fatalf("calls: got %v, want %v", r.calls, want)
+	}
+	if stored := storedSaga(t, sagaStorage, saga); stored != nil {
+		t.Fatalf("completed saga is still active: %+v", stored)
+	}
+}
+
+func TestStartErrors(t *testing.T) {
+	ctx := context.Background()
+	o := newTestOrchestrator(storage.NewSagaStorageInMem(),
+		Step{Name: "fail", Action: func(context.Context, *storage.Saga) error { return errRetryable }},
+	)
+
+	if _, err := o.Start(ctx, "unknown", uuid.NewString(), storage.SagaData{}); !errors.Is(err, ErrUnknownType) {
+		t.Fatalf("Start unknown type: got %v, want %v", err, ErrUnknownType)
+	}
+
+	orderUuid := uuid.NewString()
+	if _, err := o.Start(ctx, testSagaType, orderUuid, storage.SagaData{}); !errors.Is(err, ErrPending) {
+		t.Fatalf("Start: got %v, want %v", err, ErrPending)
+	}
+	if _, err := o.Start(ctx, testSagaType, orderUuid, storage.SagaData{}); !errors.Is(err, storage.ErrSagaAlreadyExists) {
+		t.Fatalf("Start second saga: got %v, want %v", err, storage.ErrSagaAlreadyExists)
+	}
+}
+
+func TestExecuteRetriesLater(t *testing.T) {
+	var r recorder
+	sagaStorage := storage.NewSagaStorageInMem()
+	o := newTestOrchestrator(sagaStorage,
+		r.step("reserve", nil, nil),
+		r.step("charge", []error{errRetryable, errRetryable}, nil),
+	)
+
+	start := time.Now()
+	saga, err := o.Start(context.Background(), testSagaType, uuid.NewString(), storage.SagaData{})
+	if !errors.Is(err, ErrPending) || !errors.Is(err, errRetryable) {
+		t.Fatalf("Start: got %v, want %v wrapping %v", err, ErrPending, errRetryable)
+	}
+
+	stored := storedSaga(t, sagaStorage, saga)
+	if stored.Status != storage.SagaStatusRunning || stored.Step != 1 || stored.Attempts != 1 {
+		t.Fatalf("stored saga: got status %s step %d attempts %d, want %s step 1 attempts 1",
+			stored.Status, stored.Step, stored.Attempts, storage.SagaStatusRunning)
+	}
+	if stored.NextAttemptAt.Before(start.Add(baseBackoff)) || !stored.ClaimedUntil.IsZero() {
+		t.Fatalf("stored saga: got next attempt %s claimed until %s, want after %s and released",
+			stored.NextAttemptAt, stored.ClaimedUntil, start.Add(baseBackoff))
+	}
+
+	if err = o.Execute(context.Background(), saga); !errors.Is(err, ErrPending) {
+		t.Fatalf("Execute second attempt: got %v, want %v", err, ErrPending)
+	}
+	if saga.Attempts != 2 {
+		t.Fatalf("attempts: got %d, want 2", saga.Attempts)
+	}
+
+	if err = o.Execute(context.Background(), saga); err != nil {
+		t.Fatalf("Execute third attempt: %v", err)
+	}
+	if saga.Status != storage.SagaStatusCompleted || saga.Attempts != 0 || saga.Error != "" {
+		t.Fatalf("saga: got status %s attempts %d error %q, want %s without error",
+			saga.Status, saga.Attempts, saga.Error, storage.SagaStatusCompleted)
+	}
+	if want := []string{"reserve", "charge", "charge", "charge"}; !slices.Equal(r.calls, want) {
+		t.Fatalf("calls: got %v, want %v", r.calls, want)
+	}
+}
+
+func TestExecuteAttemptsExhausted(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus storage.SagaStatus
+		wantCalls  []string
+	}{
+		{
+			name:       "retryable error is compensated",
+			err:        errRetryable,
+			wantStatus: storage.SagaStatusCompensated,
+			wantCalls:  []string{"charge", "compensate reserve"},
+		},
+		{
+			name:       "indeterminate error is retried",
+			err:        Indeterminate(errRetryable),
+			wantStatus: storage.SagaStatusRunning,
+			wantCalls:  []string{"charge"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r recorder
+			o := newTestOrchestrator(storage.NewSagaStorageInMem(),
+				r.step("reserve", nil, nil),
+				r.step("charge", []error{tt.err}, nil),
+			)
+
+			saga := newStoredSaga(t, o, 1)
+			saga.Attempts = maxAttempts - 1
+
+			err := o.Execute(context.Background(), saga)
+			if !errors.Is(err, errRetryable) {
+				t.Fatalf("Execute: got %v, want %v", err, errRetryable)
+			}
+			if saga.Status != tt.wantStatus {
+				t.Fatalf("status: got %s, want %s", saga.Status, tt.wantStatus)
+			}
+			if !slices.Equal(r.calls, tt.wantCalls) {
+				t.Fatalf("calls: got %v, want %v", r.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestExecutePermanentErrorCompensatesInReverseOrder(t *testing.T) {
+	var r recorder
+	o := newTestOrchestrator(storage.NewSagaStorageInMem(),
+		r.step("reserve", nil, nil),
+		r.step("charge", nil, nil),
+		Step{Name: "no compensation", Action: func(context.Context, *storage.Saga) error {
+			r.calls = append(r.calls, "no compensation")
+			return nil
+		}},
+		r.step("confirm", []error{Permanent(errFatal)}, nil),
+	)
+
+	saga, err := o.Start(context.Background(), testSagaType, uuid.NewString(), storage.SagaData{})
+	if !errors.Is(err, errFatal) || errors.Is(err, ErrPending) {
+		t.Fatalf("Start: got %v, want %v", err, errFatal)
+	}
+
+	if saga.Status != storage.SagaStatusCompensated || saga.Step != 0 {
+		t.Fatalf("saga: got status %s step %d, want %s step 0", saga.Status, saga.Step, storage.SagaStatusCompensated)
+	}
+	if saga.Error != "confirm: order not found" {
+		t.Fatalf("error: got %q, want %q", saga.Error, "confirm: order not found")
+	}
+
+	want := []string{"reserve", "charge", "no compensation", "confirm", "compensate charge", "compensate reserve"}
+	if !slices.Equal(r.calls, want) {
+		t.Fatalf("calls: got %v, want %v", r.calls, want)
+	}
+}
+
+func TestExecuteCompensationErrors(t *testing.T) {
+	t.Run("retryable error is retried without limit", func(t *testing.T) {
+		var r recorder
+		o := newTestOrchestrator(storage.NewSagaStorageInMem(),
+			r.step("reserve", nil, []error{errRetryable}),
+			r.step("charge", []error{Permanent(errFatal)}, nil),
+		)
+
+		saga, err := o.Start(context.Background(), testSagaType, uuid.NewString(), storage.SagaData{})
+		if !errors.Is(err, ErrPending) {
+			t.Fatalf("Start: got %v, want %v", err, ErrPending)
+		}
+		if saga.Status != storage.SagaStatusCompensating || saga.Step != 1 || saga.Attempts != 1 {
+			t.Fatalf("saga: got status %s step %d attempts %d, want %s step 1 attempts 1",
+				saga.Status, saga.Step, saga.Attempts, storage.SagaStatusCompensating)
+		}
+
+		saga.Attempts = maxAttempts
+		if err = o.Execute(context.Background(), saga); err == nil || errors.Is(err, ErrPending) {
+			t.Fatalf("Execute: got %v, want saga error", err)
+		}
+		if saga.Status != storage.SagaStatusCompensated {
+			t.Fatalf("status: got %s, want %s", saga.Status, storage.SagaStatusCompensated)
+		}
+	})
+
+	t.Run("permanent error fails saga", func(t *testing.T) {
+		var r recorder
+		o := newTestOrchestrator(storage.NewSagaStorageInMem(),
+			r.step("reserve", nil, nil),
+			r.step("charge", nil, []error{Permanent(errFatal)}),
+			r.step("confirm", []error{Permanent(errDeclined)}, nil),
+		)
+
+		saga, err := o.Start(context.Background(), testSagaType, uuid.NewString(), storage.SagaData{})
+		if !errors.Is(err, errFatal) {
+			t.Fatalf("Start: got %v, want %v", err, errFatal)
+		}
+		if saga.Status != storage.SagaStatusFailed || saga.Step != 2 {
+			t.Fatalf("saga: got status %s step %d, want %s step 2", saga.Status, saga.Step, storage.SagaStatusFailed)
+		}
+		if saga.Error != "compensate charge: order not found" {
+			t.Fatalf("error: got %q, want %q", saga.Error, "compensate charge: order not found")
+		}
+
+		want := []string{"reserve", "charge", "confirm", "compensate charge"}
+		if !slices.Equal(r.calls, want) {
+			t.Fatalf("calls: got %v, want %v", r.calls, want)
+		}
+	})
+}
+
+func TestResumeAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	sagaStorage := storage.NewSagaStorageInMem()
+
+	var before recorder
+	o := newTestOrchestrator(sagaStorage,
+		before.step("reserve", nil, nil),
+		before.step("charge", []error{errRetryable}, nil),
+	)
+	if _, err := o.Start(ctx, testSagaType, uuid.NewString(), storage.SagaData{UserUuid: "user"}); !errors.Is(err, ErrPending) {
+		t.Fatalf("Start: got %v, want %v", err, ErrPending)
+	}
+
+	// Новый оркестратор над тем же хранилищем продолжает сагу с сохраненного шага
+	var after recorder
+	restarted := newTestOrchestrator(sagaStorage,
+		after.step("reserve", nil, nil),
+		after.step("charge", nil, nil),
+	)
+
+	sagas, err := sagaStorage.ClaimSagas(ctx, time.Now(), claimLease, claimBatchSize)
+	if err != nil {
+		t.Fatalf("ClaimSagas before backoff: %v", err)
+	}
+	if len(sagas) != 0 {
+		t.Fatalf("ClaimSagas before backoff: got %d sagas, want 0", len(sagas))
+	}
+
+	sagas, err = sagaStorage.ClaimSagas(ctx, time.Now().Add(baseBackoff), claimLease, claimBatchSize)
+	if err != nil {
+		t.Fatalf("ClaimSagas: %v", err)
+	}
+	if len(sagas) != 1 {
+		t.Fatalf("ClaimSagas: got %d sagas, want 1", len(sagas))
+	}
+
+	saga := sagas[0]
+	if saga.Data.UserUuid != "user" {
+		t.Fatalf("data: got %+v, want user uuid restored", saga.Data)
+	}
+	if err = restarted.Execute(ctx, saga); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if saga.Status != storage.SagaStatusCompleted {
+		t.Fatalf("status: got %s, want %s", saga.Status, storage.SagaStatusCompleted)
+	}
+	if want := []string{"charge"}; !slices.Equal(after.calls, want) {
+		t.Fatalf("calls after restart: got %v, want %v", after.calls, want)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 6, want: 32 * time.Second},
+		{attempt: 7, want: maxBackoff},
+		{attempt: 100, want: maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d): got %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestSaveExtendsClaim(t *testing.T) {
+	var claims []time.Time
+	record := func(_ context.Context, saga *storage.Saga) error {
+		claims = append(claims, saga.ClaimedUntil)
+		return nil
+	}
+	o := newTestOrchestrator(storage.NewSagaStorageInMem(),
+		Step{Name: "reserve", Action: record},
+		Step{Name: "charge", Action: record},
+	)
+
+	if _, err := o.Start(context.Background(), testSagaType, uuid.NewString(), storage.SagaData{}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if len(claims) != 2 || !claims[1].After(claims[0]) {
+		t.Fatalf("claims: got %v, want claim extended after first step", claims)
+	}
+}
+
+func TestExecuteStopsWhenClaimIsLost(t *testing.T) {
+	ctx := context.Background()
+	sagaStorage := storage.NewSagaStorageInMem()
+
+	var other recorder
+	otherReplica := newTestOrchestrator(sagaStorage,
+		other.step("reserve", nil, nil),
+		other.step("charge", nil, nil),
+		other.step("confirm", nil, nil),
+	)
+
+	var first recorder
+	o := newTestOrchestrator(sagaStorage,
+		first.step("reserve", nil, nil),
+		Step{Name: "charge", Action: func(ctx context.Context, _ *storage.Saga) error {
+			first.calls = append(first.calls, "charge")
+
+			// Шаг выполняется дольше аренды: сагу занимает и завершает другая реплика
+			sagas, err := sagaStorage.ClaimSagas(ctx, time.Now().Add(claimLease+time.Second), claimLease, claimBatchSize)
+			if err != nil || len(sagas) != 1 {
+				t.Fatalf("ClaimSagas by other replica: got %d sagas, %v, want 1", len(sagas), err)
+			}
+			if err = otherReplica.Execute(ctx, sagas[0]); err != nil {
+				t.Fatalf("Execute by other replica: %v", err)
+			}
+
+			return nil
+		}},
+		first.step("confirm", nil, nil),
+	)
+
+	saga, err := o.Start(ctx, testSagaType, uuid.NewString(), storage.SagaData{})
+	if !errors.Is(err, storage.ErrSagaClaimLost) {
+		t.Fatalf("Start: got %v, want %v", err, storage.ErrSagaClaimLost)
+	}
+
+	if want := []string{"reserve", "charge"}; !slices.Equal(first.calls, want) {
+		t.Fatalf("calls of first replica: got %v, want %v", first.calls, want)
+	}
+	if want := []string{"charge", "confirm"}; !slices.Equal(other.calls, want) {
+		t.Fatalf("calls of other replica: got %v, want %v", other.calls, want)
+	}
+
+	// Прогресс другой реплики не перезаписан: сага завершена
+	if stored := storedSaga(t, sagaStorage, saga); stored != nil {
+		t.Fatalf("saga completed by other replica is active again: %+v", stored)
+	}
+}
+
+func TestClaimedSagaIsNotClaimedTwice(t *testing.T) {
+	ctx := context.Background()
+	sagaStorage := storage.NewSagaStorageInMem()
+	o := newTestOrchestrator(sagaStorage, Step{Name: "charge"})
+
+	saga := newStoredSaga(t, o, 0)
+	now := time.Now()
+
+	first, err := sagaStorage.ClaimSagas(ctx, now, claimLease, claimBatchSize)
+	if err != nil || len(first) != 1 {
+		t.Fatalf("ClaimSagas: got %d sagas, %v, want 1", len(first), err)
+	}
+	second, err := sagaStorage.ClaimSagas(ctx, now.Add(claimLease/2), claimLease, claimBatchSize)
+	if err != nil || len(second) != 0 {
+		t.Fatalf("ClaimSagas during lease: got %d sagas, %v, want 0", len(second), err)
+	}
+
+	// Копия, прочитанная до занятия, устарела и не может быть сохранена
+	if err = o.Execute(ctx, saga); !errors.Is(err, storage.ErrSagaClaimLost) {
+		t.Fatalf("Execute stale copy: got %v, want %v", err, storage.ErrSagaClaimLost)
+	}
+	if err = o.Execute(ctx, first[0]); err != nil {
+		t.Fatalf("Execute claimed copy: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package statemachine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Igorezka/rocket-factory/order/internal/storage"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+var ErrInvalidTransition = errors.New("invalid order status transition")
+
+// ActorSystem инициатор переходов, выполняемых сервисом без участия пользователя
+const ActorSystem = "system"
+
+// ActorFulfilment инициатор переходов по этапам выполнения заказа: сборки, отгрузки и получения
+const ActorFulfilment = "fulfilment"
+
+// transitions допустимые переходы между статусами заказа.
+// CANCELLED и REFUNDED — конечные статусы
+var transitions = map[orderV1.OrderStatus][]orderV1.OrderStatus{
+	orderV1.OrderStatusPENDINGPAYMENT: {orderV1.OrderStatusPAID, orderV1.OrderStatusCANCELLED},
+	orderV1.OrderStatusPAID:           {orderV1.OrderStatusASSEMBLING, orderV1.OrderStatusREFUNDED},
+	orderV1.OrderStatusASSEMBLING:     {orderV1.OrderStatusSHIPPED, orderV1.OrderStatusREFUNDED},
+	orderV1.OrderStatusSHIPPED:        {orderV1.OrderStatusCOMPLETED},
+	orderV1.OrderStatusCOMPLETED:      {orderV1.OrderStatusREFUNDED},
+}
+
+// UserActor возвращает инициатора перехода для пользователя
+func UserActor(userUuid string) string {
+	return "user:" + userUuid
+}
+
+// CanTransit проверяет, что заказ может перейти из статуса from в статус to
+func CanTransit(from, to orderV1.OrderStatus) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// Create переводит новый заказ в начальный статус PENDING_PAYMENT и возвращает запись о создании.
+// Время создания и изменения заказа совпадает со временем записи
+func Create(order *orderV1.OrderDto, actor, reason string) *storage.OrderTransition {
+	now := time.Now()
+	order.Status = orderV1.OrderStatusPENDINGPAYMENT
+	order.CreatedAt = now
+	order.UpdatedAt = now
+
+	return &storage.OrderTransition{
+		OrderUuid: order.OrderUUID,
+		To:        order.Status,
+		Actor:     actor,
+		Reason:    reason,
+		CreatedAt: now,
+	}
+}
+
+// Transit переводит заказ в статус to и возвращает запись о переходе для сохранения вместе с заказом.
+// Для недопустимого перехода заказ не меняется и возвращается ErrInvalidTransition
+func Transit(order *orderV1.OrderDto, to orderV1.OrderStatus, actor, reason string) (*storage.OrderTransition, error) {
+	if !CanTransit(order.Status, to) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, order.Status, to)
+	}
+
+	transition := &storage.OrderTransition{
+		OrderUuid: order.OrderUUID,
+		From:      order.Status,
+		To:        to,
+		Actor:     actor,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+	order.Status = to
+	order.UpdatedAt = transition.CreatedAt
+
+	return transition, nil
+}
+
+// Amend возвращает запись об изменении заказа без смены статуса. Запись сохраняется в историю вместе
+// с заказом, а обновление выполняется, только если статус заказа не изменился с момента чтения
+func Amend(order *orderV1.OrderDto, actor, reason string) *storage.OrderTransition {
+	transition := &storage.OrderTransition{
+		OrderUuid: order.OrderUUID,
+		From:      order.Status,
+		To:        order.Status,
+		Actor:     actor,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+	order.UpdatedAt = transition.CreatedAt
+
+	return transition
+}
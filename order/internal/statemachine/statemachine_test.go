@@ -0,0 +1,147 @@
+package statemachine
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+func TestCanTransit(t *testing.T) {
+	tests := []struct {
+		from    orderV1.OrderStatus
+		allowed []orderV1.OrderStatus
+	}{
+		{
+			from:    orderV1.OrderStatusPENDINGPAYMENT,
+			allowed: []orderV1.OrderStatus{orderV1.OrderStatusPAID, orderV1.OrderStatusCANCELLED},
+		},
+		{
+			from:    orderV1.OrderStatusPAID,
+			allowed: []orderV1.OrderStatus{orderV1.OrderStatusASSEMBLING, orderV1.OrderStatusREFUNDED},
+		},
+		{
+			from:    orderV1.OrderStatusASSEMBLING,
+			allowed: []orderV1.OrderStatus{orderV1.OrderStatusSHIPPED, orderV1.OrderStatusREFUNDED},
+		},
+		{
+			from:    orderV1.OrderStatusSHIPPED,
+			allowed: []orderV1.OrderStatus{orderV1.OrderStatusCOMPLETED},
+		},
+		{
+			from:    orderV1.OrderStatusCOMPLETED,
+			allowed: []orderV1.OrderStatus{orderV1.OrderStatusREFUNDED},
+		},
+		{from: orderV1.OrderStatusCANCELLED},
+		{from: orderV1.OrderStatusREFUNDED},
+		{from: ""},
+	}
+
+	for _, tt := range tests {
+		for _, to := range orderV1.OrderStatus("").AllValues() {
+			want := slices.Contains(tt.allowed, to)
+			if got := CanTransit(tt.from, to); got != want {
+				t.Errorf("CanTransit(%q, %q): got %t, want %t", tt.from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestTransit(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    orderV1.OrderStatus
+		to      orderV1.OrderStatus
+		wantErr error
+	}{
+		{name: "pay", from: orderV1.OrderStatusPENDINGPAYMENT, to: orderV1.OrderStatusPAID},
+		{name: "cancel unpaid", from: orderV1.OrderStatusPENDINGPAYMENT, to: orderV1.OrderStatusCANCELLED},
+		{name: "refund completed", from: orderV1.OrderStatusCOMPLETED, to: orderV1.OrderStatusREFUNDED},
+		{
+			name:    "cancel paid",
+			from:    orderV1.OrderStatusPAID,
+			to:      orderV1.OrderStatusCANCELLED,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:    "skip assembling",
+			from:    orderV1.OrderStatusPAID,
+			to:      orderV1.OrderStatusSHIPPED,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:    "same status",
+			from:    orderV1.OrderStatusPAID,
+			to:      orderV1.OrderStatusPAID,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:    "leave cancelled",
+			from:    orderV1.OrderStatusCANCELLED,
+			to:      orderV1.OrderStatusPENDINGPAYMENT,
+			wantErr: ErrInvalidTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &orderV1.OrderDto{OrderUUID: uuid.NewString(), Status: tt.from}
+
+			transition, err := Transit(order, tt.to, ActorSystem, "test")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Transit: got %v, want %v", err, tt.wantErr)
+				}
+				if transition != nil || order.Status != tt.from || !order.UpdatedAt.IsZero() {
+					t.Fatalf("rejected transition changed order: status %s updated at %s", order.Status, order.UpdatedAt)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Transit: %v", err)
+			}
+
+			if order.Status != tt.to || !order.UpdatedAt.Equal(transition.CreatedAt) {
+				t.Fatalf("order: got status %s updated at %s, want %s updated at %s",
+					order.Status, order.UpdatedAt, tt.to, transition.CreatedAt)
+			}
+			if transition.OrderUuid != order.OrderUUID || transition.From != tt.from || transition.To != tt.to ||
+				transition.Actor != ActorSystem || transition.Reason != "test" {
+				t.Fatalf("transition: got %+v", transition)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	order := &orderV1.OrderDto{OrderUUID: uuid.NewString(), Status: orderV1.OrderStatusPAID}
+	actor := UserActor("user")
+
+	transition := Create(order, actor, "order created")
+
+	if order.Status != orderV1.OrderStatusPENDINGPAYMENT {
+		t.Fatalf("status: got %s, want %s", order.Status, orderV1.OrderStatusPENDINGPAYMENT)
+	}
+	if !order.CreatedAt.Equal(transition.CreatedAt) || !order.UpdatedAt.Equal(transition.CreatedAt) {
+		t.Fatalf("order times: got created %s updated %s, want %s", order.CreatedAt, order.UpdatedAt, transition.CreatedAt)
+	}
+	if transition.From != "" || transition.To != orderV1.OrderStatusPENDINGPAYMENT || transition.Actor != "user:user" {
+		t.Fatalf("transition: got %+v", transition)
+	}
+}
+
+func TestAmend(t *testing.T) {
+	order := &orderV1.OrderDto{OrderUUID: uuid.NewString(), Status: orderV1.OrderStatusASSEMBLING}
+
+	transition := Amend(order, ActorSystem, "address changed")
+
+	if order.Status != orderV1.OrderStatusASSEMBLING || !order.UpdatedAt.Equal(transition.CreatedAt) {
+		t.Fatalf("order: got status %s updated at %s", order.Status, order.UpdatedAt)
+	}
+	if transition.From != orderV1.OrderStatusASSEMBLING || transition.To != orderV1.OrderStatusASSEMBLING {
+		t.Fatalf("transition: got %s -> %s, want status unchanged", transition.From, transition.To)
+	}
+}
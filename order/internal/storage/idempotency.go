@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
+// IdempotencyRecord сохраненный результат запроса с ключом идемпотентности.
+// Пока запрос обрабатывается, StatusCode равен нулю
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// InProgress проверяет, что запрос с этим ключом еще обрабатывается
+func (r *IdempotencyRecord) InProgress() bool {
+	return r.StatusCode == 0
+}
+
+// IdempotencyStorage описывает хранилище ключей идемпотентности и сохраненных ответов
+type IdempotencyStorage interface {
+	// BeginIdempotentRequest занимает ключ под новый запрос. Если ключ уже занят действующей записью,
+	// она возвращается без изменений. Просроченные записи и записи, обработка которых началась раньше
+	// staleBefore и не завершилась, перезаписываются
+	BeginIdempotentRequest(ctx context.Context, record *IdempotencyRecord, staleBefore time.Time) (existing *IdempotencyRecord, err error)
+	// CompleteIdempotentRequest сохраняет ответ на запрос
+	CompleteIdempotentRequest(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	// AbortIdempotentRequest освобождает ключ, чтобы запрос можно было повторить
+	AbortIdempotentRequest(ctx context.Context, key string) error
+	// DeleteExpiredIdempotencyKeys удаляет просроченные записи и возвращает их количество
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
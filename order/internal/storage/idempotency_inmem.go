@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// IdempotencyStorageInMem представляет потокобезопасное хранилище ключей идемпотентности в памяти
+type IdempotencyStorageInMem struct {
+	mu      sync.Mutex
+	records map[string]*IdempotencyRecord
+}
+
+// NewIdempotencyStorageInMem создает новое хранилище ключей идемпотентности в памяти
+func NewIdempotencyStorageInMem() *IdempotencyStorageInMem {
+	return &IdempotencyStorageInMem{
+		records: make(map[string]*IdempotencyRecord),
+	}
+}
+
+// BeginIdempotentRequest занимает ключ под новый запрос или возвращает действующую запись
+func (s *IdempotencyStorageInMem) BeginIdempotentRequest(
+	_ context.Context,
+	record *IdempotencyRecord,
+	staleBefore time.Time,
+) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.records[record.Key]
+	if ok && existing.ExpiresAt.After(record.CreatedAt) &&
+		(!existing.InProgress() || existing.CreatedAt.After(staleBefore)) {
+		return copyIdempotencyRecord(existing), nil
+	}
+
+	s.records[record.Key] = copyIdempotencyRecord(record)
+
+	return nil, nil
+}
+
+// CompleteIdempotentRequest сохраняет ответ на запрос
+func (s *IdempotencyStorageInMem) CompleteIdempotentRequest(
+	_ context.Context,
+	key string,
+	statusCode int,
+	contentType string,
+	body []byte,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return ErrIdempotencyKeyNotFound
+	}
+
+	record.StatusCode = statusCode
+	record.ContentType = contentType
+	record.Body = slices.Clone(body)
+
+	return nil
+}
+
+// AbortIdempotentRequest освобождает ключ
+func (s *IdempotencyStorageInMem) AbortIdempotentRequest(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys удаляет просроченные записи
+func (s *IdempotencyStorageInMem) DeleteExpiredIdempotencyKeys(_ context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, record := range s.records {
+		if !record.ExpiresAt.After(now) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// copyIdempotencyRecord копирует запись вместе с телом ответа
+func copyIdempotencyRecord(record *IdempotencyRecord) *IdempotencyRecord {
+	c := *record
+	c.Body = slices.Clone(record.Body)
+
+	return &c
+}
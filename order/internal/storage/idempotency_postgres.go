@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IdempotencyStoragePostgres представляет хранилище ключей идемпотентности в PostgreSQL,
+// общее для всех реплик order service
+type IdempotencyStoragePostgres struct {
+	pool *pgxpool.Pool
+}
+
+// NewIdempotencyStoragePostgres создает новое хранилище ключей идемпотентности в PostgreSQL
+func NewIdempotencyStoragePostgres(pool *pgxpool.Pool) *IdempotencyStoragePostgres {
+	return &IdempotencyStoragePostgres{
+		pool: pool,
+	}
+}
+
+// BeginIdempotentRequest занимает ключ под новый запрос или возвращает действующую запись.
+// Вставка и перезапись просроченной записи выполняются одним запросом, поэтому ключ
+// может занять только одна из параллельно обрабатываемых реплик
+func (s *IdempotencyStoragePostgres) BeginIdempotentRequest(
+	ctx context.Context,
+	record *IdempotencyRecord,
+	staleBefore time.Time,
+) (*IdempotencyRecord, error) {
+	// Запись могла истечь между вставкой и чтением, поэтому пробуем занять ключ повторно
+	for range 2 {
+		tag, err := s.pool.Exec(ctx, `
+			INSERT INTO idempotency_keys (idempotency_key, request_hash, created_at, expires_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (idempotency_key) DO UPDATE
+			SET request_hash  = EXCLUDED.request_hash,
+			    status_code   = NULL,
+			    content_type  = '',
+			    response_body = NULL,
+			    created_at    = EXCLUDED.created_at,
+			    expires_at    = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			   OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at <= $5)`,
+			record.Key,
+			record.RequestHash,
+			record.CreatedAt,
+			record.ExpiresAt,
+			staleBefore,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("insert idempotency key: %w", err)
+		}
+
+		if tag.RowsAffected() == 1 {
+			return nil, nil
+		}
+
+		existing, err := s.idempotencyRecord(ctx, record.Key)
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, ErrIdempotencyKeyNotFound) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("acquire idempotency key %s: concurrent modification", record.Key)
+}
+
+// idempotencyRecord возвращает запись по ключу
+func (s *IdempotencyStoragePostgres) idempotencyRecord(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	var (
+		record     IdempotencyRecord
+		statusCode *int
+	)
+
+	err := s.pool.QueryRow(ctx, `
+		SELECT idempotency_key, request_hash, status_code, content_type, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE idempotency_key = $1`,
+		key,
+	).Scan(
+		&record.Key,
+		&record.RequestHash,
+		&statusCode,
+		&record.ContentType,
+		&record.Body,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrIdempotencyKeyNotFound
+		}
+
+		return nil, fmt.Errorf("select idempotency key: %w", err)
+	}
+
+	if statusCode != nil {
+		record.StatusCode = *statusCode
+	}
+
+	return &record, nil
+}
+
+// CompleteIdempotentRequest сохраняет ответ на запрос
+func (s *IdempotencyStoragePostgres) CompleteIdempotentRequest(
+	ctx context.Context,
+	key string,
+	statusCode int,
+	contentType string,
+	body []byte,
+) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET status_code   = $2,
+		    content_type  = $3,
+		    response_body = $4
+		WHERE idempotency_key = $1`,
+		key,
+		statusCode,
+		contentType,
+		body,
+	)
+	if err != nil {
+		return fmt.Errorf("update idempotency key: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+// AbortIdempotentRequest освобождает ключ
+func (s *IdempotencyStoragePostgres) AbortIdempotentRequest(ctx context.Context, key string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE idempotency_key = $1`, key)
+	if err != nil {
+		return fmt.Errorf("delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys удаляет просроченные записи
+func (s *IdempotencyStoragePostgres) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
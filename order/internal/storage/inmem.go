@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+// OrderStorageInMem представляет потокобезопасное хранилище данных о заказах в памяти
+type OrderStorageInMem struct {
+	mu      sync.RWMutex
+	orders  map[string]*orderV1.OrderDto
+	history map[string][]OrderTransition
+	// outbox сообщения о переходах заказов в порядке записи
+	outbox []*outboxEntry
+}
+
+// NewOrderStorageInMem создает новое хранилище данных о заказах в памяти
+func NewOrderStorageInMem() *OrderStorageInMem {
+	return &OrderStorageInMem{
+		orders:  make(map[string]*orderV1.OrderDto),
+		history: make(map[string][]OrderTransition),
+	}
+}
+
+// GetOrder возвращает копию заказа по uuid из хранилища
+func (s *OrderStorageInMem) GetOrder(_ context.Context, orderUuid string) (*orderV1.OrderDto, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	order, ok := s.orders[orderUuid]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+
+	return copyOrder(order), nil
+}
+
+// CreateOrder сохраняет новый заказ в хранилище
+func (s *OrderStorageInMem) CreateOrder(_ context.Context, order *orderV1.OrderDto, transition *OrderTransition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.orders[order.OrderUUID]; ok {
+		return ErrOrderAlreadyExists
+	}
+
+	event, err := newOrderEvent(order, transition)
+	if err != nil {
+		return err
+	}
+
+	s.orders[order.OrderUUID] = copyOrder(order)
+	if transition != nil {
+		s.history[order.OrderUUID] = append(s.history[order.OrderUUID], *transition)
+	}
+	s.appendOutbox(event)
+
+	return nil
+}
+
+// UpdateOrder обновляет существующий заказ в хранилище
+func (s *OrderStorageInMem) UpdateOrder(_ context.Context, order *orderV1.OrderDto, transition *OrderTransition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.orders[order.OrderUUID]
+	if !ok {
+		return ErrOrderNotFound
+	}
+
+	if transition != nil && stored.Status != transition.From {
+		return ErrOrderStatusConflict
+	}
+
+	event, err := newOrderEvent(order, transition)
+	if err != nil {
+		return err
+	}
+
+	if transition != nil {
+		s.history[order.OrderUUID] = append(s.history[order.OrderUUID], *transition)
+	}
+	s.orders[order.OrderUUID] = copyOrder(order)
+	s.appendOutbox(event)
+
+	return nil
+}
+
+// GetOrderHistory возвращает копию истории статусов заказа
+func (s *OrderStorageInMem) GetOrderHistory(_ context.Context, orderUuid string) ([]OrderTransition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.orders[orderUuid]; !ok {
+		return nil, ErrOrderNotFound
+	}
+
+	return slices.Clone(s.history[orderUuid]), nil
+}
+
+// ListOrders возвращает копии заказов, подходящих под фильтр, в порядке сортировки
+func (s *OrderStorageInMem) ListOrders(_ context.Context, query OrderQuery) ([]*orderV1.OrderDto, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matched := make([]*orderV1.OrderDto, 0)
+	for _, order := range s.orders {
+		if !matchOrder(order, query.Filter) {
+			continue
+		}
+		if query.After != nil && !afterCursor(order, query) {
+			continue
+		}
+
+		matched = append(matched, order)
+	}
+
+	slices.SortFunc(matched, func(a, b *orderV1.OrderDto) int {
+		c := compareOrders(query.SortBy, CursorOf(a), CursorOf(b))
+		if query.Descending {
+			return -c
+		}
+		return c
+	})
+
+	if query.Limit > 0 && len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	orders := make([]*orderV1.OrderDto, 0, len(matched))
+	for _, order := range matched {
+		orders = append(orders, copyOrder(order))
+	}
+
+	return orders, nil
+}
+
+// matchOrder проверяет, что заказ подходит под все условия фильтра
+func matchOrder(order *orderV1.OrderDto, filter OrderFilter) bool {
+	if filter.UserUuid != "" && order.UserUUID != filter.UserUuid {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, order.Status) {
+		return false
+	}
+	if !filter.CreatedFrom.IsZero() && order.CreatedAt.Before(filter.CreatedFrom) {
+		return false
+	}
+	if !filter.CreatedTo.IsZero() && !order.CreatedAt.Before(filter.CreatedTo) {
+		return false
+	}
+	if filter.PartUuid != "" && !slices.ContainsFunc(order.Items, func(item orderV1.OrderLine) bool {
+		return item.PartUUID == filter.PartUuid
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// afterCursor проверяет, что заказ находится после курсора в порядке сортировки запроса
+func afterCursor(order *orderV1.OrderDto, query OrderQuery) bool {
+	c := compareOrders(query.SortBy, CursorOf(order), query.After)
+	if query.Descending {
+		return c < 0
+	}
+	return c > 0
+}
+
+// copyOrder копирует заказ, чтобы изменения вне хранилища не затрагивали сохраненные данные
+func copyOrder(order *orderV1.OrderDto) *orderV1.OrderDto {
+	c := *order
+	c.Items = slices.Clone(order.Items)
+	c.PartUuids = slices.Clone(order.PartUuids)
+
+	return &c
+}
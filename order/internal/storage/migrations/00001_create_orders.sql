@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS orders
+(
+    order_uuid       TEXT PRIMARY KEY,
+    user_uuid        TEXT             NOT NULL,
+    part_uuids       TEXT[]           NOT NULL DEFAULT '{}',
+    total_price      DOUBLE PRECISION NOT NULL,
+    transaction_uuid TEXT,
+    payment_method   TEXT,
+    status           TEXT             NOT NULL,
+    created_at       TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    updated_at       TIMESTAMPTZ      NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS orders_user_uuid_idx ON orders (user_uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS orders;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS order_items
+(
+    order_uuid TEXT   NOT NULL REFERENCES orders (order_uuid) ON DELETE CASCADE,
+    part_uuid  TEXT   NOT NULL,
+    quantity   BIGINT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (order_uuid, part_uuid)
+);
+
+-- Переносим позиции уже созданных заказов из плоского списка деталей
+INSERT INTO order_items (order_uuid, part_uuid, quantity)
+SELECT o.order_uuid, p.part_uuid, count(*)
+FROM orders o
+         CROSS JOIN LATERAL unnest(o.part_uuids) AS p(part_uuid)
+GROUP BY o.order_uuid, p.part_uuid
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS order_items;
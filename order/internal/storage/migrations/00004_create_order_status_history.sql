@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS order_status_history
+(
+    id          BIGSERIAL PRIMARY KEY,
+    order_uuid  TEXT        NOT NULL REFERENCES orders (order_uuid) ON DELETE CASCADE,
+    from_status TEXT,
+    to_status   TEXT        NOT NULL,
+    actor       TEXT        NOT NULL,
+    reason      TEXT        NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_uuid_idx ON order_status_history (order_uuid, id);
+
+-- Восстанавливаем историю уже созданных заказов по их текущему статусу
+INSERT INTO order_status_history (order_uuid, from_status, to_status, actor, reason, created_at)
+SELECT order_uuid, NULL, 'PENDING_PAYMENT', 'system', 'Order created before history tracking', created_at
+FROM orders;
+
+INSERT INTO order_status_history (order_uuid, from_status, to_status, actor, reason, created_at)
+SELECT order_uuid, 'PENDING_PAYMENT', status, 'system', 'Status restored from order before history tracking', updated_at
+FROM orders
+WHERE status <> 'PENDING_PAYMENT';
+
+-- +goose Down
+DROP TABLE IF EXISTS order_status_history;
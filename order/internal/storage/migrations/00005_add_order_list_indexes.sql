@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS orders_created_at_idx ON orders (created_at, order_uuid);
+CREATE INDEX IF NOT EXISTS orders_updated_at_idx ON orders (updated_at, order_uuid);
+CREATE INDEX IF NOT EXISTS orders_total_price_idx ON orders (total_price, order_uuid);
+CREATE INDEX IF NOT EXISTS orders_status_created_at_idx ON orders (status, created_at);
+CREATE INDEX IF NOT EXISTS order_items_part_uuid_idx ON order_items (part_uuid);
+
+-- +goose Down
+DROP INDEX IF EXISTS order_items_part_uuid_idx;
+DROP INDEX IF EXISTS orders_status_created_at_idx;
+DROP INDEX IF EXISTS orders_total_price_idx;
+DROP INDEX IF EXISTS orders_updated_at_idx;
+DROP INDEX IF EXISTS orders_created_at_idx;
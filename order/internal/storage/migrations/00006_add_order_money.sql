@@ -0,0 +1,34 @@
+-- +goose Up
+-- Суммы заказа хранятся в минимальных единицах валюты, ранее созданные заказы выставлены в рублях
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS currency          TEXT NOT NULL DEFAULT 'RUB',
+    ADD COLUMN IF NOT EXISTS total_price_minor BIGINT;
+
+UPDATE orders
+SET total_price_minor = round(total_price * 100)::BIGINT
+WHERE total_price_minor IS NULL;
+
+ALTER TABLE orders
+    ALTER COLUMN total_price_minor SET NOT NULL,
+    ALTER COLUMN currency DROP DEFAULT;
+
+DROP INDEX IF EXISTS orders_total_price_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS total_price;
+CREATE INDEX IF NOT EXISTS orders_total_price_minor_idx ON orders (total_price_minor, order_uuid);
+
+-- Цена детали на момент создания заказа, у ранее созданных заказов неизвестна
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS unit_price_minor BIGINT CHECK (unit_price_minor >= 0);
+
+-- +goose Down
+ALTER TABLE order_items DROP COLUMN IF EXISTS unit_price_minor;
+
+DROP INDEX IF EXISTS orders_total_price_minor_idx;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_price DOUBLE PRECISION;
+UPDATE orders
+SET total_price = total_price_minor / 100.0;
+ALTER TABLE orders
+    ALTER COLUMN total_price SET NOT NULL,
+    DROP COLUMN IF EXISTS total_price_minor,
+    DROP COLUMN IF EXISTS currency;
+CREATE INDEX IF NOT EXISTS orders_total_price_idx ON orders (total_price, order_uuid);
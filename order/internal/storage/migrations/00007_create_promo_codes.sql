@@ -0,0 +1,54 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS promo_codes
+(
+    code              TEXT PRIMARY KEY,
+    discount_type     TEXT        NOT NULL,
+    percent           INTEGER CHECK (percent BETWEEN 1 AND 100),
+    amount_minor      BIGINT CHECK (amount_minor > 0),
+    currency          TEXT,
+    category          TEXT,
+    max_uses_per_user INTEGER     NOT NULL DEFAULT 0 CHECK (max_uses_per_user >= 0),
+    starts_at         TIMESTAMPTZ,
+    expires_at        TIMESTAMPTZ,
+    active            BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL
+);
+
+-- Применения промокодов, по одному на заказ. Лимит на пользователя считается по этой таблице
+CREATE TABLE IF NOT EXISTS promo_redemptions
+(
+    order_uuid  TEXT PRIMARY KEY,
+    code        TEXT        NOT NULL REFERENCES promo_codes (code) ON DELETE CASCADE,
+    user_uuid   TEXT        NOT NULL,
+    redeemed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS promo_redemptions_code_user_idx ON promo_redemptions (code, user_uuid);
+
+-- Ранее созданные заказы оформлены без скидки
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS subtotal_minor BIGINT,
+    ADD COLUMN IF NOT EXISTS discount_minor BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS promo_code     TEXT;
+
+UPDATE orders
+SET subtotal_minor = total_price_minor
+WHERE subtotal_minor IS NULL;
+
+ALTER TABLE orders
+    ALTER COLUMN subtotal_minor SET NOT NULL;
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS discount_minor BIGINT CHECK (discount_minor >= 0);
+
+-- +goose Down
+ALTER TABLE order_items DROP COLUMN IF EXISTS discount_minor;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS promo_code,
+    DROP COLUMN IF EXISTS discount_minor,
+    DROP COLUMN IF EXISTS subtotal_minor;
+
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promo_codes;
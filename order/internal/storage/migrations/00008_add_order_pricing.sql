@@ -0,0 +1,19 @@
+-- +goose Up
+-- Доставка и НДС не начислялись ранее созданным заказам, они пересчитываются при оплате
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS country            TEXT             NOT NULL DEFAULT 'RU',
+    ADD COLUMN IF NOT EXISTS shipping_weight_kg DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS shipping_minor     BIGINT           NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_rate_bp        INTEGER          NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_minor          BIGINT           NOT NULL DEFAULT 0;
+
+ALTER TABLE orders
+    ALTER COLUMN country DROP DEFAULT;
+
+-- +goose Down
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS tax_minor,
+    DROP COLUMN IF EXISTS tax_rate_bp,
+    DROP COLUMN IF EXISTS shipping_minor,
+    DROP COLUMN IF EXISTS shipping_weight_kg,
+    DROP COLUMN IF EXISTS country;
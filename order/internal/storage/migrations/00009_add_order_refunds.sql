@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS refunded_minor BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS refunded_minor;
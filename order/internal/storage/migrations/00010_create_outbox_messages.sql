@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS outbox_messages
+(
+    id            BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    message_uuid  TEXT        NOT NULL UNIQUE,
+    topic         TEXT        NOT NULL,
+    message_key   TEXT        NOT NULL,
+    event_type    TEXT        NOT NULL,
+    payload       BYTEA       NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL,
+    claimed_until TIMESTAMPTZ,
+    published_at  TIMESTAMPTZ
+);
+
+-- Неопубликованные сообщения выбираются в порядке записи
+CREATE INDEX IF NOT EXISTS outbox_messages_unpublished_idx ON outbox_messages (id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_messages_published_at_idx ON outbox_messages (published_at) WHERE published_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox_messages;
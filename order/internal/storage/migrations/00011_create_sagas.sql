@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS sagas
+(
+    saga_uuid       TEXT PRIMARY KEY,
+    order_uuid      TEXT        NOT NULL REFERENCES orders (order_uuid) ON DELETE CASCADE,
+    saga_type       TEXT        NOT NULL,
+    status          TEXT        NOT NULL,
+    step            INTEGER     NOT NULL,
+    data            JSONB       NOT NULL,
+    error           TEXT        NOT NULL DEFAULT '',
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    claimed_until   TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+-- У заказа может быть только одна незавершенная сага каждого типа
+CREATE UNIQUE INDEX IF NOT EXISTS sagas_active_order_uuid_idx ON sagas (order_uuid, saga_type)
+    WHERE status IN ('RUNNING', 'COMPENSATING');
+CREATE INDEX IF NOT EXISTS sagas_next_attempt_at_idx ON sagas (next_attempt_at)
+    WHERE status IN ('RUNNING', 'COMPENSATING');
+
+-- +goose Down
+DROP TABLE IF EXISTS sagas;
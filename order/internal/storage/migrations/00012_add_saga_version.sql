@@ -0,0 +1,8 @@
+-- +goose Up
+-- Версия саги меняется при каждом сохранении и занятии, сохранение с устаревшей версией отклоняется
+ALTER TABLE sagas
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE sagas
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+	eventsV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/events/v1"
+)
+
+// OrderEventsTopic топик, в который публикуются события заказов
+const OrderEventsTopic = "order.events"
+
+// OutboxMessage доменное событие, записанное в outbox вместе с изменением заказа и ожидающее публикации
+type OutboxMessage struct {
+	Uuid  string
+	Topic string
+	// Key ключ партиционирования, для событий заказа — uuid заказа
+	Key       string
+	EventType string
+	// Payload событие, сериализованное в protobuf
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// OutboxStorage описывает outbox доменных событий. Событие о смене статуса заказа записывается
+// атомарно с самим переходом в OrderStorage.CreateOrder и OrderStorage.UpdateOrder, изменение заказа
+// без смены статуса событий не создает
+type OutboxStorage interface {
+	// ClaimOutboxMessages занимает до limit неопубликованных сообщений в порядке записи до now+lease.
+	// Сообщение, не отмеченное опубликованным до истечения аренды, выдается повторно,
+	// поэтому при сбое публикации или падении реплики события доставляются не меньше одного раза
+	ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*OutboxMessage, error)
+	// MarkOutboxMessagesPublished отмечает сообщения опубликованными
+	MarkOutboxMessagesPublished(ctx context.Context, uuids []string, now time.Time) error
+	// DeletePublishedOutboxMessages удаляет сообщения, опубликованные раньше before, и возвращает их количество
+	DeletePublishedOutboxMessages(ctx context.Context, before time.Time) (int64, error)
+}
+
+// newOrderEvent создает сообщение outbox о переходе заказа в другой статус,
+// для записи без смены статуса возвращает nil
+func newOrderEvent(order *orderV1.OrderDto, transition *OrderTransition) (*OutboxMessage, error) {
+	if transition == nil || transition.From == transition.To {
+		return nil, nil
+	}
+
+	event := &eventsV1.OrderEvent{
+		EventUuid:       uuid.NewString(),
+		Type:            orderEventType(transition),
+		OrderUuid:       order.OrderUUID,
+		UserUuid:        order.UserUUID,
+		FromStatus:      eventStatus(transition.From),
+		ToStatus:        eventStatus(transition.To),
+		Actor:           transition.Actor,
+		Reason:          transition.Reason,
+		Items:           make([]*eventsV1.OrderEventItem, 0, len(order.Items)),
+		TotalPriceMinor: order.TotalPriceMinor,
+		RefundedMinor:   order.RefundedMinor,
+		Currency:        order.Currency,
+		TransactionUuid: order.TransactionUUID.Or(""),
+		OccurredAt:      timestamppb.New(transition.CreatedAt),
+	}
+	for _, line := range order.Items {
+		event.Items = append(event.Items, &eventsV1.OrderEventItem{
+			PartUuid: line.PartUUID,
+			Quantity: line.Quantity,
+		})
+	}
+
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshal order event: %w", err)
+	}
+
+	return &OutboxMessage{
+		Uuid:      event.GetEventUuid(),
+		Topic:     OrderEventsTopic,
+		Key:       order.OrderUUID,
+		EventType: event.GetType().String(),
+		Payload:   payload,
+		CreatedAt: transition.CreatedAt,
+	}, nil
+}
+
+// orderEventType возвращает тип события для перехода заказа
+func orderEventType(transition *OrderTransition) eventsV1.OrderEventType {
+	if transition.From == "" {
+		return eventsV1.OrderEventType_ORDER_EVENT_TYPE_CREATED
+	}
+
+	switch transition.To {
+	case orderV1.OrderStatusPAID:
+		return eventsV1.OrderEventType_ORDER_EVENT_TYPE_PAID
+	case orderV1.OrderStatusCANCELLED:
+		return eventsV1.OrderEventType_ORDER_EVENT_TYPE_CANCELLED
+	case orderV1.OrderStatusREFUNDED:
+		return eventsV1.OrderEventType_ORDER_EVENT_TYPE_REFUNDED
+	}
+
+	return eventsV1.OrderEventType_ORDER_EVENT_TYPE_STATUS_CHANGED
+}
+
+// eventStatus преобразует статус заказа API в статус схемы событий
+func eventStatus(s orderV1.OrderStatus) eventsV1.OrderStatus {
+	return eventsV1.OrderStatus(eventsV1.OrderStatus_value["ORDER_STATUS_"+string(s)])
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// outboxEntry сообщение outbox в памяти вместе с состоянием публикации
+type outboxEntry struct {
+	message      OutboxMessage
+	claimedUntil time.Time
+	publishedAt  time.Time
+}
+
+// appendOutbox добавляет сообщение в outbox, вызывается под блокировкой хранилища
+func (s *OrderStorageInMem) appendOutbox(message *OutboxMessage) {
+	if message == nil {
+		return
+	}
+
+	s.outbox = append(s.outbox, &outboxEntry{message: *message})
+}
+
+// ClaimOutboxMessages занимает до limit неопубликованных сообщений в порядке записи
+func (s *OrderStorageInMem) ClaimOutboxMessages(_ context.Context, now time.Time, lease time.Duration, limit int) ([]*OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]*OutboxMessage, 0, limit)
+	for _, entry := range s.outbox {
+		if len(messages) == limit {
+			break
+		}
+		if !entry.publishedAt.IsZero() || entry.claimedUntil.After(now) {
+			continue
+		}
+
+		entry.claimedUntil = now.Add(lease)
+		message := entry.message
+		message.Payload = slices.Clone(entry.message.Payload)
+		messages = append(messages, &message)
+	}
+
+	return messages, nil
+}
+
+// MarkOutboxMessagesPublished отмечает сообщения опубликованными
+func (s *OrderStorageInMem) MarkOutboxMessagesPublished(_ context.Context, uuids []string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range s.outbox {
+		if entry.publishedAt.IsZero() && slices.Contains(uuids, entry.message.Uuid) {
+			entry.publishedAt = now
+		}
+	}
+
+	return nil
+}
+
+// DeletePublishedOutboxMessages удаляет сообщения, опубликованные раньше before
+func (s *OrderStorageInMem) DeletePublishedOutboxMessages(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.outbox)
+	s.outbox = slices.DeleteFunc(s.outbox, func(entry *outboxEntry) bool {
+		return !entry.publishedAt.IsZero() && entry.publishedAt.Before(before)
+	})
+
+	return int64(n - len(s.outbox)), nil
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+// insertOrderEvent записывает в outbox событие о переходе заказа в той же транзакции, что и переход
+func insertOrderEvent(ctx context.Context, tx pgx.Tx, order *orderV1.OrderDto, transition *OrderTransition) error {
+	message, err := newOrderEvent(order, transition)
+	if err != nil || message == nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO outbox_messages (message_uuid, topic, message_key, event_type, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		message.Uuid,
+		message.Topic,
+		message.Key,
+		message.EventType,
+		message.Payload,
+		message.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimOutboxMessages занимает до limit неопубликованных сообщений в порядке записи. Строки, занятые
+// параллельной репликой, пропускаются, поэтому реплики не публикуют одни и те же сообщения одновременно
+func (s *OrderStoragePostgres) ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*OutboxMessage, error) {
+	rows, err := s.pool.Query(ctx, `
+		UPDATE outbox_messages
+		SET claimed_until = $2
+		WHERE id IN (SELECT id
+		             FROM outbox_messages
+		             WHERE published_at IS NULL AND (claimed_until IS NULL OR claimed_until <= $1)
+		             ORDER BY id
+		             LIMIT $3 FOR UPDATE SKIP LOCKED)
+		RETURNING id, message_uuid, topic, message_key, event_type, payload, created_at`,
+		now,
+		now.Add(lease),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	type claimed struct {
+		id      int64
+		message *OutboxMessage
+	}
+
+	var messages []claimed
+	for rows.Next() {
+		var (
+			id      int64
+			message OutboxMessage
+		)
+
+		err = rows.Scan(&id, &message.Uuid, &message.Topic, &message.Key, &message.EventType, &message.Payload, &message.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+
+		messages = append(messages, claimed{id: id, message: &message})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("claim outbox messages: %w", err)
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	slices.SortFunc(messages, func(a, b claimed) int {
+		return cmp.Compare(a.id, b.id)
+	})
+
+	result := make([]*OutboxMessage, 0, len(messages))
+	for _, m := range messages {
+		result = append(result, m.message)
+	}
+
+	return result, nil
+}
+
+// MarkOutboxMessagesPublished отмечает сообщения опубликованными
+func (s *OrderStoragePostgres) MarkOutboxMessagesPublished(ctx context.Context, uuids []string, now time.Time) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE outbox_messages
+		SET published_at = $2
+		WHERE message_uuid = ANY ($1) AND published_at IS NULL`,
+		uuids,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("mark outbox messages published: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePublishedOutboxMessages удаляет сообщения, опубликованные раньше before
+func (s *OrderStoragePostgres) DeletePublishedOutboxMessages(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM outbox_messages WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete published outbox messages: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
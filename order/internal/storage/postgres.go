@@ -0,0 +1,529 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Igorezka/rocket-factory/shared/pkg/money"
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+// uniqueViolationCode код ошибки PostgreSQL при нарушении уникальности
+const uniqueViolationCode = "23505"
+
+// OrderStoragePostgres представляет хранилище данных о заказах в PostgreSQL
+type OrderStoragePostgres struct {
+	pool *pgxpool.Pool
+}
+
+// NewOrderStoragePostgres создает новое хранилище данных о заказах в PostgreSQL
+func NewOrderStoragePostgres(pool *pgxpool.Pool) *OrderStoragePostgres {
+	return &OrderStoragePostgres{
+		pool: pool,
+	}
+}
+
+// orderColumns колонки заказа в порядке чтения scanOrder
+const orderColumns = `order_uuid, user_uuid, part_uuids, subtotal_minor, discount_minor, shipping_weight_kg,
+	shipping_minor, tax_rate_bp, tax_minor, total_price_minor, refunded_minor, promo_code, country, currency,
+	transaction_uuid, payment_method, status, created_at, updated_at`
+
+// orderSortColumns колонки сортировки заказов
+var orderSortColumns = map[OrderSortField]string{
+	OrderSortByCreatedAt:  "created_at",
+	OrderSortByUpdatedAt:  "updated_at",
+	OrderSortByTotalPrice: "total_price_minor",
+}
+
+// GetOrder возвращает информацию о заказе по uuid из хранилища
+func (s *OrderStoragePostgres) GetOrder(ctx context.Context, orderUuid string) (*orderV1.OrderDto, error) {
+	order, err := scanOrder(s.pool.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders WHERE order_uuid = $1`, orderUuid))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+
+		return nil, fmt.Errorf("select order: %w", err)
+	}
+
+	items, err := s.orderItems(ctx, []string{orderUuid})
+	if err != nil {
+		return nil, err
+	}
+	order.Items = items[orderUuid]
+
+	return order, nil
+}
+
+// ListOrders возвращает заказы, подходящие под фильтр, в порядке сортировки.
+// Страницы выбираются по курсору (значение поля сортировки, uuid), а не по смещению
+func (s *OrderStoragePostgres) ListOrders(ctx context.Context, query OrderQuery) ([]*orderV1.OrderDto, error) {
+	sortColumn, ok := orderSortColumns[query.SortBy]
+	if !ok {
+		sortColumn = orderSortColumns[OrderSortByCreatedAt]
+	}
+
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := orderFilterConditions(query.Filter, arg)
+
+	direction, comparison := "ASC", ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if query.After != nil {
+		var value any
+		switch query.SortBy {
+		case OrderSortByUpdatedAt:
+			value = query.After.UpdatedAt
+		case OrderSortByTotalPrice:
+			value = query.After.TotalPriceMinor
+		default:
+			value = query.After.CreatedAt
+		}
+		conditions = append(conditions,
+			fmt.Sprintf("(%s, order_uuid) %s (%s, %s)", sortColumn, comparison, arg(value), arg(query.After.OrderUuid)))
+	}
+
+	sql := `SELECT ` + orderColumns + ` FROM orders`
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sql += fmt.Sprintf(" ORDER BY %s %s, order_uuid %s", sortColumn, direction, direction)
+	if query.Limit > 0 {
+		sql += " LIMIT " + arg(query.Limit)
+	}
+
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select orders: %w", err)
+	}
+
+	orders, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*orderV1.OrderDto, error) {
+		return scanOrder(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan orders: %w", err)
+	}
+
+	orderUuids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		orderUuids = append(orderUuids, order.OrderUUID)
+	}
+
+	items, err := s.orderItems(ctx, orderUuids)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		order.Items = items[order.OrderUUID]
+	}
+
+	return orders, nil
+}
+
+// orderFilterConditions возвращает SQL-условия фильтра заказов, значения передаются через arg
+func orderFilterConditions(filter OrderFilter, arg func(v any) string) []string {
+	var conditions []string
+
+	if filter.UserUuid != "" {
+		conditions = append(conditions, "user_uuid = "+arg(filter.UserUuid))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		conditions = append(conditions, "status = ANY("+arg(statuses)+")")
+	}
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, "created_at >= "+arg(filter.CreatedFrom))
+	}
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, "created_at < "+arg(filter.CreatedTo))
+	}
+	if filter.PartUuid != "" {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM order_items i WHERE i.order_uuid = orders.order_uuid AND i.part_uuid = `+arg(filter.PartUuid)+`)`)
+	}
+
+	return conditions
+}
+
+// scanOrder читает заказ без позиций из строки результата запроса
+func scanOrder(row pgx.Row) (*orderV1.OrderDto, error) {
+	var (
+		order           orderV1.OrderDto
+		promoCode       *string
+		transactionUuid *string
+		paymentMethod   *string
+	)
+
+	err := row.Scan(
+		&order.OrderUUID,
+		&order.UserUUID,
+		&order.PartUuids,
+		&order.SubtotalMinor,
+		&order.DiscountMinor,
+		&order.ShippingWeightKg,
+		&order.ShippingMinor,
+		&order.TaxRateBp,
+		&order.TaxMinor,
+		&order.TotalPriceMinor,
+		&order.RefundedMinor,
+		&promoCode,
+		&order.Country,
+		&order.Currency,
+		&transactionUuid,
+		&paymentMethod,
+		&order.Status,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	order.TotalPrice = money.FromMinor(order.TotalPriceMinor, order.Currency) //nolint:staticcheck // заполняем устаревшее поле для старых клиентов
+
+	if promoCode != nil {
+		order.PromoCode = orderV1.NewOptString(*promoCode)
+	}
+	if transactionUuid != nil {
+		order.TransactionUUID = orderV1.NewOptString(*transactionUuid)
+	}
+	if paymentMethod != nil {
+		order.PaymentMethod = orderV1.NewOptPaymentMethod(orderV1.PaymentMethod(*paymentMethod))
+	}
+
+	return &order, nil
+}
+
+// orderItems возвращает позиции заказов, сгруппированные по uuid заказа
+func (s *OrderStoragePostgres) orderItems(ctx context.Context, orderUuids []string) (map[string][]orderV1.OrderLine, error) {
+	items := make(map[string][]orderV1.OrderLine, len(orderUuids))
+	if len(orderUuids) == 0 {
+		return items, nil
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT order_uuid, part_uuid, quantity, unit_price_minor, discount_minor
+		FROM order_items
+		WHERE order_uuid = ANY($1)
+		ORDER BY order_uuid, part_uuid`,
+		orderUuids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select order items: %w", err)
+	}
+
+	var (
+		orderUuid      string
+		partUuid       string
+		quantity       int64
+		unitPriceMinor *int64
+		discountMinor  *int64
+	)
+	_, err = pgx.ForEachRow(rows, []any{&orderUuid, &partUuid, &quantity, &unitPriceMinor, &discountMinor}, func() error {
+		item := orderV1.OrderLine{
+			PartUUID: partUuid,
+			Quantity: quantity,
+		}
+		// Стоимость позиции не хранится, а вычисляется из цены, переполнение проверено при создании заказа
+		if unitPriceMinor != nil {
+			item.UnitPriceMinor = orderV1.NewOptInt64(*unitPriceMinor)
+			item.LineTotalMinor = orderV1.NewOptInt64(*unitPriceMinor * quantity)
+		}
+		if discountMinor != nil {
+			item.DiscountMinor = orderV1.NewOptInt64(*discountMinor)
+		}
+
+		items[orderUuid] = append(items[orderUuid], item)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan order items: %w", err)
+	}
+
+	return items, nil
+}
+
+// CreateOrder сохраняет новый заказ вместе с позициями в хранилище
+func (s *OrderStoragePostgres) CreateOrder(ctx context.Context, order *orderV1.OrderDto, transition *OrderTransition) error {
+	transactionUuid, paymentMethod := paymentColumns(order)
+
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO orders (order_uuid, user_uuid, part_uuids, subtotal_minor, discount_minor, shipping_weight_kg,
+			                    shipping_minor, tax_rate_bp, tax_minor, total_price_minor, refunded_minor, promo_code,
+			                    country, currency, transaction_uuid, payment_method, status, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+			order.OrderUUID,
+			order.UserUUID,
+			partUuidsColumn(order),
+			order.SubtotalMinor,
+			order.DiscountMinor,
+			order.ShippingWeightKg,
+			order.ShippingMinor,
+			order.TaxRateBp,
+			order.TaxMinor,
+			order.TotalPriceMinor,
+			order.RefundedMinor,
+			promoCodeColumn(order),
+			order.Country,
+			order.Currency,
+			transactionUuid,
+			paymentMethod,
+			string(order.Status),
+			order.CreatedAt,
+			order.UpdatedAt,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+				return ErrOrderAlreadyExists
+			}
+
+			return fmt.Errorf("insert order: %w", err)
+		}
+
+		if err = insertOrderItems(ctx, tx, order); err != nil {
+			return err
+		}
+
+		if err = insertOrderTransition(ctx, tx, transition); err != nil {
+			return err
+		}
+
+		return insertOrderEvent(ctx, tx, order, transition)
+	})
+}
+
+// UpdateOrder обновляет существующий заказ вместе с позициями в хранилище. Строка заказа блокируется
+// до конца транзакции, поэтому параллельные переходы статуса выполняются последовательно
+func (s *OrderStoragePostgres) UpdateOrder(ctx context.Context, order *orderV1.OrderDto, transition *OrderTransition) error {
+	transactionUuid, paymentMethod := paymentColumns(order)
+
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		var currentStatus orderV1.OrderStatus
+		err := tx.QueryRow(ctx, `SELECT status FROM orders WHERE order_uuid = $1 FOR UPDATE`, order.OrderUUID).
+			Scan(&currentStatus)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrOrderNotFound
+			}
+
+			return fmt.Errorf("lock order: %w", err)
+		}
+
+		if transition != nil && currentStatus != transition.From {
+			return ErrOrderStatusConflict
+		}
+
+		tag, err := tx.Exec(ctx, `
+			UPDATE orders
+			SET user_uuid          = $2,
+			    part_uuids         = $3,
+			    subtotal_minor     = $4,
+			    discount_minor     = $5,
+			    shipping_weight_kg = $6,
+			    shipping_minor     = $7,
+			    tax_rate_bp        = $8,
+			    tax_minor          = $9,
+			    total_price_minor  = $10,
+			    refunded_minor     = $11,
+			    promo_code         = $12,
+			    country            = $13,
+			    currency           = $14,
+			    transaction_uuid   = $15,
+			    payment_method     = $16,
+			    status             = $17,
+			    updated_at         = $18
+			WHERE order_uuid = $1`,
+			order.OrderUUID,
+			order.UserUUID,
+			partUuidsColumn(order),
+			order.SubtotalMinor,
+			order.DiscountMinor,
+			order.ShippingWeightKg,
+			order.ShippingMinor,
+			order.TaxRateBp,
+			order.TaxMinor,
+			order.TotalPriceMinor,
+			order.RefundedMinor,
+			promoCodeColumn(order),
+			order.Country,
+			order.Currency,
+			transactionUuid,
+			paymentMethod,
+			string(order.Status),
+			order.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("update order: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return ErrOrderNotFound
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM order_items WHERE order_uuid = $1`, order.OrderUUID)
+		if err != nil {
+			return fmt.Errorf("delete order items: %w", err)
+		}
+
+		if err = insertOrderItems(ctx, tx, order); err != nil {
+			return err
+		}
+
+		if err = insertOrderTransition(ctx, tx, transition); err != nil {
+			return err
+		}
+
+		return insertOrderEvent(ctx, tx, order, transition)
+	})
+}
+
+// GetOrderHistory возвращает переходы статуса заказа в порядке их выполнения
+func (s *OrderStoragePostgres) GetOrderHistory(ctx context.Context, orderUuid string) ([]OrderTransition, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE order_uuid = $1)`, orderUuid).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("select order: %w", err)
+	}
+	if !exists {
+		return nil, ErrOrderNotFound
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT order_uuid, coalesce(from_status, ''), to_status, actor, reason, created_at
+		FROM order_status_history
+		WHERE order_uuid = $1
+		ORDER BY id`,
+		orderUuid,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select order history: %w", err)
+	}
+
+	history, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (OrderTransition, error) {
+		var transition OrderTransition
+		err := row.Scan(
+			&transition.OrderUuid,
+			&transition.From,
+			&transition.To,
+			&transition.Actor,
+			&transition.Reason,
+			&transition.CreatedAt,
+		)
+		return transition, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan order history: %w", err)
+	}
+
+	return history, nil
+}
+
+// insertOrderTransition записывает переход статуса в историю в рамках транзакции
+func insertOrderTransition(ctx context.Context, tx pgx.Tx, transition *OrderTransition) error {
+	if transition == nil {
+		return nil
+	}
+
+	var from *string
+	if transition.From != "" {
+		f := string(transition.From)
+		from = &f
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO order_status_history (order_uuid, from_status, to_status, actor, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		transition.OrderUuid,
+		from,
+		string(transition.To),
+		transition.Actor,
+		transition.Reason,
+		transition.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert order transition: %w", err)
+	}
+
+	return nil
+}
+
+// insertOrderItems сохраняет позиции заказа в рамках транзакции
+func insertOrderItems(ctx context.Context, tx pgx.Tx, order *orderV1.OrderDto) error {
+	if len(order.Items) == 0 {
+		return nil
+	}
+
+	rows := make([][]any, 0, len(order.Items))
+	for _, item := range order.Items {
+		var unitPriceMinor, discountMinor *int64
+		if v, ok := item.UnitPriceMinor.Get(); ok {
+			unitPriceMinor = &v
+		}
+		if v, ok := item.DiscountMinor.Get(); ok {
+			discountMinor = &v
+		}
+
+		rows = append(rows, []any{order.OrderUUID, item.PartUUID, item.Quantity, unitPriceMinor, discountMinor})
+	}
+
+	_, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"order_items"},
+		[]string{"order_uuid", "part_uuid", "quantity", "unit_price_minor", "discount_minor"},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return fmt.Errorf("insert order items: %w", err)
+	}
+
+	return nil
+}
+
+// partUuidsColumn возвращает список деталей заказа, пустой список вместо nil для NOT NULL колонки
+func partUuidsColumn(order *orderV1.OrderDto) []string {
+	if order.PartUuids == nil {
+		return []string{}
+	}
+
+	return order.PartUuids
+}
+
+// promoCodeColumn возвращает примененный промокод заказа, nil если заказ оформлен без промокода
+func promoCodeColumn(order *orderV1.OrderDto) *string {
+	if v, ok := order.PromoCode.Get(); ok {
+		return &v
+	}
+
+	return nil
+}
+
+// paymentColumns возвращает значения опциональных платежных полей заказа для записи в хранилище
+func paymentColumns(order *orderV1.OrderDto) (transactionUuid, paymentMethod *string) {
+	if v, ok := order.TransactionUUID.Get(); ok {
+		transactionUuid = &v
+	}
+	if v, ok := order.PaymentMethod.Get(); ok {
+		m := string(v)
+		paymentMethod = &m
+	}
+
+	return transactionUuid, paymentMethod
+}
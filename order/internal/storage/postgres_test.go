@@ -0,0 +1,666 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+// testPostgresDSNEnv переменная окружения с адресом временной базы данных для интеграционных тестов
+const testPostgresDSNEnv = "ORDER_TEST_POSTGRES_DSN"
+
+// newTestPool создает пул соединений к отдельной схеме временной базы данных
+// и применяет к ней миграции, схема удаляется по завершении теста
+func newTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv(testPostgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testPostgresDSNEnv)
+	}
+
+	ctx := context.Background()
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	admin, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect to postgres: %v", err)
+	}
+	if _, err = admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("drop schema: %v", err)
+		}
+		_ = admin.Close(context.Background())
+	})
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("parse dsn: %v", err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	if err = Migrate(ctx, pool); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return pool
+}
+
+func TestOrderStoragePostgres(t *testing.T) {
+	ctx := context.Background()
+	s := NewOrderStoragePostgres(newTestPool(t))
+
+	partUuid := uuid.NewString()
+	order := &orderV1.OrderDto{
+		OrderUUID: uuid.NewString(),
+		UserUUID:  uuid.NewString(),
+		Items: []orderV1.OrderLine{{
+			PartUUID:       partUuid,
+			Quantity:       2,
+			UnitPriceMinor: orderV1.NewOptInt64(67567),
+			DiscountMinor:  orderV1.NewOptInt64(13513),
+			LineTotalMinor: orderV1.NewOptInt64(135134),
+		}},
+		PartUuids:        []string{partUuid, partUuid},
+		SubtotalMinor:    135134,
+		DiscountMinor:    13513,
+		ShippingWeightKg: 12.5,
+		ShippingMinor:    89000,
+		TaxRateBp:        2200,
+		TaxMinor:         46337,
+		TotalPriceMinor:  256958,
+		RefundedMinor:    10000,
+		PromoCode:        orderV1.NewOptString("SPRING10"),
+		Country:          "RU",
+		Currency:         "RUB",
+		Status:           orderV1.OrderStatusPENDINGPAYMENT,
+	}
+
+	if err := s.CreateOrder(ctx, order, nil); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	if err := s.CreateOrder(ctx, order, nil); !errors.Is(err, ErrOrderAlreadyExists) {
+		t.Fatalf("CreateOrder duplicate: got %v, want %v", err, ErrOrderAlreadyExists)
+	}
+
+	got, err := s.GetOrder(ctx, order.OrderUUID)
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if got.UserUUID != order.UserUUID || got.SubtotalMinor != order.SubtotalMinor ||
+		got.DiscountMinor != order.DiscountMinor || got.ShippingWeightKg != order.ShippingWeightKg ||
+		got.ShippingMinor != order.ShippingMinor || got.TaxRateBp != order.TaxRateBp || got.TaxMinor != order.TaxMinor ||
+		got.TotalPriceMinor != order.TotalPriceMinor || got.RefundedMinor != order.RefundedMinor || got.PromoCode != order.PromoCode ||
+		got.Country != order.Country || got.Currency != order.Currency || got.Status != order.Status {
+		t.Fatalf("GetOrder: got %+v, want %+v", got, order)
+	}
+	if len(got.PartUuids) != len(order.PartUuids) {
+		t.Fatalf("GetOrder: got parts %v, want %v", got.PartUuids, order.PartUuids)
+	}
+	if len(got.Items) != 1 || got.Items[0] != order.Items[0] {
+		t.Fatalf("GetOrder: got items %v, want %v", got.Items, order.Items)
+	}
+	if got.TransactionUUID.Set || got.PaymentMethod.Set {
+		t.Fatalf("GetOrder: unexpected payment info %+v", got)
+	}
+
+	got.Status = orderV1.OrderStatusPAID
+	got.TransactionUUID = orderV1.NewOptString(uuid.NewString())
+	got.PaymentMethod = orderV1.NewOptPaymentMethod(orderV1.PaymentMethodPAYMENTMETHODCARD)
+
+	if err = s.UpdateOrder(ctx, got, nil); err != nil {
+		t.Fatalf("UpdateOrder: %v", err)
+	}
+
+	updated, err := s.GetOrder(ctx, order.OrderUUID)
+	if err != nil {
+		t.Fatalf("GetOrder after update: %v", err)
+	}
+	if updated.Status != orderV1.OrderStatusPAID ||
+		updated.TransactionUUID != got.TransactionUUID ||
+		updated.PaymentMethod != got.PaymentMethod {
+		t.Fatalf("GetOrder after update: got %+v, want %+v", updated, got)
+	}
+}
+
+func TestOrderStoragePostgresNotFound(t *testing.T) {
+	ctx := context.Background()
+	s := NewOrderStoragePostgres(newTestPool(t))
+
+	if _, err := s.GetOrder(ctx, uuid.NewString()); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("GetOrder: got %v, want %v", err, ErrOrderNotFound)
+	}
+
+	err := s.UpdateOrder(ctx, &orderV1.OrderDto{
+		OrderUUID: uuid.NewString(),
+		Status:    orderV1.OrderStatusCANCELLED,
+	}, nil)
+	if !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("UpdateOrder: got %v, want %v", err, ErrOrderNotFound)
+	}
+}
+
+func TestOrderStoragePostgresHistory(t *testing.T) {
+	ctx := context.Background()
+	s := NewOrderStoragePostgres(newTestPool(t))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	order := &orderV1.OrderDto{
+		OrderUUID: uuid.NewString(),
+		UserUUID:  uuid.NewString(),
+		Status:    orderV1.OrderStatusPENDINGPAYMENT,
+	}
+	created := &OrderTransition{
+		OrderUuid: order.OrderUUID,
+		To:        orderV1.OrderStatusPENDINGPAYMENT,
+		Actor:     "user:" + order.UserUUID,
+		Reason:    "Order created",
+		CreatedAt: now,
+	}
+
+	if err := s.CreateOrder(ctx, order, created); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	order.Status = orderV1.OrderStatusPAID
+	paid := &OrderTransition{
+		OrderUuid: order.OrderUUID,
+		From:      orderV1.OrderStatusPENDINGPAYMENT,
+		To:        orderV1.OrderStatusPAID,
+		Actor:     "user:" + order.UserUUID,
+		Reason:    "Order paid",
+		CreatedAt: now.Add(time.Second),
+	}
+	if err := s.UpdateOrder(ctx, order, paid); err != nil {
+		t.Fatalf("UpdateOrder: %v", err)
+	}
+
+	// Повторный переход из устаревшего статуса отклоняется
+	order.Status = orderV1.OrderStatusCANCELLED
+	cancelled := &OrderTransition{
+		OrderUuid: order.OrderUUID,
+		From:      orderV1.OrderStatusPENDINGPAYMENT,
+		To:        orderV1.OrderStatusCANCELLED,
+		Actor:     "system",
+		CreatedAt: now.Add(2 * time.Second),
+	}
+	if err := s.UpdateOrder(ctx, order, cancelled); !errors.Is(err, ErrOrderStatusConflict) {
+		t.Fatalf("UpdateOrder stale: got %v, want %v", err, ErrOrderStatusConflict)
+	}
+
+	history, err := s.GetOrderHistory(ctx, order.OrderUUID)
+	if err != nil {
+		t.Fatalf("GetOrderHistory: %v", err)
+	}
+	want := []*OrderTransition{created, paid}
+	if len(history) != len(want) {
+		t.Fatalf("GetOrderHistory: got %+v, want %d transitions", history, len(want))
+	}
+	for i, w := range want {
+		got := history[i]
+		if got.From != w.From || got.To != w.To || got.Actor != w.Actor || got.Reason != w.Reason ||
+			!got.CreatedAt.Equal(w.CreatedAt) {
+			t.Fatalf("GetOrderHistory[%d]: got %+v, want %+v", i, got, *w)
+		}
+	}
+
+	if _, err = s.GetOrderHistory(ctx, uuid.NewString()); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("GetOrderHistory: got %v, want %v", err, ErrOrderNotFound)
+	}
+}
+
+func TestOrderStoragePostgresListOrders(t *testing.T) {
+	ctx := context.Background()
+	s := NewOrderStoragePostgres(newTestPool(t))
+
+	start := time.Now().UTC().Truncate(time.Microsecond)
+	userUuid := uuid.NewString()
+	partUuid := uuid.NewString()
+
+	var orders []*orderV1.OrderDto
+	for i := range 5 {
+		order := &orderV1.OrderDto{
+			OrderUUID:       uuid.NewString(),
+			UserUUID:        userUuid,
+			Items:           []orderV1.OrderLine{{PartUUID: uuid.NewString(), Quantity: 1}},
+			SubtotalMinor:   int64(10000 * (i + 1)),
+			TotalPriceMinor: int64(10000 * (i + 1)),
+			Country:         "RU",
+			Currency:        "RUB",
+			Status:          orderV1.OrderStatusPENDINGPAYMENT,
+			CreatedAt:       start.Add(time.Duration(i) * time.Minute),
+			UpdatedAt:       start.Add(time.Duration(i) * time.Minute),
+		}
+		if i%2 == 0 {
+			order.Items = append(order.Items, orderV1.OrderLine{PartUUID: partUuid, Quantity: 2})
+		}
+		if i == 4 {
+			order.Status = orderV1.OrderStatusPAID
+		}
+
+		if err := s.CreateOrder(ctx, order, nil); err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+		orders = append(orders, order)
+	}
+
+	// Заказ другого пользователя не должен попадать в выборку
+	other := &orderV1.OrderDto{
+		OrderUUID: uuid.NewString(),
+		UserUUID:  uuid.NewString(),
+		Status:    orderV1.OrderStatusPENDINGPAYMENT,
+		CreatedAt: start,
+		UpdatedAt: start,
+	}
+	if err := s.CreateOrder(ctx, other, nil); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	// Постранично обходим заказы пользователя по убыванию времени создания
+	query := OrderQuery{
+		Filter:     OrderFilter{UserUuid: userUuid},
+		SortBy:     OrderSortByCreatedAt,
+		Descending: true,
+		Limit:      2,
+	}
+	var got []string
+	for {
+		page, err := s.ListOrders(ctx, query)
+		if err != nil {
+			t.Fatalf("ListOrders: %v", err)
+		}
+		for _, order := range page {
+			got = append(got, order.OrderUUID)
+		}
+		if len(page) < query.Limit {
+			break
+		}
+		query.After = CursorOf(page[len(page)-1])
+	}
+
+	want := []string{orders[4].OrderUUID, orders[3].OrderUUID, orders[2].OrderUUID, orders[1].OrderUUID, orders[0].OrderUUID}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("ListOrders pages: got %v, want %v", got, want)
+	}
+
+	filtered, err := s.ListOrders(ctx, OrderQuery{
+		Filter: OrderFilter{
+			Statuses:    []orderV1.OrderStatus{orderV1.OrderStatusPENDINGPAYMENT},
+			CreatedFrom: start,
+			CreatedTo:   start.Add(4 * time.Minute),
+			PartUuid:    partUuid,
+		},
+		SortBy: OrderSortByTotalPrice,
+	})
+	if err != nil {
+		t.Fatalf("ListOrders filtered: %v", err)
+	}
+	if len(filtered) != 2 || filtered[0].OrderUUID != orders[0].OrderUUID || filtered[1].OrderUUID != orders[2].OrderUUID {
+		t.Fatalf("ListOrders filtered: got %d orders, want orders 0 and 2", len(filtered))
+	}
+	if len(filtered[0].Items) != 2 {
+		t.Fatalf("ListOrders filtered: got items %v, want 2 items", filtered[0].Items)
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	pool := newTestPool(t)
+
+	if err := Migrate(context.Background(), pool); err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+}
+
+func TestIdempotencyStoragePostgres(t *testing.T) {
+	ctx := context.Background()
+	s := NewIdempotencyStoragePostgres(newTestPool(t))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	record := &IdempotencyRecord{
+		Key:         uuid.NewString(),
+		RequestHash: "hash",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+
+	existing, err := s.BeginIdempotentRequest(ctx, record, now.Add(-time.Minute))
+	if err != nil || existing != nil {
+		t.Fatalf("BeginIdempotentRequest: got %+v, %v, want key acquired", existing, err)
+	}
+
+	existing, err = s.BeginIdempotentRequest(ctx, record, now.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("BeginIdempotentRequest in progress: %v", err)
+	}
+	if existing == nil || !existing.InProgress() {
+		t.Fatalf("BeginIdempotentRequest in progress: got %+v, want record in progress", existing)
+	}
+
+	body := []byte(`{"order_uuid":"test"}`)
+	if err = s.CompleteIdempotentRequest(ctx, record.Key, 200, "application/json", body); err != nil {
+		t.Fatalf("CompleteIdempotentRequest: %v", err)
+	}
+
+	// Завершенная запись не перезаписывается даже после таймаута незавершенной обработки
+	existing, err = s.BeginIdempotentRequest(ctx, record, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("BeginIdempotentRequest completed: %v", err)
+	}
+	if existing == nil || existing.StatusCode != 200 || string(existing.Body) != string(body) {
+		t.Fatalf("BeginIdempotentRequest completed: got %+v, want saved response", existing)
+	}
+
+	deleted, err := s.DeleteExpiredIdempotencyKeys(ctx, now.Add(2*time.Hour))
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteExpiredIdempotencyKeys: got %d, %v, want 1", deleted, err)
+	}
+
+	if err = s.CompleteIdempotentRequest(ctx, record.Key, 200, "", nil); !errors.Is(err, ErrIdempotencyKeyNotFound) {
+		t.Fatalf("CompleteIdempotentRequest: got %v, want %v", err, ErrIdempotencyKeyNotFound)
+	}
+}
+
+func TestPromoStoragePostgres(t *testing.T) {
+	ctx := context.Background()
+	s := NewPromoStoragePostgres(newTestPool(t))
+
+	if _, err := s.GetPromoCode(ctx, "MISSING"); !errors.Is(err, ErrPromoCodeNotFound) {
+		t.Fatalf("GetPromoCode missing: got %v, want %v", err, ErrPromoCodeNotFound)
+	}
+
+	promo := &orderV1.PromoCodeDto{
+		Code:           "ENGINE10",
+		DiscountType:   orderV1.DiscountTypePERCENTAGE,
+		Percent:        orderV1.NewOptInt32(10),
+		Category:       orderV1.NewOptPartCategory(orderV1.PartCategoryCATEGORYENGINE),
+		MaxUsesPerUser: 1,
+		ExpiresAt:      orderV1.NewOptDateTime(time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)),
+		Active:         true,
+	}
+
+	created, err := s.PutPromoCode(ctx, promo)
+	if err != nil {
+		t.Fatalf("PutPromoCode: %v", err)
+	}
+	if created.Percent != promo.Percent || created.Category != promo.Category ||
+		!created.ExpiresAt.Value.Equal(promo.ExpiresAt.Value) || created.AmountMinor.Set || created.StartsAt.Set {
+		t.Fatalf("PutPromoCode: got %+v, want %+v", created, promo)
+	}
+
+	promo.Active = false
+	updated, err := s.PutPromoCode(ctx, promo)
+	if err != nil {
+		t.Fatalf("PutPromoCode update: %v", err)
+	}
+	if updated.Active || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Fatalf("PutPromoCode update: got %+v, created %+v", updated, created)
+	}
+
+	userUuid := uuid.NewString()
+	orderUuid := uuid.NewString()
+	if err = s.RedeemPromoCode(ctx, promo.Code, userUuid, orderUuid); err != nil {
+		t.Fatalf("RedeemPromoCode: %v", err)
+	}
+	if err = s.RedeemPromoCode(ctx, promo.Code, userUuid, uuid.NewString()); !errors.Is(err, ErrPromoCodeUsageLimitReached) {
+		t.Fatalf("RedeemPromoCode over limit: got %v, want %v", err, ErrPromoCodeUsageLimitReached)
+	}
+	if err = s.RedeemPromoCode(ctx, promo.Code, uuid.NewString(), uuid.NewString()); err != nil {
+		t.Fatalf("RedeemPromoCode other user: %v", err)
+	}
+
+	if err = s.ReleasePromoCode(ctx, orderUuid); err != nil {
+		t.Fatalf("ReleasePromoCode: %v", err)
+	}
+	if err = s.RedeemPromoCode(ctx, promo.Code, userUuid, uuid.NewString()); err != nil {
+		t.Fatalf("RedeemPromoCode after release: %v", err)
+	}
+
+	promos, err := s.ListPromoCodes(ctx)
+	if err != nil {
+		t.Fatalf("ListPromoCodes: %v", err)
+	}
+	if len(promos) != 1 || promos[0].Code != promo.Code {
+		t.Fatalf("ListPromoCodes: got %v", promos)
+	}
+
+	if err = s.DeletePromoCode(ctx, promo.Code); err != nil {
+		t.Fatalf("DeletePromoCode: %v", err)
+	}
+	if err = s.DeletePromoCode(ctx, promo.Code); !errors.Is(err, ErrPromoCodeNotFound) {
+		t.Fatalf("DeletePromoCode missing: got %v, want %v", err, ErrPromoCodeNotFound)
+	}
+}
+
+func TestOutboxStoragePostgres(t *testing.T) {
+	ctx := context.Background()
+	s := NewOrderStoragePostgres(newTestPool(t))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	order := &orderV1.OrderDto{
+		OrderUUID: uuid.NewString(),
+		UserUUID:  uuid.NewString(),
+		Status:    orderV1.OrderStatusPENDINGPAYMENT,
+	}
+	created := &OrderTransition{
+		OrderUuid: order.OrderUUID,
+		To:        orderV1.OrderStatusPENDINGPAYMENT,
+		Actor:     "user:" + order.UserUUID,
+		Reason:    "Order created",
+		CreatedAt: now,
+	}
+	if err := s.CreateOrder(ctx, order, created); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	// Изменение без смены статуса событие не создает
+	amended := &OrderTransition{
+		OrderUuid: order.OrderUUID,
+		From:      orderV1.OrderStatusPENDINGPAYMENT,
+		To:        orderV1.OrderStatusPENDINGPAYMENT,
+		Actor:     "system",
+		CreatedAt: now.Add(time.Second),
+	}
+	if err := s.UpdateOrder(ctx, order, amended); err != nil {
+		t.Fatalf("UpdateOrder amended: %v", err)
+	}
+
+	order.Status = orderV1.OrderStatusPAID
+	paid := &OrderTransition{
+		OrderUuid: order.OrderUUID,
+		From:      orderV1.OrderStatusPENDINGPAYMENT,
+		To:        orderV1.OrderStatusPAID,
+		Actor:     "user:" + order.UserUUID,
+		CreatedAt: now.Add(2 * time.Second),
+	}
+	if err := s.UpdateOrder(ctx, order, paid); err != nil {
+		t.Fatalf("UpdateOrder paid: %v", err)
+	}
+
+	messages, err := s.ClaimOutboxMessages(ctx, now, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimOutboxMessages: %v", err)
+	}
+	if len(messages) != 2 ||
+		messages[0].EventType != "ORDER_EVENT_TYPE_CREATED" ||
+		messages[1].EventType != "ORDER_EVENT_TYPE_PAID" ||
+		messages[0].Key != order.OrderUUID || messages[0].Topic != OrderEventsTopic {
+		t.Fatalf("ClaimOutboxMessages: got %+v, want created and paid events", messages)
+	}
+
+	// Занятые сообщения не выдаются до истечения аренды
+	again, err := s.ClaimOutboxMessages(ctx, now.Add(time.Second), time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimOutboxMessages claimed: %v", err)
+	}
+	if len(again) != 0 {
+		t.Fatalf("ClaimOutboxMessages claimed: got %d messages, want 0", len(again))
+	}
+
+	if err = s.MarkOutboxMessagesPublished(ctx, []string{messages[0].Uuid}, now.Add(time.Second)); err != nil {
+		t.Fatalf("MarkOutboxMessagesPublished: %v", err)
+	}
+
+	// После истечения аренды повторно выдается только неопубликованное сообщение
+	expired, err := s.ClaimOutboxMessages(ctx, now.Add(2*time.Minute), time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimOutboxMessages expired: %v", err)
+	}
+	if len(expired) != 1 || expired[0].Uuid != messages[1].Uuid {
+		t.Fatalf("ClaimOutboxMessages expired: got %+v, want %s", expired, messages[1].Uuid)
+	}
+
+	deleted, err := s.DeletePublishedOutboxMessages(ctx, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("DeletePublishedOutboxMessages: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("DeletePublishedOutboxMessages: got %d, want 1", deleted)
+	}
+}
+
+func TestSagaStoragePostgres(t *testing.T) {
+	ctx := context.Background()
+	pool := newTestPool(t)
+	orders := NewOrderStoragePostgres(pool)
+	s := NewSagaStoragePostgres(pool)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	order := &orderV1.OrderDto{
+		OrderUUID: uuid.NewString(),
+		UserUUID:  uuid.NewString(),
+		Status:    orderV1.OrderStatusPENDINGPAYMENT,
+	}
+	if err := orders.CreateOrder(ctx, order, &OrderTransition{
+		OrderUuid: order.OrderUUID,
+		To:        orderV1.OrderStatusPENDINGPAYMENT,
+		Actor:     "user:" + order.UserUUID,
+		CreatedAt: now,
+	}); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	if _, err := s.GetActiveSaga(ctx, order.OrderUUID, "pay_order"); !errors.Is(err, ErrSagaNotFound) {
+		t.Fatalf("GetActiveSaga before create: got %v, want ErrSagaNotFound", err)
+	}
+
+	saga := &Saga{
+		Uuid:          uuid.NewString(),
+		OrderUuid:     order.OrderUUID,
+		Type:          "pay_order",
+		Status:        SagaStatusRunning,
+		Data:          SagaData{UserUuid: order.UserUUID, Amount: 1500, Currency: "RUB", RefundUuid: uuid.NewString()},
+		NextAttemptAt: now,
+		ClaimedUntil:  now.Add(time.Minute),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.CreateSaga(ctx, saga); err != nil {
+		t.Fatalf("CreateSaga: %v", err)
+	}
+
+	// У заказа может быть только одна незавершенная сага одного типа
+	duplicate := *saga
+	duplicate.Uuid = uuid.NewString()
+	if err := s.CreateSaga(ctx, &duplicate); !errors.Is(err, ErrSagaAlreadyExists) {
+		t.Fatalf("CreateSaga duplicate: got %v, want ErrSagaAlreadyExists", err)
+	}
+
+	// Занятая сага не выдается до истечения аренды
+	if claimed := claimTestSaga(ctx, t, s, now, saga.Uuid); claimed != nil {
+		t.Fatalf("ClaimSagas: claimed saga %+v before lease expiry", claimed)
+	}
+
+	saga.Step = 2
+	saga.Attempts = 1
+	saga.Error = "charge payment: unavailable"
+	saga.Data.TransactionUuid = uuid.NewString()
+	saga.NextAttemptAt = now.Add(time.Second)
+	saga.ClaimedUntil = time.Time{}
+	saga.UpdatedAt = now.Add(time.Second)
+	if err := s.UpdateSaga(ctx, saga); err != nil {
+		t.Fatalf("UpdateSaga: %v", err)
+	}
+
+	if claimed := claimTestSaga(ctx, t, s, now, saga.Uuid); claimed != nil {
+		t.Fatalf("ClaimSagas: claimed saga %+v before next attempt", claimed)
+	}
+
+	claimed := claimTestSaga(ctx, t, s, now.Add(2*time.Second), saga.Uuid)
+	if claimed == nil {
+		t.Fatal("ClaimSagas: saga not claimed after next attempt time")
+	}
+	if claimed.Step != 2 || claimed.Attempts != 1 || claimed.Error != saga.Error ||
+		claimed.Data != saga.Data || !claimed.ClaimedUntil.Equal(now.Add(2*time.Second+time.Minute)) {
+		t.Fatalf("ClaimSagas: got %+v, want %+v", claimed, saga)
+	}
+
+	// Занятие саги увеличивает версию, реплика с прежней копией больше не может ее сохранить
+	if claimed.Version != saga.Version+1 {
+		t.Fatalf("ClaimSagas: got version %d, want %d", claimed.Version, saga.Version+1)
+	}
+	if err := s.UpdateSaga(ctx, saga); !errors.Is(err, ErrSagaClaimLost) {
+		t.Fatalf("UpdateSaga stale copy: got %v, want ErrSagaClaimLost", err)
+	}
+
+	saga = claimed
+	saga.Status = SagaStatusCompleted
+	saga.ClaimedUntil = time.Time{}
+	if err := s.UpdateSaga(ctx, saga); err != nil {
+		t.Fatalf("UpdateSaga completed: %v", err)
+	}
+
+	if _, err := s.GetActiveSaga(ctx, order.OrderUUID, "pay_order"); !errors.Is(err, ErrSagaNotFound) {
+		t.Fatalf("GetActiveSaga after completion: got %v, want ErrSagaNotFound", err)
+	}
+
+	// После завершения саги для заказа можно запустить новую
+	duplicate.CreatedAt = now.Add(time.Minute)
+	if err := s.CreateSaga(ctx, &duplicate); err != nil {
+		t.Fatalf("CreateSaga after completion: %v", err)
+	}
+
+	missing := *saga
+	missing.Uuid = uuid.NewString()
+	if err := s.UpdateSaga(ctx, &missing); !errors.Is(err, ErrSagaNotFound) {
+		t.Fatalf("UpdateSaga missing: got %v, want ErrSagaNotFound", err)
+	}
+}
+
+// claimTestSaga занимает саги, готовые к продолжению, и возвращает сагу sagaUuid, если она среди них
+func claimTestSaga(ctx context.Context, t *testing.T, s *SagaStoragePostgres, now time.Time, sagaUuid string) *Saga {
+	t.Helper()
+
+	sagas, err := s.ClaimSagas(ctx, now, time.Minute, 100)
+	if err != nil {
+		t.Fatalf("ClaimSagas: %v", err)
+	}
+
+	for _, saga := range sagas {
+		if saga.Uuid == sagaUuid {
+			return saga
+		}
+	}
+
+	return nil
+}
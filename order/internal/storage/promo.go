@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+var (
+	ErrPromoCodeNotFound = errors.New("promo code not found")
+	// ErrPromoCodeUsageLimitReached пользователь уже применил промокод максимальное количество раз
+	ErrPromoCodeUsageLimitReached = errors.New("promo code usage limit reached")
+)
+
+// PromoStorage описывает хранилище промокодов и их применений к заказам
+type PromoStorage interface {
+	GetPromoCode(ctx context.Context, code string) (*orderV1.PromoCodeDto, error)
+	// ListPromoCodes возвращает все промокоды в порядке кода
+	ListPromoCodes(ctx context.Context) ([]*orderV1.PromoCodeDto, error)
+	// PutPromoCode создает промокод или заменяет существующий с сохранением времени создания
+	// и возвращает сохраненное значение
+	PutPromoCode(ctx context.Context, promo *orderV1.PromoCodeDto) (*orderV1.PromoCodeDto, error)
+	// DeletePromoCode удаляет промокод, заказы сохраняют примененный код и скидку
+	DeletePromoCode(ctx context.Context, code string) error
+	// RedeemPromoCode записывает применение промокода пользователем к заказу. Если пользователь уже
+	// применил промокод max_uses_per_user раз, возвращает ErrPromoCodeUsageLimitReached.
+	// Проверка и запись выполняются атомарно
+	RedeemPromoCode(ctx context.Context, code, userUuid, orderUuid string) error
+	// ReleasePromoCode отменяет применение промокода к заказу, отсутствие применения ошибкой не считается
+	ReleasePromoCode(ctx context.Context, orderUuid string) error
+}
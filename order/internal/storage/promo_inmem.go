@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+// promoRedemption применение промокода пользователем к заказу
+type promoRedemption struct {
+	code     string
+	userUuid string
+}
+
+// PromoStorageInMem представляет потокобезопасное хранилище промокодов в памяти
+type PromoStorageInMem struct {
+	mu     sync.Mutex
+	promos map[string]*orderV1.PromoCodeDto
+	// redemptions применения промокодов по uuid заказа
+	redemptions map[string]promoRedemption
+}
+
+// NewPromoStorageInMem создает новое хранилище промокодов в памяти
+func NewPromoStorageInMem() *PromoStorageInMem {
+	return &PromoStorageInMem{
+		promos:      make(map[string]*orderV1.PromoCodeDto),
+		redemptions: make(map[string]promoRedemption),
+	}
+}
+
+// GetPromoCode возвращает копию промокода из хранилища
+func (s *PromoStorageInMem) GetPromoCode(_ context.Context, code string) (*orderV1.PromoCodeDto, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	promo, ok := s.promos[code]
+	if !ok {
+		return nil, ErrPromoCodeNotFound
+	}
+
+	c := *promo
+	return &c, nil
+}
+
+// ListPromoCodes возвращает копии всех промокодов в порядке кода
+func (s *PromoStorageInMem) ListPromoCodes(_ context.Context) ([]*orderV1.PromoCodeDto, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	promos := make([]*orderV1.PromoCodeDto, 0, len(s.promos))
+	for _, promo := range s.promos {
+		c := *promo
+		promos = append(promos, &c)
+	}
+
+	slices.SortFunc(promos, func(a, b *orderV1.PromoCodeDto) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+
+	return promos, nil
+}
+
+// PutPromoCode создает или заменяет промокод
+func (s *PromoStorageInMem) PutPromoCode(_ context.Context, promo *orderV1.PromoCodeDto) (*orderV1.PromoCodeDto, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *promo
+	stored.UpdatedAt = time.Now()
+	stored.CreatedAt = stored.UpdatedAt
+	if existing, ok := s.promos[promo.Code]; ok {
+		stored.CreatedAt = existing.CreatedAt
+	}
+
+	s.promos[promo.Code] = &stored
+
+	c := stored
+	return &c, nil
+}
+
+// DeletePromoCode удаляет промокод вместе с историей применений
+func (s *PromoStorageInMem) DeletePromoCode(_ context.Context, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.promos[code]; !ok {
+		return ErrPromoCodeNotFound
+	}
+
+	delete(s.promos, code)
+	for orderUuid, redemption := range s.redemptions {
+		if redemption.code == code {
+			delete(s.redemptions, orderUuid)
+		}
+	}
+
+	return nil
+}
+
+// RedeemPromoCode записывает применение промокода с проверкой лимита на пользователя
+func (s *PromoStorageInMem) RedeemPromoCode(_ context.Context, code, userUuid, orderUuid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	promo, ok := s.promos[code]
+	if !ok {
+		return ErrPromoCodeNotFound
+	}
+
+	if promo.MaxUsesPerUser > 0 {
+		var uses int32
+		for _, redemption := range s.redemptions {
+			if redemption.code == code && redemption.userUuid == userUuid {
+				uses++
+			}
+		}
+		if uses >= promo.MaxUsesPerUser {
+			return ErrPromoCodeUsageLimitReached
+		}
+	}
+
+	s.redemptions[orderUuid] = promoRedemption{code: code, userUuid: userUuid}
+
+	return nil
+}
+
+// ReleasePromoCode отменяет применение промокода к заказу
+func (s *PromoStorageInMem) ReleasePromoCode(_ context.Context, orderUuid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.redemptions, orderUuid)
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+func TestPromoStorageInMemRedeemLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxUsesPerUser int32
+		redeemed       int
+		wantErr        error
+	}{
+		{name: "unlimited", maxUsesPerUser: 0, redeemed: 5},
+		{name: "under limit", maxUsesPerUser: 2, redeemed: 1},
+		{name: "limit reached", maxUsesPerUser: 2, redeemed: 2, wantErr: ErrPromoCodeUsageLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := NewPromoStorageInMem()
+			if _, err := s.PutPromoCode(ctx, &orderV1.PromoCodeDto{Code: "SPRING", MaxUsesPerUser: tt.maxUsesPerUser}); err != nil {
+				t.Fatalf("PutPromoCode: %v", err)
+			}
+
+			userUuid := uuid.NewString()
+			for range tt.redeemed {
+				if err := s.RedeemPromoCode(ctx, "SPRING", userUuid, uuid.NewString()); err != nil {
+					t.Fatalf("RedeemPromoCode: %v", err)
+				}
+			}
+
+			if err := s.RedeemPromoCode(ctx, "SPRING", userUuid, uuid.NewString()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RedeemPromoCode: got %v, want %v", err, tt.wantErr)
+			}
+
+			// Лимит считается для каждого пользователя отдельно
+			if err := s.RedeemPromoCode(ctx, "SPRING", uuid.NewString(), uuid.NewString()); err != nil {
+				t.Fatalf("RedeemPromoCode by other user: %v", err)
+			}
+		})
+	}
+}
+
+func TestPromoStorageInMemReleaseFreesUse(t *testing.T) {
+	ctx := context.Background()
+	s := NewPromoStorageInMem()
+	if _, err := s.PutPromoCode(ctx, &orderV1.PromoCodeDto{Code: "SPRING", MaxUsesPerUser: 1}); err != nil {
+		t.Fatalf("PutPromoCode: %v", err)
+	}
+
+	userUuid, orderUuid := uuid.NewString(), uuid.NewString()
+	if err := s.RedeemPromoCode(ctx, "SPRING", userUuid, orderUuid); err != nil {
+		t.Fatalf("RedeemPromoCode: %v", err)
+	}
+	if err := s.ReleasePromoCode(ctx, orderUuid); err != nil {
+		t.Fatalf("ReleasePromoCode: %v", err)
+	}
+	if err := s.RedeemPromoCode(ctx, "SPRING", userUuid, uuid.NewString()); err != nil {
+		t.Fatalf("RedeemPromoCode after release: %v", err)
+	}
+
+	if err := s.RedeemPromoCode(ctx, "MISSING", userUuid, uuid.NewString()); !errors.Is(err, ErrPromoCodeNotFound) {
+		t.Fatalf("RedeemPromoCode missing code: got %v, want %v", err, ErrPromoCodeNotFound)
+	}
+}
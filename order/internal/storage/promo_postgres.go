@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+// PromoStoragePostgres представляет хранилище промокодов в PostgreSQL
+type PromoStoragePostgres struct {
+	pool *pgxpool.Pool
+}
+
+// NewPromoStoragePostgres создает новое хранилище промокодов в PostgreSQL
+func NewPromoStoragePostgres(pool *pgxpool.Pool) *PromoStoragePostgres {
+	return &PromoStoragePostgres{
+		pool: pool,
+	}
+}
+
+// promoColumns колонки промокода в порядке чтения scanPromoCode
+const promoColumns = `code, discount_type, percent, amount_minor, currency, category, max_uses_per_user,
+	starts_at, expires_at, active, created_at, updated_at`
+
+// GetPromoCode возвращает промокод из хранилища
+func (s *PromoStoragePostgres) GetPromoCode(ctx context.Context, code string) (*orderV1.PromoCodeDto, error) {
+	promo, err := scanPromoCode(s.pool.QueryRow(ctx, `SELECT `+promoColumns+` FROM promo_codes WHERE code = $1`, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPromoCodeNotFound
+		}
+
+		return nil, fmt.Errorf("select promo code: %w", err)
+	}
+
+	return promo, nil
+}
+
+// ListPromoCodes возвращает все промокоды в порядке кода
+func (s *PromoStoragePostgres) ListPromoCodes(ctx context.Context) ([]*orderV1.PromoCodeDto, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+promoColumns+` FROM promo_codes ORDER BY code`)
+	if err != nil {
+		return nil, fmt.Errorf("select promo codes: %w", err)
+	}
+
+	promos, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*orderV1.PromoCodeDto, error) {
+		return scanPromoCode(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan promo codes: %w", err)
+	}
+
+	return promos, nil
+}
+
+// PutPromoCode создает или заменяет промокод одним запросом, время создания существующего промокода сохраняется
+func (s *PromoStoragePostgres) PutPromoCode(ctx context.Context, promo *orderV1.PromoCodeDto) (*orderV1.PromoCodeDto, error) {
+	var (
+		percent     *int32
+		amountMinor *int64
+		currency    *string
+		category    *string
+		startsAt    *time.Time
+		expiresAt   *time.Time
+	)
+	if v, ok := promo.Percent.Get(); ok {
+		percent = &v
+	}
+	if v, ok := promo.AmountMinor.Get(); ok {
+		amountMinor = &v
+	}
+	if v, ok := promo.Currency.Get(); ok {
+		currency = &v
+	}
+	if v, ok := promo.Category.Get(); ok {
+		c := string(v)
+		category = &c
+	}
+	if v, ok := promo.StartsAt.Get(); ok {
+		startsAt = &v
+	}
+	if v, ok := promo.ExpiresAt.Get(); ok {
+		expiresAt = &v
+	}
+
+	now := time.Now()
+	stored, err := scanPromoCode(s.pool.QueryRow(ctx, `
+		INSERT INTO promo_codes (code, discount_type, percent, amount_minor, currency, category, max_uses_per_user,
+		                         starts_at, expires_at, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+		ON CONFLICT (code) DO UPDATE
+		SET discount_type     = EXCLUDED.discount_type,
+		    percent           = EXCLUDED.percent,
+		    amount_minor      = EXCLUDED.amount_minor,
+		    currency          = EXCLUDED.currency,
+		    category          = EXCLUDED.category,
+		    max_uses_per_user = EXCLUDED.max_uses_per_user,
+		    starts_at         = EXCLUDED.starts_at,
+		    expires_at        = EXCLUDED.expires_at,
+		    active            = EXCLUDED.active,
+		    updated_at        = EXCLUDED.updated_at
+		RETURNING `+promoColumns,
+		promo.Code,
+		string(promo.DiscountType),
+		percent,
+		amountMinor,
+		currency,
+		category,
+		promo.MaxUsesPerUser,
+		startsAt,
+		expiresAt,
+		promo.Active,
+		now,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("upsert promo code: %w", err)
+	}
+
+	return stored, nil
+}
+
+// DeletePromoCode удаляет промокод, история применений удаляется каскадно
+func (s *PromoStoragePostgres) DeletePromoCode(ctx context.Context, code string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM promo_codes WHERE code = $1`, code)
+	if err != nil {
+		return fmt.Errorf("delete promo code: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrPromoCodeNotFound
+	}
+
+	return nil
+}
+
+// RedeemPromoCode записывает применение промокода. Строка промокода блокируется до конца транзакции,
+// поэтому параллельные заказы одного пользователя не превысят лимит применений
+func (s *PromoStoragePostgres) RedeemPromoCode(ctx context.Context, code, userUuid, orderUuid string) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		var maxUses int32
+		err := tx.QueryRow(ctx, `SELECT max_uses_per_user FROM promo_codes WHERE code = $1 FOR UPDATE`, code).
+			Scan(&maxUses)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrPromoCodeNotFound
+			}
+
+			return fmt.Errorf("lock promo code: %w", err)
+		}
+
+		if maxUses > 0 {
+			var uses int32
+			err = tx.QueryRow(ctx, `SELECT count(*) FROM promo_redemptions WHERE code = $1 AND user_uuid = $2`,
+				code, userUuid).Scan(&uses)
+			if err != nil {
+				return fmt.Errorf("count promo redemptions: %w", err)
+			}
+
+			if uses >= maxUses {
+				return ErrPromoCodeUsageLimitReached
+			}
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO promo_redemptions (order_uuid, code, user_uuid, redeemed_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (order_uuid) DO NOTHING`,
+			orderUuid, code, userUuid, time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("insert promo redemption: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ReleasePromoCode отменяет применение промокода к заказу
+func (s *PromoStoragePostgres) ReleasePromoCode(ctx context.Context, orderUuid string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM promo_redemptions WHERE order_uuid = $1`, orderUuid)
+	if err != nil {
+		return fmt.Errorf("delete promo redemption: %w", err)
+	}
+
+	return nil
+}
+
+// scanPromoCode читает промокод из строки результата запроса
+func scanPromoCode(row pgx.Row) (*orderV1.PromoCodeDto, error) {
+	var (
+		promo       orderV1.PromoCodeDto
+		percent     *int32
+		amountMinor *int64
+		currency    *string
+		category    *string
+		startsAt    *time.Time
+		expiresAt   *time.Time
+	)
+
+	err := row.Scan(
+		&promo.Code,
+		&promo.DiscountType,
+		&percent,
+		&amountMinor,
+		&currency,
+		&category,
+		&promo.MaxUsesPerUser,
+		&startsAt,
+		&expiresAt,
+		&promo.Active,
+		&promo.CreatedAt,
+		&promo.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if percent != nil {
+		promo.Percent = orderV1.NewOptInt32(*percent)
+	}
+	if amountMinor != nil {
+		promo.AmountMinor = orderV1.NewOptInt64(*amountMinor)
+	}
+	if currency != nil {
+		promo.Currency = orderV1.NewOptString(*currency)
+	}
+	if category != nil {
+		promo.Category = orderV1.NewOptPartCategory(orderV1.PartCategory(*category))
+	}
+	if startsAt != nil {
+		promo.StartsAt = orderV1.NewOptDateTime(*startsAt)
+	}
+	if expiresAt != nil {
+		promo.ExpiresAt = orderV1.NewOptDateTime(*expiresAt)
+	}
+
+	return &promo, nil
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+// OrderSortField поле сортировки заказов
+type OrderSortField string
+
+const (
+	OrderSortByCreatedAt  OrderSortField = "created_at"
+	OrderSortByUpdatedAt  OrderSortField = "updated_at"
+	OrderSortByTotalPrice OrderSortField = "total_price"
+)
+
+// OrderFilter условия отбора заказов, пустые поля не ограничивают выборку
+type OrderFilter struct {
+	UserUuid string
+	// Statuses заказ подходит, если находится в любом из перечисленных статусов
+	Statuses []orderV1.OrderStatus
+	// CreatedFrom начало интервала создания, включительно
+	CreatedFrom time.Time
+	// CreatedTo конец интервала создания, не включительно
+	CreatedTo time.Time
+	// PartUuid заказ подходит, если среди его позиций есть эта деталь
+	PartUuid string
+}
+
+// OrderCursor позиция последнего заказа предыдущей страницы
+type OrderCursor struct {
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	TotalPriceMinor int64
+	OrderUuid       string
+}
+
+// OrderQuery запрос страницы заказов. Заказы упорядочиваются по SortBy, а при равенстве — по uuid,
+// поэтому порядок детерминирован и страницы не пересекаются
+type OrderQuery struct {
+	Filter     OrderFilter
+	SortBy     OrderSortField
+	Descending bool
+	// After курсор, после которого начинается страница, nil для первой страницы
+	After *OrderCursor
+	Limit int
+}
+
+// CursorOf возвращает курсор, указывающий на заказ
+func CursorOf(order *orderV1.OrderDto) *OrderCursor {
+	return &OrderCursor{
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+		TotalPriceMinor: order.TotalPriceMinor,
+		OrderUuid:       order.OrderUUID,
+	}
+}
+
+// compareOrders сравнивает позиции двух заказов в порядке сортировки по полю field без учета направления
+func compareOrders(field OrderSortField, a, b *OrderCursor) int {
+	var c int
+	switch field {
+	case OrderSortByUpdatedAt:
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	case OrderSortByTotalPrice:
+		c = cmp.Compare(a.TotalPriceMinor, b.TotalPriceMinor)
+	default:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+
+	if c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.OrderUuid, b.OrderUuid)
+}
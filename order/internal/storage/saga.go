@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSagaNotFound = errors.New("saga not found")
+	// ErrSagaAlreadyExists у заказа уже есть незавершенная сага того же типа
+	ErrSagaAlreadyExists = errors.New("order already has unfinished saga")
+	// ErrSagaClaimLost сагу после чтения сохранила или заняла другая реплика
+	ErrSagaClaimLost = errors.New("saga is claimed by another replica")
+)
+
+// SagaStatus состояние саги
+type SagaStatus string
+
+const (
+	// SagaStatusRunning шаги саги выполняются
+	SagaStatusRunning SagaStatus = "RUNNING"
+	// SagaStatusCompleted все шаги выполнены
+	SagaStatusCompleted SagaStatus = "COMPLETED"
+	// SagaStatusCompensating шаг завершился ошибкой, выполненные шаги компенсируются
+	SagaStatusCompensating SagaStatus = "COMPENSATING"
+	// SagaStatusCompensated выполненные шаги компенсированы
+	SagaStatusCompensated SagaStatus = "COMPENSATED"
+	// SagaStatusFailed компенсация не удалась, требуется ручной разбор
+	SagaStatusFailed SagaStatus = "FAILED"
+)
+
+// SagaData данные саги, которые шаги передают друг другу
+type SagaData struct {
+	UserUuid      string `json:"user_uuid,omitempty"`
+	PaymentMethod string `json:"payment_method,omitempty"`
+	// Amount сумма в минимальных единицах валюты
+	Amount   int64  `json:"amount,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	// TransactionUuid UUID транзакции оплаты, заполняется после списания
+	TransactionUuid string `json:"transaction_uuid,omitempty"`
+	// RefundUuid UUID возврата средств при компенсации, задается при создании саги,
+	// чтобы повтор компенсации не возвращал средства повторно
+	RefundUuid string `json:"refund_uuid,omitempty"`
+}
+
+// Saga сохраненное состояние саги заказа
+type Saga struct {
+	Uuid      string
+	OrderUuid string
+	Type      string
+	Status    SagaStatus
+	// Step количество выполненных и еще не компенсированных шагов
+	Step int
+	Data SagaData
+	// Error ошибка, из-за которой сага компенсируется, или последняя ошибка повторяемого шага
+	Error string
+	// Attempts количество неудачных попыток текущего шага
+	Attempts int
+	// NextAttemptAt время, не раньше которого сага продолжается после неудачной попытки
+	NextAttemptAt time.Time
+	// ClaimedUntil время, до которого сагу выполняет занявшая ее реплика
+	ClaimedUntil time.Time
+	// Version версия состояния саги, увеличивается при каждом сохранении и занятии саги
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Finished проверяет, что сага завершена и больше не выполняется
+func (s *Saga) Finished() bool {
+	return s.Status == SagaStatusCompleted || s.Status == SagaStatusCompensated || s.Status == SagaStatusFailed
+}
+
+// SagaStorage описывает хранилище состояния саг
+type SagaStorage interface {
+	// CreateSaga сохраняет новую сагу, если у заказа нет незавершенной саги того же типа,
+	// иначе возвращает ErrSagaAlreadyExists
+	CreateSaga(ctx context.Context, saga *Saga) error
+	// UpdateSaga сохраняет состояние саги и увеличивает ее версию, если версия в хранилище совпадает
+	// с saga.Version. Если сагу после чтения сохранила или заняла другая реплика, возвращает ErrSagaClaimLost
+	UpdateSaga(ctx context.Context, saga *Saga) error
+	// GetActiveSaga возвращает незавершенную сагу заказа
+	GetActiveSaga(ctx context.Context, orderUuid, sagaType string) (*Saga, error)
+	// ClaimSagas занимает до limit незавершенных саг, время продолжения которых наступило к now
+	// и которые не заняты другой репликой, до now+lease. Занятие увеличивает версию саги, поэтому реплика,
+	// занимавшая сагу раньше, больше не может ее сохранить
+	ClaimSagas(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Saga, error)
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SagaStorageInMem представляет потокобезопасное хранилище саг в памяти
+type SagaStorageInMem struct {
+	mu    sync.Mutex
+	sagas map[string]*Saga
+}
+
+// NewSagaStorageInMem создает новое хранилище саг в памяти
+func NewSagaStorageInMem() *SagaStorageInMem {
+	return &SagaStorageInMem{
+		sagas: make(map[string]*Saga),
+	}
+}
+
+// CreateSaga сохраняет новую сагу
+func (s *SagaStorageInMem) CreateSaga(_ context.Context, saga *Saga) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.sagas {
+		if stored.OrderUuid == saga.OrderUuid && stored.Type == saga.Type && !stored.Finished() {
+			return ErrSagaAlreadyExists
+		}
+	}
+
+	c := *saga
+	s.sagas[saga.Uuid] = &c
+
+	return nil
+}
+
+// UpdateSaga обновляет состояние существующей саги, если ее версия не изменилась
+func (s *SagaStorageInMem) UpdateSaga(_ context.Context, saga *Saga) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.sagas[saga.Uuid]
+	if !ok {
+		return ErrSagaNotFound
+	}
+	if stored.Version != saga.Version {
+		return ErrSagaClaimLost
+	}
+
+	saga.Version++
+	c := *saga
+	s.sagas[saga.Uuid] = &c
+
+	return nil
+}
+
+// GetActiveSaga возвращает копию незавершенной саги заказа
+func (s *SagaStorageInMem) GetActiveSaga(_ context.Context, orderUuid, sagaType string) (*Saga, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, saga := range s.sagas {
+		if saga.OrderUuid == orderUuid && saga.Type == sagaType && !saga.Finished() {
+			c := *saga
+			return &c, nil
+		}
+	}
+
+	return nil, ErrSagaNotFound
+}
+
+// ClaimSagas занимает незавершенные саги, время продолжения которых наступило
+func (s *SagaStorageInMem) ClaimSagas(_ context.Context, now time.Time, lease time.Duration, limit int) ([]*Saga, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sagas := make([]*Saga, 0, limit)
+	for _, saga := range s.sagas {
+		if len(sagas) == limit {
+			break
+		}
+		if saga.Finished() || saga.NextAttemptAt.After(now) || saga.ClaimedUntil.After(now) {
+			continue
+		}
+
+		saga.ClaimedUntil = now.Add(lease)
+		saga.Version++
+		c := *saga
+		sagas = append(sagas, &c)
+	}
+
+	return sagas, nil
+}
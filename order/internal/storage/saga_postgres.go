@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sagaColumns колонки саги в порядке чтения scanSaga
+const sagaColumns = `saga_uuid, order_uuid, saga_type, status, step, data, error, attempts, next_attempt_at,
+	claimed_until, version, created_at, updated_at`
+
+// SagaStoragePostgres представляет хранилище саг в PostgreSQL, общее для всех реплик order service
+type SagaStoragePostgres struct {
+	pool *pgxpool.Pool
+}
+
+// NewSagaStoragePostgres создает новое хранилище саг в PostgreSQL
+func NewSagaStoragePostgres(pool *pgxpool.Pool) *SagaStoragePostgres {
+	return &SagaStoragePostgres{
+		pool: pool,
+	}
+}
+
+// CreateSaga сохраняет новую сагу, уникальный индекс не допускает две незавершенные саги заказа одного типа
+func (s *SagaStoragePostgres) CreateSaga(ctx context.Context, saga *Saga) error {
+	data, err := json.Marshal(saga.Data)
+	if err != nil {
+		return fmt.Errorf("marshal saga data: %w", err)
+	}
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO sagas (`+sagaColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		saga.Uuid,
+		saga.OrderUuid,
+		saga.Type,
+		string(saga.Status),
+		saga.Step,
+		data,
+		saga.Error,
+		saga.Attempts,
+		saga.NextAttemptAt,
+		timeColumn(saga.ClaimedUntil),
+		saga.Version,
+		saga.CreatedAt,
+		saga.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return ErrSagaAlreadyExists
+		}
+
+		return fmt.Errorf("insert saga: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSaga обновляет состояние существующей саги, если ее версия не изменилась с момента чтения
+func (s *SagaStoragePostgres) UpdateSaga(ctx context.Context, saga *Saga) error {
+	data, err := json.Marshal(saga.Data)
+	if err != nil {
+		return fmt.Errorf("marshal saga data: %w", err)
+	}
+
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE sagas
+		SET status          = $2,
+		    step            = $3,
+		    data            = $4,
+		    error           = $5,
+		    attempts        = $6,
+		    next_attempt_at = $7,
+		    claimed_until   = $8,
+		    updated_at      = $9,
+		    version         = version + 1
+		WHERE saga_uuid = $1 AND version = $10`,
+		saga.Uuid,
+		string(saga.Status),
+		saga.Step,
+		data,
+		saga.Error,
+		saga.Attempts,
+		saga.NextAttemptAt,
+		timeColumn(saga.ClaimedUntil),
+		saga.UpdatedAt,
+		saga.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("update saga: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		err = s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM sagas WHERE saga_uuid = $1)`, saga.Uuid).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("check saga: %w", err)
+		}
+		if !exists {
+			return ErrSagaNotFound
+		}
+
+		return ErrSagaClaimLost
+	}
+
+	saga.Version++
+	return nil
+}
+
+// GetActiveSaga возвращает незавершенную сагу заказа
+func (s *SagaStoragePostgres) GetActiveSaga(ctx context.Context, orderUuid, sagaType string) (*Saga, error) {
+	return scanSaga(s.pool.QueryRow(ctx, `
+		SELECT `+sagaColumns+`
+		FROM sagas
+		WHERE order_uuid = $1 AND saga_type = $2 AND status IN ($3, $4)`,
+		orderUuid,
+		sagaType,
+		string(SagaStatusRunning),
+		string(SagaStatusCompensating),
+	))
+}
+
+// ClaimSagas занимает незавершенные саги, строки, занятые параллельной репликой, пропускаются
+func (s *SagaStoragePostgres) ClaimSagas(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Saga, error) {
+	rows, err := s.pool.Query(ctx, `
+		UPDATE sagas
+		SET claimed_until = $4,
+		    version       = version + 1
+		WHERE saga_uuid IN (SELECT saga_uuid
+		                    FROM sagas
+		                    WHERE status IN ($1, $2)
+		                      AND next_attempt_at <= $3
+		                      AND (claimed_until IS NULL OR claimed_until <= $3)
+		                    ORDER BY next_attempt_at
+		                    LIMIT $5 FOR UPDATE SKIP LOCKED)
+		RETURNING `+sagaColumns,
+		string(SagaStatusRunning),
+		string(SagaStatusCompensating),
+		now,
+		now.Add(lease),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim sagas: %w", err)
+	}
+	defer rows.Close()
+
+	var sagas []*Saga
+	for rows.Next() {
+		saga, err := scanSaga(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sagas = append(sagas, saga)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("claim sagas: %w", err)
+	}
+
+	return sagas, nil
+}
+
+// scanSaga читает сагу из строки результата запроса
+func scanSaga(row pgx.Row) (*Saga, error) {
+	var (
+		saga         Saga
+		data         []byte
+		claimedUntil *time.Time
+	)
+
+	err := row.Scan(
+		&saga.Uuid,
+		&saga.OrderUuid,
+		&saga.Type,
+		&saga.Status,
+		&saga.Step,
+		&data,
+		&saga.Error,
+		&saga.Attempts,
+		&saga.NextAttemptAt,
+		&claimedUntil,
+		&saga.Version,
+		&saga.CreatedAt,
+		&saga.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSagaNotFound
+		}
+
+		return nil, fmt.Errorf("scan saga: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &saga.Data); err != nil {
+		return nil, fmt.Errorf("unmarshal saga data: %w", err)
+	}
+	if claimedUntil != nil {
+		saga.ClaimedUntil = *claimedUntil
+	}
+
+	return &saga, nil
+}
+
+// timeColumn возвращает NULL для нулевого времени
+func timeColumn(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+)
+
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrOrderAlreadyExists = errors.New("order already exists")
+	// ErrOrderStatusConflict статус заказа изменился с момента чтения
+	ErrOrderStatusConflict = errors.New("order status changed concurrently")
+)
+
+// OrderTransition запись о переходе заказа из одного статуса в другой
+type OrderTransition struct {
+	OrderUuid string
+	// From статус до перехода, пустой у записи о создании заказа
+	From   orderV1.OrderStatus
+	To     orderV1.OrderStatus
+	Actor  string
+	Reason string
+	// CreatedAt время перехода
+	CreatedAt time.Time
+}
+
+// OrderStorage описывает хранилище данных о заказах
+type OrderStorage interface {
+	GetOrder(ctx context.Context, orderUuid string) (*orderV1.OrderDto, error)
+	// CreateOrder сохраняет новый заказ и, если передан, переход в начальный статус
+	CreateOrder(ctx context.Context, order *orderV1.OrderDto, transition *OrderTransition) error
+	// UpdateOrder обновляет заказ. Если передан переход, заказ обновляется только при совпадении
+	// сохраненного статуса с transition.From, иначе возвращается ErrOrderStatusConflict,
+	// а переход записывается в историю вместе с обновлением
+	UpdateOrder(ctx context.Context, order *orderV1.OrderDto, transition *OrderTransition) error
+	// GetOrderHistory возвращает переходы статуса заказа в порядке их выполнения
+	GetOrderHistory(ctx context.Context, orderUuid string) ([]OrderTransition, error)
+	// ListOrders возвращает до query.Limit заказов, подходящих под фильтр, в порядке сортировки
+	ListOrders(ctx context.Context, query OrderQuery) ([]*orderV1.OrderDto, error)
+}
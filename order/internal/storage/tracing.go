@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	orderV1 "github.com/Igorezka/rocket-factory/shared/pkg/openapi/order/v1"
+	"github.com/Igorezka/rocket-factory/shared/pkg/tracing"
+)
+
+var tracer = otel.Tracer("github.com/Igorezka/rocket-factory/order/internal/storage")
+
+// Обертки хранилищ создают span для каждого обращения к хранилищу в рамках трассируемого запроса.
+// Фоновые опросы хранилищ вне трассы span не создают
+
+type tracingOrderStorage struct {
+	next OrderStorage
+}
+
+// OrderStorageWithTracing оборачивает хранилище заказов трассировкой обращений
+func OrderStorageWithTracing(next OrderStorage) OrderStorage {
+	return &tracingOrderStorage{next: next}
+}
+
+func (s *tracingOrderStorage) GetOrder(ctx context.Context, orderUuid string) (*orderV1.OrderDto, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "OrderStorage.GetOrder")
+	order, err := s.next.GetOrder(ctx, orderUuid)
+	endSpan(span, err)
+
+	return order, err
+}
+
+func (s *tracingOrderStorage) CreateOrder(ctx context.Context, order *orderV1.OrderDto, transition *OrderTransition) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "OrderStorage.CreateOrder")
+	err := s.next.CreateOrder(ctx, order, transition)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingOrderStorage) UpdateOrder(ctx context.Context, order *orderV1.OrderDto, transition *OrderTransition) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "OrderStorage.UpdateOrder")
+	err := s.next.UpdateOrder(ctx, order, transition)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingOrderStorage) GetOrderHistory(ctx context.Context, orderUuid string) ([]OrderTransition, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "OrderStorage.GetOrderHistory")
+	history, err := s.next.GetOrderHistory(ctx, orderUuid)
+	endSpan(span, err)
+
+	return history, err
+}
+
+func (s *tracingOrderStorage) ListOrders(ctx context.Context, query OrderQuery) ([]*orderV1.OrderDto, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "OrderStorage.ListOrders")
+	orders, err := s.next.ListOrders(ctx, query)
+	endSpan(span, err)
+
+	return orders, err
+}
+
+type tracingIdempotencyStorage struct {
+	next IdempotencyStorage
+}
+
+// IdempotencyStorageWithTracing оборачивает хранилище ключей идемпотентности трассировкой обращений
+func IdempotencyStorageWithTracing(next IdempotencyStorage) IdempotencyStorage {
+	return &tracingIdempotencyStorage{next: next}
+}
+
+func (s *tracingIdempotencyStorage) BeginIdempotentRequest(
+	ctx context.Context,
+	record *IdempotencyRecord,
+	staleBefore time.Time,
+) (*IdempotencyRecord, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "IdempotencyStorage.BeginIdempotentRequest")
+	existing, err := s.next.BeginIdempotentRequest(ctx, record, staleBefore)
+	endSpan(span, err)
+
+	return existing, err
+}
+
+func (s *tracingIdempotencyStorage) CompleteIdempotentRequest(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "IdempotencyStorage.CompleteIdempotentRequest")
+	err := s.next.CompleteIdempotentRequest(ctx, key, statusCode, contentType, body)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingIdempotencyStorage) AbortIdempotentRequest(ctx context.Context, key string) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "IdempotencyStorage.AbortIdempotentRequest")
+	err := s.next.AbortIdempotentRequest(ctx, key)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingIdempotencyStorage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "IdempotencyStorage.DeleteExpiredIdempotencyKeys")
+	deleted, err := s.next.DeleteExpiredIdempotencyKeys(ctx, now)
+	endSpan(span, err)
+
+	return deleted, err
+}
+
+type tracingPromoStorage struct {
+	next PromoStorage
+}
+
+// PromoStorageWithTracing оборачивает хранилище промокодов трассировкой обращений
+func PromoStorageWithTracing(next PromoStorage) PromoStorage {
+	return &tracingPromoStorage{next: next}
+}
+
+func (s *tracingPromoStorage) GetPromoCode(ctx context.Context, code string) (*orderV1.PromoCodeDto, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "PromoStorage.GetPromoCode")
+	promo, err := s.next.GetPromoCode(ctx, code)
+	endSpan(span, err)
+
+	return promo, err
+}
+
+func (s *tracingPromoStorage) ListPromoCodes(ctx context.Context) ([]*orderV1.PromoCodeDto, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "PromoStorage.ListPromoCodes")
+	promos, err := s.next.ListPromoCodes(ctx)
+	endSpan(span, err)
+
+	return promos, err
+}
+
+func (s *tracingPromoStorage) PutPromoCode(ctx context.Context, promo *orderV1.PromoCodeDto) (*orderV1.PromoCodeDto, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "PromoStorage.PutPromoCode")
+	saved, err := s.next.PutPromoCode(ctx, promo)
+	endSpan(span, err)
+
+	return saved, err
+}
+
+func (s *tracingPromoStorage) DeletePromoCode(ctx context.Context, code string) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "PromoStorage.DeletePromoCode")
+	err := s.next.DeletePromoCode(ctx, code)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingPromoStorage) RedeemPromoCode(ctx context.Context, code, userUuid, orderUuid string) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "PromoStorage.RedeemPromoCode")
+	err := s.next.RedeemPromoCode(ctx, code, userUuid, orderUuid)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingPromoStorage) ReleasePromoCode(ctx context.Context, orderUuid string) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "PromoStorage.ReleasePromoCode")
+	err := s.next.ReleasePromoCode(ctx, orderUuid)
+	endSpan(span, err)
+
+	return err
+}
+
+type tracingOutboxStorage struct {
+	next OutboxStorage
+}
+
+// OutboxStorageWithTracing оборачивает хранилище outbox трассировкой обращений
+func OutboxStorageWithTracing(next OutboxStorage) OutboxStorage {
+	return &tracingOutboxStorage{next: next}
+}
+
+func (s *tracingOutboxStorage) ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*OutboxMessage, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "OutboxStorage.ClaimOutboxMessages")
+	messages, err := s.next.ClaimOutboxMessages(ctx, now, lease, limit)
+	endSpan(span, err)
+
+	return messages, err
+}
+
+func (s *tracingOutboxStorage) MarkOutboxMessagesPublished(ctx context.Context, uuids []string, now time.Time) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "OutboxStorage.MarkOutboxMessagesPublished")
+	err := s.next.MarkOutboxMessagesPublished(ctx, uuids, now)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingOutboxStorage) DeletePublishedOutboxMessages(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "OutboxStorage.DeletePublishedOutboxMessages")
+	deleted, err := s.next.DeletePublishedOutboxMessages(ctx, before)
+	endSpan(span, err)
+
+	return deleted, err
+}
+
+type tracingSagaStorage struct {
+	next SagaStorage
+}
+
+// SagaStorageWithTracing оборачивает хранилище саг трассировкой обращений
+func SagaStorageWithTracing(next SagaStorage) SagaStorage {
+	return &tracingSagaStorage{next: next}
+}
+
+func (s *tracingSagaStorage) CreateSaga(ctx context.Context, saga *Saga) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "SagaStorage.CreateSaga")
+	err := s.next.CreateSaga(ctx, saga)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingSagaStorage) UpdateSaga(ctx context.Context, saga *Saga) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "SagaStorage.UpdateSaga")
+	err := s.next.UpdateSaga(ctx, saga)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingSagaStorage) GetActiveSaga(ctx context.Context, orderUuid, sagaType string) (*Saga, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "SagaStorage.GetActiveSaga")
+	saga, err := s.next.GetActiveSaga(ctx, orderUuid, sagaType)
+	endSpan(span, err)
+
+	return saga, err
+}
+
+func (s *tracingSagaStorage) ClaimSagas(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Saga, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "SagaStorage.ClaimSagas")
+	sagas, err := s.next.ClaimSagas(ctx, now, lease, limit)
+	endSpan(span, err)
+
+	return sagas, err
+}
+
+// endSpan завершает span обращения к хранилищу. Отсутствие записи — штатный результат обращения,
+// поэтому ошибкой в трассе не отмечается
+func endSpan(span trace.Span, err error) {
+	if errors.Is(err, ErrOrderNotFound) ||
+		errors.Is(err, ErrIdempotencyKeyNotFound) ||
+		errors.Is(err, ErrPromoCodeNotFound) ||
+		errors.Is(err, ErrSagaNotFound) {
+		err = nil
+	}
+
+	tracing.End(span, err)
+}
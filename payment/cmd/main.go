@@ -2,74 +2,443 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"regexp"
 	"syscall"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
+	"github.com/Igorezka/rocket-factory/payment/internal/config"
+	"github.com/Igorezka/rocket-factory/payment/internal/metrics"
+	"github.com/Igorezka/rocket-factory/payment/internal/provider"
+	"github.com/Igorezka/rocket-factory/payment/internal/storage"
+	sharedConfig "github.com/Igorezka/rocket-factory/shared/pkg/config"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	sharedMetrics "github.com/Igorezka/rocket-factory/shared/pkg/metrics"
 	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+	"github.com/Igorezka/rocket-factory/shared/pkg/tracing"
 )
 
-const grpcPort = 50052
+// currencyPattern формат кода валюты ISO 4217
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
 
-// inventoryService реализует gRPC сервис для работы с оплатой
+// paymentService реализует gRPC сервис для работы с оплатой
 type paymentService struct {
 	paymentV1.UnimplementedPaymentServiceServer
+	storage   storage.TransactionStorage
+	providers provider.Registry
+	metrics   *metrics.Metrics
+	// providerTimeout время ожидания ответа платежного провайдера
+	providerTimeout time.Duration
 }
 
-// PayOrder производит оплату и возвращает uuid транзакции
-func (s *paymentService) PayOrder(_ context.Context, _ *paymentV1.PayOrderRequest) (*paymentV1.PayOrderResponse, error) {
-	// Генерируем uuid
-	u := uuid.NewString()
+// newPaymentService создает сервис оплаты
+func newPaymentService(
+	transactionStorage storage.TransactionStorage,
+	providers provider.Registry,
+	paymentMetrics *metrics.Metrics,
+	providerTimeout time.Duration,
+) *paymentService {
+	return &paymentService{
+		storage:         transactionStorage,
+		providers:       providers,
+		metrics:         paymentMetrics,
+		providerTimeout: providerTimeout,
+	}
+}
+
+// PayOrder проводит оплату через провайдера выбранного способа оплаты и возвращает uuid транзакции.
+// Транзакция сохраняется до обращения к провайдеру и обновляется по его ответу.
+// Оплата идемпотентна по uuid заказа: повторный запрос для уже оплаченного заказа возвращает
+// проведенную транзакцию без повторного списания, а повтор после таймаута провайдера
+// отправляет списание той же транзакции
+func (s *paymentService) PayOrder(ctx context.Context, req *paymentV1.PayOrderRequest) (*paymentV1.PayOrderResponse, error) {
+	if err := validatePayOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	p, err := s.providers.Provider(req.GetPaymentMethod())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	now := time.Now()
+	transaction := &storage.Transaction{
+		Uuid:          uuid.NewString(),
+		OrderUuid:     req.GetOrderUuid(),
+		UserUuid:      req.GetUserUuid(),
+		PaymentMethod: req.GetPaymentMethod(),
+		Amount:        req.GetAmount(),
+		Currency:      req.GetCurrency(),
+		Status:        storage.TransactionStatusPending,
+		Provider:      p.Name(),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	err = s.storage.CreateTransaction(ctx, transaction)
+	if errors.Is(err, storage.ErrActiveTransactionExists) {
+		return s.replayPayment(ctx, req)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to create transaction", slog.String("order_uuid", req.GetOrderUuid()), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return s.charge(ctx, p, transaction)
+}
 
-	log.Printf("Оплата прошла успешно, transaction_uuid: %s\n", u)
+// charge отправляет провайдеру списание по транзакции и сохраняет его результат. Uuid транзакции служит
+// ключом идемпотентности у провайдера, поэтому повторное списание той же транзакции не списывает средства дважды.
+// Если результат неизвестен из-за таймаута или ошибки провайдера, транзакция остается в ожидании: новую
+// транзакцию для заказа создать нельзя, пока списание не будет повторено или сверено с провайдером
+func (s *paymentService) charge(ctx context.Context, p provider.Provider, transaction *storage.Transaction) (*paymentV1.PayOrderResponse, error) {
+	chargeCtx, cancel := context.WithTimeout(ctx, s.providerTimeout)
+	defer cancel()
 
+	result, chargeErr := p.Charge(chargeCtx, provider.ChargeRequest{
+		TransactionUuid: transaction.Uuid,
+		OrderUuid:       transaction.OrderUuid,
+		UserUuid:        transaction.UserUuid,
+		Amount:          transaction.Amount,
+		Currency:        transaction.Currency,
+	})
+
+	applyChargeResult(transaction, result, chargeErr)
+
+	// Результат списания сохраняем даже если клиент уже отменил запрос. Если сохранить не удалось,
+	// клиент все равно получает ответ провайдера, а транзакция остается в ожидании до сверки с провайдером
+	if err := s.storage.UpdateTransaction(context.WithoutCancel(ctx), transaction); err != nil {
+		slog.ErrorContext(ctx, "failed to update transaction, it will be reconciled with provider",
+			slog.String("order_uuid", transaction.OrderUuid),
+			slog.String("transaction_uuid", transaction.Uuid),
+			slog.String("status", string(transaction.Status)),
+			logger.Err(err),
+		)
+	} else if transaction.Status != storage.TransactionStatusPending {
+		s.metrics.RecordPayment(ctx, transaction.PaymentMethod, transaction.Status)
+	}
+
+	switch transaction.Status {
+	case storage.TransactionStatusSucceeded:
+		slog.InfoContext(ctx, "payment succeeded", slog.String("order_uuid", transaction.OrderUuid), slog.String("transaction_uuid", transaction.Uuid))
+		return &paymentV1.PayOrderResponse{
+			TransactionUuid: transaction.Uuid,
+		}, nil
+	case storage.TransactionStatusDeclined:
+		return nil, status.Error(codes.FailedPrecondition, chargeErr.Error())
+	}
+
+	slog.WarnContext(ctx, "payment result is unknown, transaction stays pending",
+		slog.String("order_uuid", transaction.OrderUuid),
+		slog.String("transaction_uuid", transaction.Uuid),
+		logger.Err(chargeErr),
+	)
+	if errors.Is(chargeErr, provider.ErrTimeout) {
+		return nil, status.Error(codes.DeadlineExceeded, "payment provider timeout")
+	}
+
+	return nil, status.Error(codes.Internal, "internal error")
+}
+
+// applyChargeResult записывает в транзакцию ответ провайдера на списание. При таймауте или другой ошибке
+// провайдера списание могло пройти, поэтому транзакция остается в ожидании
+func applyChargeResult(transaction *storage.Transaction, result provider.ChargeResult, chargeErr error) {
+	switch {
+	case chargeErr == nil:
+		transaction.Status = storage.TransactionStatusSucceeded
+		transaction.ProviderReference = result.Reference
+	case errors.Is(chargeErr, provider.ErrDeclined),
+		errors.Is(chargeErr, provider.ErrUnsupportedCurrency),
+		errors.Is(chargeErr, provider.ErrAmountLimitExceeded):
+		transaction.Status = storage.TransactionStatusDeclined
+		transaction.FailureReason = chargeErr.Error()
+	}
+	transaction.UpdatedAt = time.Now()
+}
+
+// replayPayment отвечает на повторный запрос оплаты заказа, у которого уже есть активная транзакция.
+// Успешная транзакция возвращается, если параметры оплаты совпадают с исходным запросом. Списание ожидающей
+// транзакции, ответ на которое не получен за время ожидания провайдера, отправляется повторно с тем же uuid
+func (s *paymentService) replayPayment(ctx context.Context, req *paymentV1.PayOrderRequest) (*paymentV1.PayOrderResponse, error) {
+	transaction, err := s.storage.GetActiveTransactionByOrder(ctx, req.GetOrderUuid())
+	if err != nil {
+		if errors.Is(err, storage.ErrTransactionNotFound) {
+			// Активная транзакция успела завершиться отказом, клиент может повторить оплату
+			return nil, status.Error(codes.Aborted, "concurrent payment for order finished, retry the request")
+		}
+
+		slog.ErrorContext(ctx, "failed to get active transaction", slog.String("order_uuid", req.GetOrderUuid()), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if transaction.UserUuid != req.GetUserUuid() ||
+		transaction.PaymentMethod != req.GetPaymentMethod() ||
+		transaction.Amount != req.GetAmount() ||
+		transaction.Currency != req.GetCurrency() {
+		return nil, status.Error(codes.AlreadyExists, "order is already paid with different payment parameters")
+	}
+
+	if transaction.Status == storage.TransactionStatusPending {
+		if time.Since(transaction.UpdatedAt) < s.providerTimeout {
+			return nil, status.Error(codes.Aborted, "payment for order is already in progress")
+		}
+
+		p, err := s.providers.Provider(transaction.PaymentMethod)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		slog.InfoContext(ctx, "retrying charge of pending transaction",
+			slog.String("order_uuid", transaction.OrderUuid),
+			slog.String("transaction_uuid", transaction.Uuid),
+		)
+		return s.charge(ctx, p, transaction)
+	}
+
+	slog.InfoContext(ctx, "order already paid, returning transaction", slog.String("order_uuid", req.GetOrderUuid()), slog.String("transaction_uuid", transaction.Uuid))
 	return &paymentV1.PayOrderResponse{
-		TransactionUuid: u,
+		TransactionUuid: transaction.Uuid,
 	}, nil
 }
 
+// GetOrderPayment возвращает ожидающую или успешную транзакцию заказа. Ожидающая транзакция
+// завершается повтором оплаты или сверкой с провайдером, после чего запрос нужно повторить
+func (s *paymentService) GetOrderPayment(ctx context.Context, req *paymentV1.GetOrderPaymentRequest) (*paymentV1.GetOrderPaymentResponse, error) {
+	if req.GetOrderUuid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order uuid is required")
+	}
+
+	transaction, err := s.storage.GetActiveTransactionByOrder(ctx, req.GetOrderUuid())
+	if err != nil {
+		if errors.Is(err, storage.ErrTransactionNotFound) {
+			return nil, status.Errorf(codes.NotFound, "order %s has no pending or succeeded transaction", req.GetOrderUuid())
+		}
+
+		slog.ErrorContext(ctx, "failed to get active transaction", slog.String("order_uuid", req.GetOrderUuid()), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	res := &paymentV1.GetOrderPaymentResponse{
+		TransactionUuid: transaction.Uuid,
+		Status:          paymentV1.TransactionStatus_TRANSACTION_STATUS_PENDING,
+	}
+	if transaction.Status == storage.TransactionStatusSucceeded {
+		res.Status = paymentV1.TransactionStatus_TRANSACTION_STATUS_SUCCEEDED
+	}
+
+	return res, nil
+}
+
+// validatePayOrderRequest проверяет обязательные поля запроса на оплату
+func validatePayOrderRequest(req *paymentV1.PayOrderRequest) error {
+	if req.GetOrderUuid() == "" {
+		return status.Error(codes.InvalidArgument, "order uuid is required")
+	}
+	if req.GetUserUuid() == "" {
+		return status.Error(codes.InvalidArgument, "user uuid is required")
+	}
+	if req.GetAmount() <= 0 {
+		return status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	if !currencyPattern.MatchString(req.GetCurrency()) {
+		return status.Error(codes.InvalidArgument, "currency must be an ISO 4217 code")
+	}
+
+	return nil
+}
+
 func main() {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
+	logger.Init("payment")
+
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("invalid config", logger.Err(err))
+		return
+	}
+	logger.SetLevel(cfg.Log.SlogLevel())
+	slog.Info("effective config", slog.String("config", cfg.String()))
+
+	shutdownTracing, err := tracing.Init(context.Background(), "payment", cfg.Tracing)
+	if err != nil {
+		slog.Error("failed to init tracing", logger.Err(err))
+		return
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.GRPC.ShutdownTimeout)
+		defer cancel()
+
+		if serr := shutdownTracing(ctx); serr != nil {
+			slog.Error("failed to shut down tracing", logger.Err(serr))
+		}
+	}()
+
+	metricsHandler, shutdownMetrics, err := sharedMetrics.Init(context.Background(), "payment")
 	if err != nil {
-		log.Printf("failed to listen: %v", err)
+		slog.Error("failed to init metrics", logger.Err(err))
+		return
 	}
 	defer func() {
-		if cerr := lis.Close(); cerr != nil {
-			log.Printf("failed to close listener: %v\n", cerr)
+		if serr := shutdownMetrics(context.Background()); serr != nil {
+			slog.Error("failed to shut down metrics", logger.Err(serr))
 		}
 	}()
 
+	paymentMetrics, err := metrics.New()
+	if err != nil {
+		slog.Error("failed to create metrics", logger.Err(err))
+		return
+	}
+
+	creds, err := cfg.GRPC.TLS.GRPCCredentials()
+	if err != nil {
+		slog.Error("failed to load tls config", logger.Err(err))
+		return
+	}
+
+	lis, err := net.Listen("tcp", cfg.GRPC.Address)
+	if err != nil {
+		slog.Error("failed to listen", slog.String("address", cfg.GRPC.Address), logger.Err(err))
+		return
+	}
+	defer func() {
+		if cerr := lis.Close(); cerr != nil && !errors.Is(cerr, net.ErrClosed) {
+			slog.Error("failed to close listener", logger.Err(cerr))
+		}
+	}()
+
+	// Создаем хранилище транзакций
+	transactionStorage, closeStorage, err := newTransactionStorage(context.Background(), cfg.Storage)
+	if err != nil {
+		slog.Error("failed to create transaction storage", logger.Err(err))
+		return
+	}
+	defer closeStorage()
+
+	// Обращения к хранилищу попадают в трассу запроса
+	transactionStorage = storage.WithTracing(transactionStorage)
+
+	// Создаем локальный провайдер, через который работают адаптеры всех способов оплаты.
+	// Режим уже проверен при загрузке конфигурации
+	fakeMode, _ := provider.ParseFakeMode(cfg.FakeProviderMode)
+	slog.Info("using fake payment provider", slog.String("mode", string(fakeMode)))
+
 	// Создаем gRPC сервер
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.UnaryInterceptor(logger.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(logger.StreamServerInterceptor()),
+	)
+
+	service := newPaymentService(transactionStorage, provider.NewRegistry(provider.NewFakeProvider(fakeMode)), paymentMetrics, cfg.ProviderTimeout)
 
-	service := &paymentService{}
+	// Запускаем сверку транзакций, оставшихся в ожидании после сбоя или таймаута провайдера
+	reconcileCtx, stopReconcile := context.WithCancel(context.Background())
+	defer stopReconcile()
+
+	go service.ReconcilePendingTransactions(reconcileCtx, cfg.PendingTimeout)
 
 	paymentV1.RegisterPaymentServiceServer(s, service)
 
+	// Регистрируем стандартную проверку здоровья, по ней order service проверяет готовность зависимостей
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(paymentV1.PaymentService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+
 	// Включаем рефлексию для отладки
 	reflection.Register(s)
 
 	go func() {
-		log.Printf("🚀 gRPC server listening on %d\n", grpcPort)
+		slog.Info("grpc server listening", slog.String("address", lis.Addr().String()))
 		err = s.Serve(lis)
 		if err != nil {
-			log.Printf("failed to serve: %v\n", err)
+			slog.Error("failed to serve", logger.Err(err))
 			return
 		}
 	}()
 
+	// Запускаем сервер метрик
+	metricsServer := sharedMetrics.NewServer(cfg.Metrics.Address, metricsHandler)
+	go func() {
+		slog.Info("metrics server listening", slog.String("address", cfg.Metrics.Address))
+		if serr := metricsServer.ListenAndServe(); serr != nil && !errors.Is(serr, http.ErrServerClosed) {
+			slog.Error("failed to serve metrics", logger.Err(serr))
+		}
+	}()
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	log.Println("🛑 Shutting down gRPC server...")
-	s.GracefulStop()
-	log.Println("✅ Server stopped")
+	slog.Info("shutting down grpc server")
+	// Сообщаем клиентам, что сервер больше не принимает запросы
+	healthServer.Shutdown()
+	gracefulStop(s, cfg.GRPC.ShutdownTimeout)
+	if err = metricsServer.Close(); err != nil {
+		slog.Error("failed to close metrics server", logger.Err(err))
+	}
+	slog.Info("server stopped")
+}
+
+// gracefulStop ожидает завершения обрабатываемых запросов не дольше timeout, после чего прерывает их
+func gracefulStop(s *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		slog.Warn("shutdown timeout exceeded, closing remaining connections")
+		s.Stop()
+	}
+}
+
+// newTransactionStorage создает хранилище транзакций выбранного в конфигурации типа,
+// для PostgreSQL перед началом работы применяются миграции
+func newTransactionStorage(ctx context.Context, cfg sharedConfig.Storage) (storage.TransactionStorage, func(), error) {
+	switch cfg.Type {
+	case sharedConfig.StorageTypeInMem:
+		slog.Info("using in-memory transaction storage")
+		return storage.NewTransactionStorageInMem(), func() {}, nil
+	case sharedConfig.StorageTypePostgres:
+		pool, err := pgxpool.New(ctx, cfg.PostgresDSN)
+		if err != nil {
+			return nil, nil, fmt.Errorf("connect to postgres: %w", err)
+		}
+
+		if err = pool.Ping(ctx); err != nil {
+			pool.Close()
+			return nil, nil, fmt.Errorf("ping postgres: %w", err)
+		}
+
+		if err = storage.Migrate(ctx, pool); err != nil {
+			pool.Close()
+			return nil, nil, err
+		}
+
+		slog.Info("using postgres transaction storage")
+		return storage.NewTransactionStoragePostgres(pool), pool.Close, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown storage type %q", cfg.Type)
 }
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Igorezka/rocket-factory/payment/internal/provider"
+	"github.com/Igorezka/rocket-factory/payment/internal/storage"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+)
+
+const (
+	// reconcileInterval интервал поиска ожидающих транзакций для сверки с провайдером
+	reconcileInterval = 30 * time.Second
+	// reconcileBatchSize максимальное количество транзакций, сверяемых за один раз
+	reconcileBatchSize = 100
+)
+
+// ReconcilePendingTransactions периодически сверяет с провайдером транзакции, которые остаются в ожидании
+// дольше pendingTimeout, пока не будет отменен контекст. Так транзакция завершается, если сервис
+// остановился между списанием и сохранением ответа провайдера или не смог сохранить ответ
+func (s *paymentService) ReconcilePendingTransactions(ctx context.Context, pendingTimeout time.Duration) {
+	ticker := time.NewTicker(reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			transactions, err := s.storage.ListPendingTransactions(ctx, now.Add(-pendingTimeout), reconcileBatchSize)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to list pending transactions", logger.Err(err))
+				continue
+			}
+
+			for _, transaction := range transactions {
+				if err = s.reconcileTransaction(ctx, transaction); err != nil {
+					slog.WarnContext(ctx, "failed to reconcile pending transaction",
+						slog.String("order_uuid", transaction.OrderUuid),
+						slog.String("transaction_uuid", transaction.Uuid),
+						logger.Err(err),
+					)
+				}
+			}
+		}
+	}
+}
+
+// reconcileTransaction запрашивает у провайдера результат списания по uuid транзакции и сохраняет его.
+// Если провайдер не получал списание, транзакция завершается ошибкой и заказ можно оплатить повторно.
+// Если провайдер не ответил, транзакция остается в ожидании до следующей сверки
+func (s *paymentService) reconcileTransaction(ctx context.Context, transaction *storage.Transaction) error {
+	p, err := s.providers.Provider(transaction.PaymentMethod)
+	if err != nil {
+		return err
+	}
+
+	statusCtx, cancel := context.WithTimeout(ctx, s.providerTimeout)
+	defer cancel()
+
+	result, err := p.ChargeStatus(statusCtx, transaction.Uuid)
+	switch {
+	case errors.Is(err, provider.ErrChargeNotFound):
+		transaction.Status = storage.TransactionStatusFailed
+		transaction.FailureReason = err.Error()
+		transaction.UpdatedAt = time.Now()
+	case err == nil, errors.Is(err, provider.ErrDeclined):
+		applyChargeResult(transaction, result, err)
+	default:
+		return err
+	}
+
+	if err = s.storage.UpdateTransaction(ctx, transaction); err != nil {
+		return err
+	}
+
+	s.metrics.RecordPayment(ctx, transaction.PaymentMethod, transaction.Status)
+	slog.InfoContext(ctx, "reconciled pending transaction",
+		slog.String("order_uuid", transaction.OrderUuid),
+		slog.String("transaction_uuid", transaction.Uuid),
+		slog.String("status", string(transaction.Status)),
+	)
+
+	return nil
+}
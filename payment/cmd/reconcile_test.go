@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Igorezka/rocket-factory/payment/internal/metrics"
+	"github.com/Igorezka/rocket-factory/payment/internal/provider"
+	"github.com/Igorezka/rocket-factory/payment/internal/storage"
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+// newTestService создает сервис оплаты с хранилищем в памяти поверх fake-провайдера
+func newTestService(t *testing.T, gateway *provider.FakeProvider) (*paymentService, *storage.TransactionStorageInMem) {
+	t.Helper()
+
+	paymentMetrics, err := metrics.New()
+	if err != nil {
+		t.Fatalf("metrics.New: %v", err)
+	}
+
+	transactionStorage := storage.NewTransactionStorageInMem()
+
+	return newPaymentService(transactionStorage, provider.NewRegistry(gateway), paymentMetrics, 50*time.Millisecond), transactionStorage
+}
+
+// pendingTransaction сохраняет ожидающую транзакцию оплаты картой
+func pendingTransaction(t *testing.T, transactionStorage storage.TransactionStorage, transactionUuid string) *storage.Transaction {
+	t.Helper()
+
+	transaction := &storage.Transaction{
+		Uuid:          transactionUuid,
+		OrderUuid:     "order-" + transactionUuid,
+		UserUuid:      "user",
+		PaymentMethod: paymentV1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:        100,
+		Currency:      "RUB",
+		Status:        storage.TransactionStatusPending,
+	}
+	if err := transactionStorage.CreateTransaction(context.Background(), transaction); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	return transaction
+}
+
+func TestReconcileTransaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       provider.FakeMode
+		charged    bool
+		wantStatus storage.TransactionStatus
+	}{
+		{name: "approved charge", mode: provider.FakeModeApprove, charged: true, wantStatus: storage.TransactionStatusSucceeded},
+		{name: "declined charge", mode: provider.FakeModeDecline, charged: true, wantStatus: storage.TransactionStatusDeclined},
+		{name: "timed out charge", mode: provider.FakeModeTimeout, charged: true, wantStatus: storage.TransactionStatusDeclined},
+		{name: "charge not received", mode: provider.FakeModeApprove, wantStatus: storage.TransactionStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := provider.NewFakeProvider(tt.mode)
+			service, transactionStorage := newTestService(t, gateway)
+			transaction := pendingTransaction(t, transactionStorage, "t1")
+
+			// Провайдер получил списание, но сервис не сохранил его результат
+			if tt.charged {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, _ = gateway.Charge(ctx, provider.ChargeRequest{TransactionUuid: transaction.Uuid, Amount: 100, Currency: "RUB"})
+			}
+
+			if err := service.reconcileTransaction(context.Background(), transaction); err != nil {
+				t.Fatalf("reconcileTransaction: %v", err)
+			}
+
+			stored, err := transactionStorage.GetTransaction(context.Background(), transaction.Uuid)
+			if err != nil {
+				t.Fatalf("GetTransaction: %v", err)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Fatalf("reconcileTransaction: got status %s, want %s", stored.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPayOrderTimeoutIsReconciled(t *testing.T) {
+	service, transactionStorage := newTestService(t, provider.NewFakeProvider(provider.FakeModeTimeout))
+
+	_, err := service.PayOrder(context.Background(), &paymentV1.PayOrderRequest{
+		OrderUuid:     "order",
+		UserUuid:      "user",
+		PaymentMethod: paymentV1.PaymentMethod_PAYMENT_METHOD_SBP,
+		Amount:        100,
+		Currency:      "RUB",
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("PayOrder: got %v, want %s", err, codes.DeadlineExceeded)
+	}
+
+	transaction, err := transactionStorage.GetActiveTransactionByOrder(context.Background(), "order")
+	if err != nil {
+		t.Fatalf("GetActiveTransactionByOrder: %v", err)
+	}
+	if transaction.Status != storage.TransactionStatusPending {
+		t.Fatalf("PayOrder: got status %s, want %s", transaction.Status, storage.TransactionStatusPending)
+	}
+
+	if err = service.reconcileTransaction(context.Background(), transaction); err != nil {
+		t.Fatalf("reconcileTransaction: %v", err)
+	}
+
+	if _, err = transactionStorage.GetActiveTransactionByOrder(context.Background(), "order"); err == nil {
+		t.Fatal("GetActiveTransactionByOrder: got active transaction after reconcile, want none")
+	}
+	stored, err := transactionStorage.GetTransaction(context.Background(), transaction.Uuid)
+	if err != nil {
+		t.Fatalf("GetTransaction: %v", err)
+	}
+	if stored.Status != storage.TransactionStatusDeclined {
+		t.Fatalf("reconcileTransaction: got status %s, want %s", stored.Status, storage.TransactionStatusDeclined)
+	}
+}
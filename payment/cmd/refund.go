@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Igorezka/rocket-factory/payment/internal/provider"
+	"github.com/Igorezka/rocket-factory/payment/internal/storage"
+	"github.com/Igorezka/rocket-factory/shared/pkg/logger"
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+// maxRefundReasonLength максимальная длина причины возврата
+const maxRefundReasonLength = 500
+
+// RefundPayment возвращает средства по успешной транзакции через провайдера, которым проводилось списание.
+// Возврат сохраняется до обращения к провайдеру и обновляется по его ответу.
+// Возврат идемпотентен по uuid: повторный запрос проведенного возврата не зачисляет средства повторно
+func (s *paymentService) RefundPayment(ctx context.Context, req *paymentV1.RefundPaymentRequest) (*paymentV1.RefundPaymentResponse, error) {
+	if err := validateRefundPaymentRequest(req); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.storage.GetTransaction(ctx, req.GetTransactionUuid())
+	if err != nil {
+		if errors.Is(err, storage.ErrTransactionNotFound) {
+			return nil, status.Errorf(codes.NotFound, "transaction %s not found", req.GetTransactionUuid())
+		}
+
+		slog.ErrorContext(ctx, "failed to get transaction", slog.String("transaction_uuid", req.GetTransactionUuid()), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	p, err := s.providers.Provider(transaction.PaymentMethod)
+	if err != nil {
+		slog.ErrorContext(ctx, "no provider for transaction", slog.String("transaction_uuid", transaction.Uuid), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	now := time.Now()
+	refund := &storage.Refund{
+		Uuid:            req.GetRefundUuid(),
+		TransactionUuid: transaction.Uuid,
+		Amount:          req.GetAmount(),
+		Currency:        transaction.Currency,
+		Status:          storage.RefundStatusPending,
+		Reason:          req.GetReason(),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	err = s.storage.CreateRefund(ctx, refund)
+	switch {
+	case errors.Is(err, storage.ErrRefundAlreadyExists):
+		return s.replayRefund(ctx, req, transaction)
+	case errors.Is(err, storage.ErrTransactionNotRefundable):
+		return nil, status.Errorf(codes.FailedPrecondition, "transaction %s is %s, only succeeded transactions can be refunded",
+			transaction.Uuid, transaction.Status)
+	case errors.Is(err, storage.ErrRefundExceedsPayment):
+		return nil, status.Errorf(codes.FailedPrecondition, "refund amount exceeds refundable amount of transaction %s", transaction.Uuid)
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to create refund", slog.String("transaction_uuid", transaction.Uuid), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	refundCtx, cancel := context.WithTimeout(ctx, s.providerTimeout)
+	defer cancel()
+
+	result, refundErr := p.Refund(refundCtx, provider.RefundRequest{
+		RefundUuid:      refund.Uuid,
+		TransactionUuid: transaction.Uuid,
+		ChargeReference: transaction.ProviderReference,
+		Amount:          refund.Amount,
+		Currency:        refund.Currency,
+	})
+
+	switch {
+	case refundErr == nil:
+		refund.Status = storage.RefundStatusSucceeded
+		refund.ProviderReference = result.Reference
+	case errors.Is(refundErr, provider.ErrDeclined):
+		refund.Status = storage.RefundStatusDeclined
+		refund.FailureReason = refundErr.Error()
+	default:
+		refund.Status = storage.RefundStatusFailed
+		refund.FailureReason = refundErr.Error()
+	}
+	refund.UpdatedAt = time.Now()
+
+	// Результат возврата сохраняем даже если клиент уже отменил запрос
+	if err = s.storage.UpdateRefund(context.WithoutCancel(ctx), refund); err != nil {
+		slog.ErrorContext(ctx, "failed to update refund", slog.String("refund_uuid", refund.Uuid), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	switch refund.Status {
+	case storage.RefundStatusSucceeded:
+		slog.InfoContext(ctx, "refund succeeded", slog.String("refund_uuid", refund.Uuid), slog.String("transaction_uuid", transaction.Uuid))
+		return s.refundResponse(ctx, refund, transaction)
+	case storage.RefundStatusDeclined:
+		return nil, status.Error(codes.FailedPrecondition, refundErr.Error())
+	}
+
+	slog.WarnContext(ctx, "refund failed", slog.String("refund_uuid", refund.Uuid), slog.String("transaction_uuid", transaction.Uuid), logger.Err(refundErr))
+	if errors.Is(refundErr, provider.ErrTimeout) {
+		return nil, status.Error(codes.DeadlineExceeded, "payment provider timeout")
+	}
+
+	return nil, status.Error(codes.Internal, "internal error")
+}
+
+// replayRefund отвечает на повторный запрос возврата с уже использованным uuid.
+// Проведенный возврат возвращается, если параметры совпадают с исходным запросом
+func (s *paymentService) replayRefund(
+	ctx context.Context,
+	req *paymentV1.RefundPaymentRequest,
+	transaction *storage.Transaction,
+) (*paymentV1.RefundPaymentResponse, error) {
+	refund, err := s.storage.GetRefund(ctx, req.GetRefundUuid())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get refund", slog.String("refund_uuid", req.GetRefundUuid()), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if refund.TransactionUuid != req.GetTransactionUuid() ||
+		(req.GetAmount() != 0 && refund.Amount != req.GetAmount()) {
+		return nil, status.Error(codes.AlreadyExists, "refund already exists with different parameters")
+	}
+
+	switch refund.Status {
+	case storage.RefundStatusPending:
+		return nil, status.Error(codes.Aborted, "refund is already in progress")
+	case storage.RefundStatusDeclined, storage.RefundStatusFailed:
+		return nil, status.Errorf(codes.FailedPrecondition, "refund %s is %s: %s", refund.Uuid, refund.Status, refund.FailureReason)
+	}
+
+	slog.InfoContext(ctx, "refund already succeeded, returning it", slog.String("refund_uuid", refund.Uuid))
+	return s.refundResponse(ctx, refund, transaction)
+}
+
+// refundResponse формирует ответ по проведенному возврату с итогами возвратов по транзакции
+func (s *paymentService) refundResponse(
+	ctx context.Context,
+	refund *storage.Refund,
+	transaction *storage.Transaction,
+) (*paymentV1.RefundPaymentResponse, error) {
+	refunded, err := s.storage.RefundedAmount(context.WithoutCancel(ctx), transaction.Uuid)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get refunded amount", slog.String("transaction_uuid", transaction.Uuid), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &paymentV1.RefundPaymentResponse{
+		RefundUuid:    refund.Uuid,
+		Amount:        refund.Amount,
+		RefundedTotal: refunded,
+		Remaining:     transaction.Amount - refunded,
+	}, nil
+}
+
+// validateRefundPaymentRequest проверяет обязательные поля запроса на возврат
+func validateRefundPaymentRequest(req *paymentV1.RefundPaymentRequest) error {
+	if req.GetRefundUuid() == "" {
+		return status.Error(codes.InvalidArgument, "refund uuid is required")
+	}
+	if req.GetTransactionUuid() == "" {
+		return status.Error(codes.InvalidArgument, "transaction uuid is required")
+	}
+	if req.GetAmount() < 0 {
+		return status.Error(codes.InvalidArgument, "amount must not be negative")
+	}
+	if len(req.GetReason()) > maxRefundReasonLength {
+		return status.Errorf(codes.InvalidArgument, "reason must be at most %d bytes", maxRefundReasonLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Package config описывает конфигурацию payment service
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Igorezka/rocket-factory/payment/internal/provider"
+	sharedConfig "github.com/Igorezka/rocket-factory/shared/pkg/config"
+)
+
+// EnvPrefix префикс переменных окружения payment service
+const EnvPrefix = "PAYMENT"
+
+// Config конфигурация payment service
+type Config struct {
+	Log     sharedConfig.Log        `yaml:"log" env:"LOG"`
+	Tracing sharedConfig.Tracing    `yaml:"tracing" env:"TRACING"`
+	Metrics sharedConfig.Metrics    `yaml:"metrics" env:"METRICS"`
+	GRPC    sharedConfig.GRPCServer `yaml:"grpc" env:"GRPC"`
+	Storage sharedConfig.Storage    `yaml:"storage"`
+	// ProviderTimeout время ожидания ответа платежного провайдера
+	ProviderTimeout time.Duration `yaml:"provider_timeout" env:"PROVIDER_TIMEOUT"`
+	// PendingTimeout время, после которого ожидающая транзакция сверяется с провайдером.
+	// Должно быть больше ProviderTimeout, чтобы не сверять транзакции, списание по которым еще выполняется
+	PendingTimeout time.Duration `yaml:"pending_timeout" env:"PENDING_TIMEOUT"`
+	// FakeProviderMode поведение локального платежного провайдера: approve, decline или timeout
+	FakeProviderMode string `yaml:"fake_provider_mode" env:"FAKE_PROVIDER_MODE"`
+}
+
+// Default возвращает конфигурацию по умолчанию
+func Default() *Config {
+	return &Config{
+		Log: sharedConfig.Log{
+			Level: "info",
+		},
+		Tracing: sharedConfig.Tracing{
+			Exporter:    sharedConfig.TracingExporterNone,
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			File:        "payment-traces.jsonl",
+			SampleRatio: 1,
+		},
+		Metrics: sharedConfig.Metrics{
+			Address: ":9092",
+		},
+		GRPC: sharedConfig.GRPCServer{
+			Address:         ":50052",
+			ShutdownTimeout: 10 * time.Second,
+		},
+		Storage: sharedConfig.Storage{
+			Type: sharedConfig.StorageTypeInMem,
+		},
+		ProviderTimeout:  5 * time.Second,
+		PendingTimeout:   time.Minute,
+		FakeProviderMode: string(provider.FakeModeApprove),
+	}
+}
+
+// Load загружает конфигурацию из YAML файла PAYMENT_CONFIG_FILE и переменных окружения PAYMENT_*
+func Load() (*Config, error) {
+	cfg := Default()
+	if err := sharedConfig.Load(EnvPrefix, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate проверяет время ожидания провайдера, время сверки ожидающих транзакций и режим локального провайдера
+func (c *Config) Validate() error {
+	if err := sharedConfig.Positive("provider_timeout", c.ProviderTimeout); err != nil {
+		return err
+	}
+
+	if c.PendingTimeout <= c.ProviderTimeout {
+		return fmt.Errorf("pending_timeout must be greater than provider_timeout %s, got %s", c.ProviderTimeout, c.PendingTimeout)
+	}
+
+	_, err := provider.ParseFakeMode(c.FakeProviderMode)
+	return err
+}
+
+// String возвращает итоговую конфигурацию в формате YAML
+func (c *Config) String() string {
+	return sharedConfig.Format(EnvPrefix, c)
+}
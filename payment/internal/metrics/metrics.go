@@ -0,0 +1,40 @@
+// Package metrics описывает бизнес-метрики payment service: исходы оплат по способам оплаты
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/Igorezka/rocket-factory/payment/internal/storage"
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+// Metrics бизнес-метрики payment service
+type Metrics struct {
+	payments metric.Int64Counter
+}
+
+// New создает метрики payment service в провайдере метрик по умолчанию
+func New() (*Metrics, error) {
+	meter := otel.Meter("github.com/Igorezka/rocket-factory/payment/internal/metrics")
+
+	payments, err := meter.Int64Counter("payments",
+		metric.WithDescription("Payment attempts by payment method and outcome"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metrics{payments: payments}, nil
+}
+
+// RecordPayment записывает исход попытки оплаты — статус транзакции после ответа платежного провайдера
+func (m *Metrics) RecordPayment(ctx context.Context, method paymentV1.PaymentMethod, outcome storage.TransactionStatus) {
+	m.payments.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("payment_method", method.String()),
+		attribute.String("outcome", string(outcome)),
+	))
+}
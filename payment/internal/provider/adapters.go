@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// methodProvider адаптер способа оплаты: проверяет ограничения способа и передает списание шлюзу
+type methodProvider struct {
+	name       string
+	currencies []string
+	// maxAmount максимальная сумма одного списания в минимальных единицах валюты, 0 — без ограничения
+	maxAmount int64
+	gateway   Provider
+}
+
+// NewCardProvider создает адаптер оплаты банковской картой
+func NewCardProvider(gateway Provider) Provider {
+	return &methodProvider{
+		name:       "card",
+		currencies: []string{"RUB", "USD", "EUR"},
+		maxAmount:  100_000_000_00,
+		gateway:    gateway,
+	}
+}
+
+// NewSBPProvider создает адаптер оплаты через Систему быстрых платежей, СБП работает только с рублями
+func NewSBPProvider(gateway Provider) Provider {
+	return &methodProvider{
+		name:       "sbp",
+		currencies: []string{"RUB"},
+		maxAmount:  1_000_000_00,
+		gateway:    gateway,
+	}
+}
+
+// NewCreditCardProvider создает адаптер оплаты кредитной картой
+func NewCreditCardProvider(gateway Provider) Provider {
+	return &methodProvider{
+		name:       "credit_card",
+		currencies: []string{"RUB", "USD", "EUR"},
+		maxAmount:  10_000_000_00,
+		gateway:    gateway,
+	}
+}
+
+// NewInvestorMoneyProvider создает адаптер оплаты деньгами инвестора, внутренний способ без ограничения суммы
+func NewInvestorMoneyProvider(gateway Provider) Provider {
+	return &methodProvider{
+		name:       "investor_money",
+		currencies: []string{"RUB", "USD", "EUR"},
+		gateway:    gateway,
+	}
+}
+
+// Name возвращает название способа оплаты вместе с названием шлюза
+func (p *methodProvider) Name() string {
+	return p.name + "/" + p.gateway.Name()
+}
+
+// Charge проверяет валюту и лимит способа оплаты и списывает средства через шлюз
+func (p *methodProvider) Charge(ctx context.Context, req ChargeRequest) (ChargeResult, error) {
+	if !slices.Contains(p.currencies, req.Currency) {
+		return ChargeResult{}, fmt.Errorf("%w: %s for %s", ErrUnsupportedCurrency, req.Currency, p.name)
+	}
+
+	if p.maxAmount > 0 && req.Amount > p.maxAmount {
+		return ChargeResult{}, fmt.Errorf("%w: %d > %d for %s", ErrAmountLimitExceeded, req.Amount, p.maxAmount, p.name)
+	}
+
+	return p.gateway.Charge(ctx, req)
+}
+
+// ChargeStatus запрашивает результат списания у шлюза
+func (p *methodProvider) ChargeStatus(ctx context.Context, transactionUuid string) (ChargeResult, error) {
+	return p.gateway.ChargeStatus(ctx, transactionUuid)
+}
+
+// Refund передает возврат шлюзу, ограничения способа оплаты проверены при списании
+func (p *methodProvider) Refund(ctx context.Context, req RefundRequest) (RefundResult, error) {
+	return p.gateway.Refund(ctx, req)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+func TestMethodProviderCharge(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   paymentV1.PaymentMethod
+		amount   int64
+		currency string
+		wantErr  error
+	}{
+		{name: "card", method: paymentV1.PaymentMethod_PAYMENT_METHOD_CARD, amount: 100, currency: "USD"},
+		{name: "card over limit", method: paymentV1.PaymentMethod_PAYMENT_METHOD_CARD, amount: 100_000_000_01, currency: "RUB", wantErr: ErrAmountLimitExceeded},
+		{name: "card in unknown currency", method: paymentV1.PaymentMethod_PAYMENT_METHOD_CARD, amount: 100, currency: "GBP", wantErr: ErrUnsupportedCurrency},
+		{name: "sbp at limit", method: paymentV1.PaymentMethod_PAYMENT_METHOD_SBP, amount: 1_000_000_00, currency: "RUB"},
+		{name: "sbp over limit", method: paymentV1.PaymentMethod_PAYMENT_METHOD_SBP, amount: 1_000_000_01, currency: "RUB", wantErr: ErrAmountLimitExceeded},
+		{name: "sbp in usd", method: paymentV1.PaymentMethod_PAYMENT_METHOD_SBP, amount: 100, currency: "USD", wantErr: ErrUnsupportedCurrency},
+		{name: "credit card over limit", method: paymentV1.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, amount: 10_000_000_01, currency: "EUR", wantErr: ErrAmountLimitExceeded},
+		{name: "investor money without limit", method: paymentV1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY, amount: 1_000_000_000_00, currency: "RUB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := NewFakeProvider(FakeModeApprove)
+			p, err := NewRegistry(gateway).Provider(tt.method)
+			if err != nil {
+				t.Fatalf("Provider: %v", err)
+			}
+
+			_, err = p.Charge(context.Background(), ChargeRequest{TransactionUuid: "t1", Amount: tt.amount, Currency: tt.currency})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Charge: got %v, want %v", err, tt.wantErr)
+			}
+
+			// Отклоненное адаптером списание не доходит до шлюза
+			_, err = gateway.ChargeStatus(context.Background(), "t1")
+			if (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("ChargeStatus: got %v, gateway charged %v, want %v", err, err == nil, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestMethodProviderDelegatesToGateway(t *testing.T) {
+	p := NewSBPProvider(NewFakeProvider(FakeModeDecline))
+
+	if got := p.Name(); got != "sbp/fake" {
+		t.Fatalf("Name: got %q, want %q", got, "sbp/fake")
+	}
+
+	if _, err := p.Charge(context.Background(), ChargeRequest{TransactionUuid: "t1", Amount: 100, Currency: "RUB"}); !errors.Is(err, ErrDeclined) {
+		t.Fatalf("Charge: got %v, want %v", err, ErrDeclined)
+	}
+	if _, err := p.ChargeStatus(context.Background(), "t1"); !errors.Is(err, ErrDeclined) {
+		t.Fatalf("ChargeStatus: got %v, want %v", err, ErrDeclined)
+	}
+	if _, err := p.Refund(context.Background(), RefundRequest{TransactionUuid: "t1"}); !errors.Is(err, ErrDeclined) {
+		t.Fatalf("Refund: got %v, want %v", err, ErrDeclined)
+	}
+}
+
+func TestRegistryUnsupportedMethod(t *testing.T) {
+	_, err := NewRegistry(NewFakeProvider(FakeModeApprove)).Provider(paymentV1.PaymentMethod_PAYMENT_METHOD_UNKNOWN_UNSPECIFIED)
+	if !errors.Is(err, ErrUnsupportedPaymentType) {
+		t.Fatalf("Provider: got %v, want %v", err, ErrUnsupportedPaymentType)
+	}
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// FakeMode поведение локального провайдера
+type FakeMode string
+
+const (
+	// FakeModeApprove все списания проходят успешно
+	FakeModeApprove FakeMode = "approve"
+	// FakeModeDecline все списания отклоняются
+	FakeModeDecline FakeMode = "decline"
+	// FakeModeTimeout провайдер не отвечает на списание до истечения таймаута запроса, а затем
+	// отклоняет его. Результат списания можно узнать через ChargeStatus
+	FakeModeTimeout FakeMode = "timeout"
+)
+
+// ParseFakeMode преобразует строку в режим локального провайдера
+func ParseFakeMode(s string) (FakeMode, error) {
+	switch mode := FakeMode(s); mode {
+	case FakeModeApprove, FakeModeDecline, FakeModeTimeout:
+		return mode, nil
+	}
+
+	return "", fmt.Errorf("unknown fake provider mode %q", s)
+}
+
+// FakeProvider локальный провайдер для разработки и тестов, поведение задается режимом.
+// Полученные списания запоминаются по uuid транзакции, чтобы их результат можно было запросить позже
+type FakeProvider struct {
+	mu      sync.RWMutex
+	mode    FakeMode
+	charges map[string]fakeCharge
+}
+
+// fakeCharge результат списания, полученного локальным провайдером
+type fakeCharge struct {
+	result ChargeResult
+	err    error
+}
+
+// NewFakeProvider создает локальный провайдер с переданным режимом
+func NewFakeProvider(mode FakeMode) *FakeProvider {
+	return &FakeProvider{
+		mode:    mode,
+		charges: make(map[string]fakeCharge),
+	}
+}
+
+// SetMode меняет режим провайдера во время работы
+func (p *FakeProvider) SetMode(mode FakeMode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.mode = mode
+}
+
+// Name возвращает название провайдера
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// Charge списывает средства в соответствии с текущим режимом. Повторное списание с тем же uuid транзакции
+// возвращает результат первого. В режиме таймаута списание отклоняется, но ответ не приходит
+// до истечения таймаута запроса
+func (p *FakeProvider) Charge(ctx context.Context, req ChargeRequest) (ChargeResult, error) {
+	p.mu.RLock()
+	mode := p.mode
+	charge, ok := p.charges[req.TransactionUuid]
+	p.mu.RUnlock()
+
+	if ok {
+		return charge.result, charge.err
+	}
+
+	switch mode {
+	case FakeModeDecline:
+		charge.err = fmt.Errorf("%w: declined by fake provider", ErrDeclined)
+	case FakeModeTimeout:
+		charge.err = fmt.Errorf("%w: timed out at fake provider", ErrDeclined)
+	default:
+		charge.result = ChargeResult{Reference: "fake-" + uuid.NewString()}
+	}
+
+	charge = p.remember(req.TransactionUuid, charge)
+
+	if mode == FakeModeTimeout {
+		<-ctx.Done()
+		return ChargeResult{}, fmt.Errorf("%w: %w", ErrTimeout, ctx.Err())
+	}
+
+	return charge.result, charge.err
+}
+
+// remember запоминает результат списания. Если параллельное списание той же транзакции
+// завершилось раньше, возвращает его результат
+func (p *FakeProvider) remember(transactionUuid string, charge fakeCharge) fakeCharge {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if first, ok := p.charges[transactionUuid]; ok {
+		return first
+	}
+	p.charges[transactionUuid] = charge
+
+	return charge
+}
+
+// ChargeStatus возвращает результат запомненного списания. Запрос результата отвечает в любом режиме,
+// поэтому сверка завершает транзакции, ответ на списание которых не был получен
+func (p *FakeProvider) ChargeStatus(_ context.Context, transactionUuid string) (ChargeResult, error) {
+	p.mu.RLock()
+	charge, ok := p.charges[transactionUuid]
+	p.mu.RUnlock()
+
+	if !ok {
+		return ChargeResult{}, ErrChargeNotFound
+	}
+
+	return charge.result, charge.err
+}
+
+// Refund возвращает средства в соответствии с текущим режимом
+func (p *FakeProvider) Refund(ctx context.Context, _ RefundRequest) (RefundResult, error) {
+	p.mu.RLock()
+	mode := p.mode
+	p.mu.RUnlock()
+
+	switch mode {
+	case FakeModeDecline:
+		return RefundResult{}, fmt.Errorf("%w: refund declined by fake provider", ErrDeclined)
+	case FakeModeTimeout:
+		<-ctx.Done()
+		return RefundResult{}, fmt.Errorf("%w: %w", ErrTimeout, ctx.Err())
+	}
+
+	return RefundResult{
+		Reference: "fake-refund-" + uuid.NewString(),
+	}, nil
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFakeProviderCharge(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          FakeMode
+		wantErr       error
+		wantStatusErr error
+	}{
+		{name: "approve", mode: FakeModeApprove},
+		{name: "decline", mode: FakeModeDecline, wantErr: ErrDeclined, wantStatusErr: ErrDeclined},
+		{name: "timeout", mode: FakeModeTimeout, wantErr: ErrTimeout, wantStatusErr: ErrDeclined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewFakeProvider(tt.mode)
+
+			// Отмененный контекст завершает ожидание ответа в режиме таймаута сразу
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			result, err := p.Charge(ctx, ChargeRequest{TransactionUuid: "t1", Amount: 100, Currency: "RUB"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Charge: got %v, want %v", err, tt.wantErr)
+			}
+			if (result.Reference != "") != (tt.wantErr == nil) {
+				t.Fatalf("Charge: got reference %q", result.Reference)
+			}
+
+			status, err := p.ChargeStatus(ctx, "t1")
+			if !errors.Is(err, tt.wantStatusErr) {
+				t.Fatalf("ChargeStatus: got %v, want %v", err, tt.wantStatusErr)
+			}
+			if status.Reference != result.Reference {
+				t.Fatalf("ChargeStatus: got reference %q, want %q", status.Reference, result.Reference)
+			}
+		})
+	}
+}
+
+func TestFakeProviderChargeIsIdempotent(t *testing.T) {
+	p := NewFakeProvider(FakeModeApprove)
+	req := ChargeRequest{TransactionUuid: "t1", Amount: 100, Currency: "RUB"}
+
+	first, err := p.Charge(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Charge: %v", err)
+	}
+
+	// Повтор возвращает результат первого списания, даже если режим изменился
+	p.SetMode(FakeModeDecline)
+	second, err := p.Charge(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Charge: %v", err)
+	}
+	if second.Reference != first.Reference {
+		t.Fatalf("Charge: got reference %q, want %q", second.Reference, first.Reference)
+	}
+}
+
+func TestFakeProviderTimedOutChargeIsDeclinedOnRepeat(t *testing.T) {
+	p := NewFakeProvider(FakeModeTimeout)
+	req := ChargeRequest{TransactionUuid: "t1", Amount: 100, Currency: "RUB"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Charge(ctx, req); !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Charge: got %v, want %v", err, ErrTimeout)
+	}
+
+	// Повтор списания той же транзакции отвечает сразу сохраненным отказом
+	if _, err := p.Charge(context.Background(), req); !errors.Is(err, ErrDeclined) {
+		t.Fatalf("Charge: got %v, want %v", err, ErrDeclined)
+	}
+}
+
+func TestFakeProviderChargeStatusNotFound(t *testing.T) {
+	p := NewFakeProvider(FakeModeTimeout)
+
+	if _, err := p.ChargeStatus(context.Background(), "unknown"); !errors.Is(err, ErrChargeNotFound) {
+		t.Fatalf("ChargeStatus: got %v, want %v", err, ErrChargeNotFound)
+	}
+}
+
+func TestParseFakeMode(t *testing.T) {
+	for _, s := range []string{"approve", "decline", "timeout"} {
+		if mode, err := ParseFakeMode(s); err != nil || string(mode) != s {
+			t.Fatalf("ParseFakeMode(%q): got %q, %v", s, mode, err)
+		}
+	}
+
+	if _, err := ParseFakeMode("unknown"); err == nil {
+		t.Fatal("ParseFakeMode: got nil, want error")
+	}
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+var (
+	ErrDeclined               = errors.New("payment declined")
+	ErrTimeout                = errors.New("payment provider timeout")
+	ErrUnsupportedCurrency    = errors.New("currency is not supported")
+	ErrAmountLimitExceeded    = errors.New("amount exceeds payment method limit")
+	ErrUnsupportedPaymentType = errors.New("payment method is not supported")
+	// ErrChargeNotFound провайдер не получал списание с таким uuid транзакции
+	ErrChargeNotFound = errors.New("charge not found")
+)
+
+// ChargeRequest данные для списания средств
+type ChargeRequest struct {
+	TransactionUuid string
+	OrderUuid       string
+	UserUuid        string
+	// Amount сумма в минимальных единицах валюты
+	Amount   int64
+	Currency string
+}
+
+// ChargeResult результат успешного списания средств
+type ChargeResult struct {
+	// Reference идентификатор операции на стороне провайдера
+	Reference string
+}
+
+// RefundRequest данные для возврата средств по проведенному списанию
+type RefundRequest struct {
+	RefundUuid      string
+	TransactionUuid string
+	// ChargeReference идентификатор списания на стороне провайдера
+	ChargeReference string
+	// Amount сумма в минимальных единицах валюты
+	Amount   int64
+	Currency string
+}
+
+// RefundResult результат успешного возврата средств
+type RefundResult struct {
+	// Reference идентификатор операции на стороне провайдера
+	Reference string
+}
+
+// Provider описывает провайдера, через которого проводится оплата
+type Provider interface {
+	// Name возвращает название провайдера для записи в транзакцию
+	Name() string
+	// Charge списывает средства, при отказе возвращает ErrDeclined. Uuid транзакции служит ключом идемпотентности:
+	// повторное списание той же транзакции возвращает результат первого без повторного списания
+	Charge(ctx context.Context, req ChargeRequest) (ChargeResult, error)
+	// ChargeStatus возвращает результат списания по uuid транзакции: ChargeResult проведенного списания,
+	// ErrDeclined для отклоненного и ErrChargeNotFound, если провайдер не получал списание
+	ChargeStatus(ctx context.Context, transactionUuid string) (ChargeResult, error)
+	// Refund возвращает средства по проведенному списанию, при отказе возвращает ErrDeclined
+	Refund(ctx context.Context, req RefundRequest) (RefundResult, error)
+}
+
+// Registry сопоставляет способы оплаты с провайдерами
+type Registry map[paymentV1.PaymentMethod]Provider
+
+// NewRegistry создает реестр, в котором каждому способу оплаты соответствует свой адаптер
+// поверх переданного шлюза
+func NewRegistry(gateway Provider) Registry {
+	return Registry{
+		paymentV1.PaymentMethod_PAYMENT_METHOD_CARD:           NewCardProvider(gateway),
+		paymentV1.PaymentMethod_PAYMENT_METHOD_SBP:            NewSBPProvider(gateway),
+		paymentV1.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD:    NewCreditCardProvider(gateway),
+		paymentV1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY: NewInvestorMoneyProvider(gateway),
+	}
+}
+
+// Provider возвращает провайдера для способа оплаты
+func (r Registry) Provider(method paymentV1.PaymentMethod) (Provider, error) {
+	p, ok := r[method]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPaymentType, method)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TransactionStorageInMem представляет потокобезопасное хранилище транзакций в памяти
+type TransactionStorageInMem struct {
+	mu           sync.RWMutex
+	transactions map[string]*Transaction
+	refunds      map[string]*Refund
+}
+
+// NewTransactionStorageInMem создает новое хранилище транзакций в памяти
+func NewTransactionStorageInMem() *TransactionStorageInMem {
+	return &TransactionStorageInMem{
+		transactions: make(map[string]*Transaction),
+		refunds:      make(map[string]*Refund),
+	}
+}
+
+// GetTransaction возвращает копию транзакции по uuid
+func (s *TransactionStorageInMem) GetTransaction(_ context.Context, transactionUuid string) (*Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	transaction, ok := s.transactions[transactionUuid]
+	if !ok {
+		return nil, ErrTransactionNotFound
+	}
+
+	c := *transaction
+	return &c, nil
+}
+
+// GetActiveTransactionByOrder возвращает копию ожидающей или успешной транзакции заказа
+func (s *TransactionStorageInMem) GetActiveTransactionByOrder(_ context.Context, orderUuid string) (*Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	transaction := s.activeTransaction(orderUuid)
+	if transaction == nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	c := *transaction
+	return &c, nil
+}
+
+// activeTransaction ищет активную транзакцию заказа, вызывается под блокировкой
+func (s *TransactionStorageInMem) activeTransaction(orderUuid string) *Transaction {
+	for _, transaction := range s.transactions {
+		if transaction.OrderUuid == orderUuid && transaction.Active() {
+			return transaction
+		}
+	}
+
+	return nil
+}
+
+// CreateTransaction сохраняет новую транзакцию
+func (s *TransactionStorageInMem) CreateTransaction(_ context.Context, transaction *Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.transactions[transaction.Uuid]; ok {
+		return ErrTransactionAlreadyExists
+	}
+
+	if transaction.Active() && s.activeTransaction(transaction.OrderUuid) != nil {
+		return ErrActiveTransactionExists
+	}
+
+	c := *transaction
+	s.transactions[transaction.Uuid] = &c
+
+	return nil
+}
+
+// UpdateTransaction обновляет существующую транзакцию
+func (s *TransactionStorageInMem) UpdateTransaction(_ context.Context, transaction *Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.transactions[transaction.Uuid]; !ok {
+		return ErrTransactionNotFound
+	}
+
+	c := *transaction
+	s.transactions[transaction.Uuid] = &c
+
+	return nil
+}
+
+// ListPendingTransactions возвращает копии ожидающих транзакций, не обновлявшихся с updatedBefore
+func (s *TransactionStorageInMem) ListPendingTransactions(_ context.Context, updatedBefore time.Time, limit int) ([]*Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var pending []*Transaction
+	for _, transaction := range s.transactions {
+		if transaction.Status == TransactionStatusPending && transaction.UpdatedAt.Before(updatedBefore) {
+			c := *transaction
+			pending = append(pending, &c)
+		}
+	}
+
+	slices.SortFunc(pending, func(a, b *Transaction) int {
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	})
+
+	return pending[:min(limit, len(pending))], nil
+}
+
+// GetRefund возвращает копию возврата по uuid
+func (s *TransactionStorageInMem) GetRefund(_ context.Context, refundUuid string) (*Refund, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	refund, ok := s.refunds[refundUuid]
+	if !ok {
+		return nil, ErrRefundNotFound
+	}
+
+	c := *refund
+	return &c, nil
+}
+
+// CreateRefund сохраняет новый возврат с проверкой остатка транзакции
+func (s *TransactionStorageInMem) CreateRefund(_ context.Context, refund *Refund) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.refunds[refund.Uuid]; ok {
+		return ErrRefundAlreadyExists
+	}
+
+	transaction, ok := s.transactions[refund.TransactionUuid]
+	if !ok {
+		return ErrTransactionNotFound
+	}
+	if transaction.Status != TransactionStatusSucceeded {
+		return ErrTransactionNotRefundable
+	}
+
+	var held int64
+	for _, r := range s.refunds {
+		if r.TransactionUuid == refund.TransactionUuid && r.Holds() {
+			held += r.Amount
+		}
+	}
+
+	remaining := transaction.Amount - held
+	if refund.Amount == 0 {
+		refund.Amount = remaining
+	}
+	if refund.Amount <= 0 || refund.Amount > remaining {
+		return ErrRefundExceedsPayment
+	}
+
+	c := *refund
+	s.refunds[refund.Uuid] = &c
+
+	return nil
+}
+
+// UpdateRefund обновляет существующий возврат
+func (s *TransactionStorageInMem) UpdateRefund(_ context.Context, refund *Refund) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.refunds[refund.Uuid]; !ok {
+		return ErrRefundNotFound
+	}
+
+	c := *refund
+	s.refunds[refund.Uuid] = &c
+
+	return nil
+}
+
+// RefundedAmount возвращает сумму успешных возвратов по транзакции
+func (s *TransactionStorageInMem) RefundedAmount(_ context.Context, transactionUuid string) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var refunded int64
+	for _, refund := range s.refunds {
+		if refund.TransactionUuid == transactionUuid && refund.Status == RefundStatusSucceeded {
+			refunded += refund.Amount
+		}
+	}
+
+	return refunded, nil
+}
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS transactions
+(
+    transaction_uuid   TEXT PRIMARY KEY,
+    order_uuid         TEXT        NOT NULL,
+    user_uuid          TEXT        NOT NULL,
+    payment_method     TEXT        NOT NULL,
+    amount             BIGINT      NOT NULL CHECK (amount > 0),
+    currency           TEXT        NOT NULL,
+    status             TEXT        NOT NULL,
+    provider           TEXT        NOT NULL,
+    provider_reference TEXT        NOT NULL DEFAULT '',
+    failure_reason     TEXT        NOT NULL DEFAULT '',
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS transactions_order_uuid_idx ON transactions (order_uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS transactions;
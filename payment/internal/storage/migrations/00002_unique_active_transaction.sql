@@ -0,0 +1,9 @@
+-- +goose Up
+-- У заказа может быть только одна ожидающая или успешная транзакция, повторная оплата
+-- возвращает уже проведенную транзакцию вместо нового списания
+CREATE UNIQUE INDEX IF NOT EXISTS transactions_active_order_uuid_idx
+    ON transactions (order_uuid)
+    WHERE status IN ('PENDING', 'SUCCEEDED');
+
+-- +goose Down
+DROP INDEX IF EXISTS transactions_active_order_uuid_idx;
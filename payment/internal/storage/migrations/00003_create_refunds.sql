@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS refunds
+(
+    refund_uuid        TEXT PRIMARY KEY,
+    transaction_uuid   TEXT        NOT NULL REFERENCES transactions (transaction_uuid),
+    amount             BIGINT      NOT NULL CHECK (amount > 0),
+    currency           TEXT        NOT NULL,
+    status             TEXT        NOT NULL,
+    reason             TEXT        NOT NULL DEFAULT '',
+    provider_reference TEXT        NOT NULL DEFAULT '',
+    failure_reason     TEXT        NOT NULL DEFAULT '',
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS refunds_transaction_uuid_idx ON refunds (transaction_uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS refunds;
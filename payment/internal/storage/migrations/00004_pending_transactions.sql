@@ -0,0 +1,8 @@
+-- +goose Up
+-- Ожидающие транзакции периодически сверяются с провайдером, начиная с самых старых
+CREATE INDEX IF NOT EXISTS transactions_pending_updated_at_idx
+    ON transactions (updated_at)
+    WHERE status = 'PENDING';
+
+-- +goose Down
+DROP INDEX IF EXISTS transactions_pending_updated_at_idx;
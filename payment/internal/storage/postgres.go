@@ -0,0 +1,340 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+const (
+	// uniqueViolationCode код ошибки PostgreSQL при нарушении уникальности
+	uniqueViolationCode = "23505"
+	// activeOrderTransactionIndex индекс, допускающий только одну активную транзакцию на заказ
+	activeOrderTransactionIndex = "transactions_active_order_uuid_idx"
+)
+
+// TransactionStoragePostgres представляет хранилище транзакций в PostgreSQL
+type TransactionStoragePostgres struct {
+	pool *pgxpool.Pool
+}
+
+// NewTransactionStoragePostgres создает новое хранилище транзакций в PostgreSQL
+func NewTransactionStoragePostgres(pool *pgxpool.Pool) *TransactionStoragePostgres {
+	return &TransactionStoragePostgres{
+		pool: pool,
+	}
+}
+
+// GetTransaction возвращает транзакцию по uuid
+func (s *TransactionStoragePostgres) GetTransaction(ctx context.Context, transactionUuid string) (*Transaction, error) {
+	return scanTransaction(s.pool.QueryRow(ctx, `
+		SELECT transaction_uuid, order_uuid, user_uuid, payment_method, amount, currency, status,
+		       provider, provider_reference, failure_reason, created_at, updated_at
+		FROM transactions
+		WHERE transaction_uuid = $1`,
+		transactionUuid,
+	))
+}
+
+// GetActiveTransactionByOrder возвращает ожидающую или успешную транзакцию заказа
+func (s *TransactionStoragePostgres) GetActiveTransactionByOrder(ctx context.Context, orderUuid string) (*Transaction, error) {
+	return scanTransaction(s.pool.QueryRow(ctx, `
+		SELECT transaction_uuid, order_uuid, user_uuid, payment_method, amount, currency, status,
+		       provider, provider_reference, failure_reason, created_at, updated_at
+		FROM transactions
+		WHERE order_uuid = $1 AND status IN ($2, $3)`,
+		orderUuid,
+		string(TransactionStatusPending),
+		string(TransactionStatusSucceeded),
+	))
+}
+
+// scanTransaction читает транзакцию из строки результата запроса
+func scanTransaction(row pgx.Row) (*Transaction, error) {
+	var (
+		transaction   Transaction
+		paymentMethod string
+	)
+
+	err := row.Scan(
+		&transaction.Uuid,
+		&transaction.OrderUuid,
+		&transaction.UserUuid,
+		&paymentMethod,
+		&transaction.Amount,
+		&transaction.Currency,
+		&transaction.Status,
+		&transaction.Provider,
+		&transaction.ProviderReference,
+		&transaction.FailureReason,
+		&transaction.CreatedAt,
+		&transaction.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransactionNotFound
+		}
+
+		return nil, fmt.Errorf("select transaction: %w", err)
+	}
+
+	transaction.PaymentMethod = paymentV1.PaymentMethod(paymentV1.PaymentMethod_value[paymentMethod])
+
+	return &transaction, nil
+}
+
+// CreateTransaction сохраняет новую транзакцию
+func (s *TransactionStoragePostgres) CreateTransaction(ctx context.Context, transaction *Transaction) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO transactions (transaction_uuid, order_uuid, user_uuid, payment_method, amount, currency, status,
+		                          provider, provider_reference, failure_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		transaction.Uuid,
+		transaction.OrderUuid,
+		transaction.UserUuid,
+		transaction.PaymentMethod.String(),
+		transaction.Amount,
+		transaction.Currency,
+		string(transaction.Status),
+		transaction.Provider,
+		transaction.ProviderReference,
+		transaction.FailureReason,
+		transaction.CreatedAt,
+		transaction.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			if pgErr.ConstraintName == activeOrderTransactionIndex {
+				return ErrActiveTransactionExists
+			}
+
+			return ErrTransactionAlreadyExists
+		}
+
+		return fmt.Errorf("insert transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTransaction обновляет состояние существующей транзакции
+func (s *TransactionStoragePostgres) UpdateTransaction(ctx context.Context, transaction *Transaction) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE transactions
+		SET status             = $2,
+		    provider_reference = $3,
+		    failure_reason     = $4,
+		    updated_at         = $5
+		WHERE transaction_uuid = $1`,
+		transaction.Uuid,
+		string(transaction.Status),
+		transaction.ProviderReference,
+		transaction.FailureReason,
+		transaction.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update transaction: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrTransactionNotFound
+	}
+
+	return nil
+}
+
+// ListPendingTransactions возвращает ожидающие транзакции, не обновлявшиеся с updatedBefore
+func (s *TransactionStoragePostgres) ListPendingTransactions(ctx context.Context, updatedBefore time.Time, limit int) ([]*Transaction, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT transaction_uuid, order_uuid, user_uuid, payment_method, amount, currency, status,
+		       provider, provider_reference, failure_reason, created_at, updated_at
+		FROM transactions
+		WHERE status = $1 AND updated_at < $2
+		ORDER BY updated_at
+		LIMIT $3`,
+		string(TransactionStatusPending),
+		updatedBefore,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select pending transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []*Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		pending = append(pending, transaction)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("select pending transactions: %w", err)
+	}
+
+	return pending, nil
+}
+
+// GetRefund возвращает возврат по uuid
+func (s *TransactionStoragePostgres) GetRefund(ctx context.Context, refundUuid string) (*Refund, error) {
+	var refund Refund
+
+	err := s.pool.QueryRow(ctx, `
+		SELECT refund_uuid, transaction_uuid, amount, currency, status, reason,
+		       provider_reference, failure_reason, created_at, updated_at
+		FROM refunds
+		WHERE refund_uuid = $1`,
+		refundUuid,
+	).Scan(
+		&refund.Uuid,
+		&refund.TransactionUuid,
+		&refund.Amount,
+		&refund.Currency,
+		&refund.Status,
+		&refund.Reason,
+		&refund.ProviderReference,
+		&refund.FailureReason,
+		&refund.CreatedAt,
+		&refund.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRefundNotFound
+		}
+
+		return nil, fmt.Errorf("select refund: %w", err)
+	}
+
+	return &refund, nil
+}
+
+// CreateRefund сохраняет новый возврат, строка транзакции блокируется до конца записи,
+// чтобы параллельные возвраты не превысили сумму транзакции
+func (s *TransactionStoragePostgres) CreateRefund(ctx context.Context, refund *Refund) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		var (
+			amount int64
+			status TransactionStatus
+		)
+
+		err := tx.QueryRow(ctx, `
+			SELECT amount, status
+			FROM transactions
+			WHERE transaction_uuid = $1
+			FOR UPDATE`,
+			refund.TransactionUuid,
+		).Scan(&amount, &status)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrTransactionNotFound
+			}
+
+			return fmt.Errorf("lock transaction: %w", err)
+		}
+
+		if status != TransactionStatusSucceeded {
+			return ErrTransactionNotRefundable
+		}
+
+		var held int64
+		err = tx.QueryRow(ctx, `
+			SELECT COALESCE(SUM(amount), 0)
+			FROM refunds
+			WHERE transaction_uuid = $1 AND status IN ($2, $3)`,
+			refund.TransactionUuid,
+			string(RefundStatusPending),
+			string(RefundStatusSucceeded),
+		).Scan(&held)
+		if err != nil {
+			return fmt.Errorf("select refunded amount: %w", err)
+		}
+
+		remaining := amount - held
+		if refund.Amount == 0 {
+			refund.Amount = remaining
+		}
+		if refund.Amount <= 0 || refund.Amount > remaining {
+			return ErrRefundExceedsPayment
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO refunds (refund_uuid, transaction_uuid, amount, currency, status, reason,
+			                     provider_reference, failure_reason, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			refund.Uuid,
+			refund.TransactionUuid,
+			refund.Amount,
+			refund.Currency,
+			string(refund.Status),
+			refund.Reason,
+			refund.ProviderReference,
+			refund.FailureReason,
+			refund.CreatedAt,
+			refund.UpdatedAt,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+				return ErrRefundAlreadyExists
+			}
+
+			return fmt.Errorf("insert refund: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UpdateRefund обновляет состояние существующего возврата
+func (s *TransactionStoragePostgres) UpdateRefund(ctx context.Context, refund *Refund) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE refunds
+		SET status             = $2,
+		    provider_reference = $3,
+		    failure_reason     = $4,
+		    updated_at         = $5
+		WHERE refund_uuid = $1`,
+		refund.Uuid,
+		string(refund.Status),
+		refund.ProviderReference,
+		refund.FailureReason,
+		refund.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update refund: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrRefundNotFound
+	}
+
+	return nil
+}
+
+// RefundedAmount возвращает сумму успешных возвратов по транзакции
+func (s *TransactionStoragePostgres) RefundedAmount(ctx context.Context, transactionUuid string) (int64, error) {
+	var refunded int64
+
+	err := s.pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM refunds
+		WHERE transaction_uuid = $1 AND status = $2`,
+		transactionUuid,
+		string(RefundStatusSucceeded),
+	).Scan(&refunded)
+	if err != nil {
+		return 0, fmt.Errorf("select refunded amount: %w", err)
+	}
+
+	return refunded, nil
+}
@@ -0,0 +1,317 @@
+//go:build integration
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+// testPostgresDSNEnv переменная окружения с адресом временной базы данных для интеграционных тестов
+const testPostgresDSNEnv = "PAYMENT_TEST_POSTGRES_DSN"
+
+// newTestPool создает пул соединений к отдельной схеме временной базы данных
+// и применяет к ней миграции, схема удаляется по завершении теста
+func newTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv(testPostgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testPostgresDSNEnv)
+	}
+
+	ctx := context.Background()
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	admin, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect to postgres: %v", err)
+	}
+	if _, err = admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("drop schema: %v", err)
+		}
+		_ = admin.Close(context.Background())
+	})
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("parse dsn: %v", err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	if err = Migrate(ctx, pool); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return pool
+}
+
+func TestTransactionStoragePostgres(t *testing.T) {
+	ctx := context.Background()
+	s := NewTransactionStoragePostgres(newTestPool(t))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	transaction := &Transaction{
+		Uuid:          uuid.NewString(),
+		OrderUuid:     uuid.NewString(),
+		UserUuid:      uuid.NewString(),
+		PaymentMethod: paymentV1.PaymentMethod_PAYMENT_METHOD_SBP,
+		Amount:        135134,
+		Currency:      "RUB",
+		Status:        TransactionStatusPending,
+		Provider:      "sbp/fake",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.CreateTransaction(ctx, transaction); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	if err := s.CreateTransaction(ctx, transaction); !errors.Is(err, ErrTransactionAlreadyExists) {
+		t.Fatalf("CreateTransaction duplicate: got %v, want %v", err, ErrTransactionAlreadyExists)
+	}
+
+	transaction.Status = TransactionStatusSucceeded
+	transaction.ProviderReference = "fake-reference"
+	transaction.UpdatedAt = now.Add(time.Second)
+
+	if err := s.UpdateTransaction(ctx, transaction); err != nil {
+		t.Fatalf("UpdateTransaction: %v", err)
+	}
+
+	got, err := s.GetTransaction(ctx, transaction.Uuid)
+	if err != nil {
+		t.Fatalf("GetTransaction: %v", err)
+	}
+	if got.PaymentMethod != transaction.PaymentMethod ||
+		got.Status != transaction.Status ||
+		got.ProviderReference != transaction.ProviderReference ||
+		got.Amount != transaction.Amount ||
+		!got.UpdatedAt.Equal(transaction.UpdatedAt) {
+		t.Fatalf("GetTransaction: got %+v, want %+v", got, transaction)
+	}
+}
+
+func TestTransactionStoragePostgresNotFound(t *testing.T) {
+	ctx := context.Background()
+	s := NewTransactionStoragePostgres(newTestPool(t))
+
+	if _, err := s.GetTransaction(ctx, uuid.NewString()); !errors.Is(err, ErrTransactionNotFound) {
+		t.Fatalf("GetTransaction: got %v, want %v", err, ErrTransactionNotFound)
+	}
+
+	err := s.UpdateTransaction(ctx, &Transaction{Uuid: uuid.NewString(), Status: TransactionStatusFailed})
+	if !errors.Is(err, ErrTransactionNotFound) {
+		t.Fatalf("UpdateTransaction: got %v, want %v", err, ErrTransactionNotFound)
+	}
+}
+
+func TestTransactionStoragePostgresActiveTransaction(t *testing.T) {
+	ctx := context.Background()
+	s := NewTransactionStoragePostgres(newTestPool(t))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	declined := &Transaction{
+		Uuid:          uuid.NewString(),
+		OrderUuid:     uuid.NewString(),
+		UserUuid:      uuid.NewString(),
+		PaymentMethod: paymentV1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:        5000,
+		Currency:      "RUB",
+		Status:        TransactionStatusDeclined,
+		Provider:      "card/fake",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.CreateTransaction(ctx, declined); err != nil {
+		t.Fatalf("CreateTransaction declined: %v", err)
+	}
+
+	if _, err := s.GetActiveTransactionByOrder(ctx, declined.OrderUuid); !errors.Is(err, ErrTransactionNotFound) {
+		t.Fatalf("GetActiveTransactionByOrder: got %v, want %v", err, ErrTransactionNotFound)
+	}
+
+	succeeded := *declined
+	succeeded.Uuid = uuid.NewString()
+	succeeded.Status = TransactionStatusSucceeded
+	if err := s.CreateTransaction(ctx, &succeeded); err != nil {
+		t.Fatalf("CreateTransaction succeeded: %v", err)
+	}
+
+	pending := *declined
+	pending.Uuid = uuid.NewString()
+	pending.Status = TransactionStatusPending
+	if err := s.CreateTransaction(ctx, &pending); !errors.Is(err, ErrActiveTransactionExists) {
+		t.Fatalf("CreateTransaction second active: got %v, want %v", err, ErrActiveTransactionExists)
+	}
+
+	got, err := s.GetActiveTransactionByOrder(ctx, declined.OrderUuid)
+	if err != nil {
+		t.Fatalf("GetActiveTransactionByOrder: %v", err)
+	}
+	if got.Uuid != succeeded.Uuid {
+		t.Fatalf("GetActiveTransactionByOrder: got %s, want %s", got.Uuid, succeeded.Uuid)
+	}
+}
+
+func TestTransactionStoragePostgresListPending(t *testing.T) {
+	ctx := context.Background()
+	s := NewTransactionStoragePostgres(newTestPool(t))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	newTransaction := func(status TransactionStatus, updatedAt time.Time) *Transaction {
+		transaction := &Transaction{
+			Uuid:          uuid.NewString(),
+			OrderUuid:     uuid.NewString(),
+			UserUuid:      uuid.NewString(),
+			PaymentMethod: paymentV1.PaymentMethod_PAYMENT_METHOD_CARD,
+			Amount:        5000,
+			Currency:      "RUB",
+			Status:        status,
+			Provider:      "card/fake",
+			CreatedAt:     updatedAt,
+			UpdatedAt:     updatedAt,
+		}
+		if err := s.CreateTransaction(ctx, transaction); err != nil {
+			t.Fatalf("CreateTransaction: %v", err)
+		}
+
+		return transaction
+	}
+
+	older := newTransaction(TransactionStatusPending, now.Add(-2*time.Hour))
+	old := newTransaction(TransactionStatusPending, now.Add(-time.Hour))
+	newTransaction(TransactionStatusPending, now)
+	newTransaction(TransactionStatusSucceeded, now.Add(-time.Hour))
+
+	pending, err := s.ListPendingTransactions(ctx, now.Add(-time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ListPendingTransactions: %v", err)
+	}
+	if len(pending) != 2 || pending[0].Uuid != older.Uuid || pending[1].Uuid != old.Uuid {
+		t.Fatalf("ListPendingTransactions: got %d transactions, want [%s %s]", len(pending), older.Uuid, old.Uuid)
+	}
+
+	pending, err = s.ListPendingTransactions(ctx, now.Add(-time.Minute), 1)
+	if err != nil {
+		t.Fatalf("ListPendingTransactions with limit: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Uuid != older.Uuid {
+		t.Fatalf("ListPendingTransactions with limit: got %d transactions, want [%s]", len(pending), older.Uuid)
+	}
+}
+
+func TestRefundStoragePostgres(t *testing.T) {
+	ctx := context.Background()
+	s := NewTransactionStoragePostgres(newTestPool(t))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	transaction := &Transaction{
+		Uuid:          uuid.NewString(),
+		OrderUuid:     uuid.NewString(),
+		UserUuid:      uuid.NewString(),
+		PaymentMethod: paymentV1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:        10000,
+		Currency:      "RUB",
+		Status:        TransactionStatusPending,
+		Provider:      "card/fake",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.CreateTransaction(ctx, transaction); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	partial := &Refund{
+		Uuid:            uuid.NewString(),
+		TransactionUuid: transaction.Uuid,
+		Amount:          3000,
+		Currency:        "RUB",
+		Status:          RefundStatusPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.CreateRefund(ctx, partial); !errors.Is(err, ErrTransactionNotRefundable) {
+		t.Fatalf("CreateRefund pending transaction: got %v, want %v", err, ErrTransactionNotRefundable)
+	}
+
+	transaction.Status = TransactionStatusSucceeded
+	if err := s.UpdateTransaction(ctx, transaction); err != nil {
+		t.Fatalf("UpdateTransaction: %v", err)
+	}
+
+	if err := s.CreateRefund(ctx, partial); err != nil {
+		t.Fatalf("CreateRefund: %v", err)
+	}
+	if err := s.CreateRefund(ctx, partial); !errors.Is(err, ErrRefundAlreadyExists) {
+		t.Fatalf("CreateRefund duplicate: got %v, want %v", err, ErrRefundAlreadyExists)
+	}
+
+	excess := *partial
+	excess.Uuid = uuid.NewString()
+	excess.Amount = 7001
+	if err := s.CreateRefund(ctx, &excess); !errors.Is(err, ErrRefundExceedsPayment) {
+		t.Fatalf("CreateRefund excess: got %v, want %v", err, ErrRefundExceedsPayment)
+	}
+
+	partial.Status = RefundStatusSucceeded
+	partial.ProviderReference = "fake-refund"
+	partial.UpdatedAt = now.Add(time.Second)
+	if err := s.UpdateRefund(ctx, partial); err != nil {
+		t.Fatalf("UpdateRefund: %v", err)
+	}
+
+	rest := *partial
+	rest.Uuid = uuid.NewString()
+	rest.Amount = 0
+	rest.Status = RefundStatusPending
+	if err := s.CreateRefund(ctx, &rest); err != nil {
+		t.Fatalf("CreateRefund rest: %v", err)
+	}
+	if rest.Amount != 7000 {
+		t.Fatalf("CreateRefund rest: got amount %d, want %d", rest.Amount, 7000)
+	}
+
+	refunded, err := s.RefundedAmount(ctx, transaction.Uuid)
+	if err != nil {
+		t.Fatalf("RefundedAmount: %v", err)
+	}
+	if refunded != partial.Amount {
+		t.Fatalf("RefundedAmount: got %d, want %d", refunded, partial.Amount)
+	}
+
+	got, err := s.GetRefund(ctx, partial.Uuid)
+	if err != nil {
+		t.Fatalf("GetRefund: %v", err)
+	}
+	if got.Status != partial.Status ||
+		got.ProviderReference != partial.ProviderReference ||
+		!got.UpdatedAt.Equal(partial.UpdatedAt) {
+		t.Fatalf("GetRefund: got %+v, want %+v", got, partial)
+	}
+
+	if _, err = s.GetRefund(ctx, uuid.NewString()); !errors.Is(err, ErrRefundNotFound) {
+		t.Fatalf("GetRefund: got %v, want %v", err, ErrRefundNotFound)
+	}
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrRefundNotFound      = errors.New("refund not found")
+	ErrRefundAlreadyExists = errors.New("refund already exists")
+	// ErrRefundExceedsPayment сумма возвратов по транзакции превысила бы сумму транзакции
+	ErrRefundExceedsPayment = errors.New("refund amount exceeds refundable amount of transaction")
+	// ErrTransactionNotRefundable возврат возможен только по успешной транзакции
+	ErrTransactionNotRefundable = errors.New("transaction is not succeeded")
+)
+
+// RefundStatus состояние возврата средств
+type RefundStatus string
+
+const (
+	// RefundStatusPending возврат отправлен провайдеру и ожидает ответа
+	RefundStatusPending RefundStatus = "PENDING"
+	// RefundStatusSucceeded средства возвращены
+	RefundStatusSucceeded RefundStatus = "SUCCEEDED"
+	// RefundStatusDeclined провайдер отказал в возврате
+	RefundStatusDeclined RefundStatus = "DECLINED"
+	// RefundStatusFailed возврат не удалось провести из-за ошибки или таймаута провайдера
+	RefundStatusFailed RefundStatus = "FAILED"
+)
+
+// Refund возврат средств по платежной транзакции
+type Refund struct {
+	Uuid            string
+	TransactionUuid string
+	// Amount сумма в минимальных единицах валюты транзакции
+	Amount   int64
+	Currency string
+	Status   RefundStatus
+	Reason   string
+	// ProviderReference идентификатор операции на стороне провайдера
+	ProviderReference string
+	// FailureReason причина отказа или ошибки
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Holds проверяет, что возврат ожидает ответа провайдера или уже проведен,
+// такие возвраты уменьшают сумму, доступную для возврата
+func (r *Refund) Holds() bool {
+	return r.Status == RefundStatusPending || r.Status == RefundStatusSucceeded
+}
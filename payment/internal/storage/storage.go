@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	paymentV1 "github.com/Igorezka/rocket-factory/shared/pkg/proto/payment/v1"
+)
+
+var (
+	ErrTransactionNotFound      = errors.New("transaction not found")
+	ErrTransactionAlreadyExists = errors.New("transaction already exists")
+	// ErrActiveTransactionExists у заказа уже есть ожидающая или успешная транзакция
+	ErrActiveTransactionExists = errors.New("order already has pending or succeeded transaction")
+)
+
+// TransactionStatus состояние платежной транзакции
+type TransactionStatus string
+
+const (
+	// TransactionStatusPending списание отправлено провайдеру и ожидает ответа или его результат неизвестен
+	TransactionStatusPending TransactionStatus = "PENDING"
+	// TransactionStatusSucceeded средства списаны
+	TransactionStatusSucceeded TransactionStatus = "SUCCEEDED"
+	// TransactionStatusDeclined провайдер отказал в списании
+	TransactionStatusDeclined TransactionStatus = "DECLINED"
+	// TransactionStatusFailed провайдер не получал списание, заказ можно оплатить новой транзакцией
+	TransactionStatusFailed TransactionStatus = "FAILED"
+)
+
+// Transaction платежная транзакция
+type Transaction struct {
+	Uuid          string
+	OrderUuid     string
+	UserUuid      string
+	PaymentMethod paymentV1.PaymentMethod
+	// Amount сумма в минимальных единицах валюты
+	Amount   int64
+	Currency string
+	Status   TransactionStatus
+	// Provider провайдер, через которого проводилось списание
+	Provider string
+	// ProviderReference идентификатор операции на стороне провайдера
+	ProviderReference string
+	// FailureReason причина отказа или ошибки
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Active проверяет, что транзакция ожидает ответа провайдера или уже успешно проведена.
+// У заказа может быть не больше одной активной транзакции
+func (t *Transaction) Active() bool {
+	return t.Status == TransactionStatusPending || t.Status == TransactionStatusSucceeded
+}
+
+// TransactionStorage описывает хранилище платежных транзакций
+type TransactionStorage interface {
+	GetTransaction(ctx context.Context, transactionUuid string) (*Transaction, error)
+	// GetActiveTransactionByOrder возвращает ожидающую или успешную транзакцию заказа
+	GetActiveTransactionByOrder(ctx context.Context, orderUuid string) (*Transaction, error)
+	// CreateTransaction сохраняет новую транзакцию, для активной транзакции возвращает
+	// ErrActiveTransactionExists, если у заказа уже есть другая активная транзакция
+	CreateTransaction(ctx context.Context, transaction *Transaction) error
+	UpdateTransaction(ctx context.Context, transaction *Transaction) error
+	// ListPendingTransactions возвращает до limit ожидающих транзакций, не обновлявшихся с updatedBefore,
+	// начиная с самых старых
+	ListPendingTransactions(ctx context.Context, updatedBefore time.Time, limit int) ([]*Transaction, error)
+
+	GetRefund(ctx context.Context, refundUuid string) (*Refund, error)
+	// CreateRefund сохраняет новый возврат по успешной транзакции. Если сумма возврата не задана,
+	// возвращается весь остаток. Сумма ожидающих и успешных возвратов с учетом нового не может превышать
+	// сумму транзакции, иначе возвращается ErrRefundExceedsPayment. Проверка и запись выполняются атомарно
+	CreateRefund(ctx context.Context, refund *Refund) error
+	UpdateRefund(ctx context.Context, refund *Refund) error
+	// RefundedAmount возвращает сумму успешных возвратов по транзакции
+	RefundedAmount(ctx context.Context, transactionUuid string) (int64, error)
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Igorezka/rocket-factory/shared/pkg/tracing"
+)
+
+var tracer = otel.Tracer("github.com/Igorezka/rocket-factory/payment/internal/storage")
+
+// tracingTransactionStorage создает span для каждого обращения к хранилищу в рамках трассируемого запроса
+type tracingTransactionStorage struct {
+	next TransactionStorage
+}
+
+// WithTracing оборачивает хранилище транзакций и возвратов трассировкой обращений
+func WithTracing(next TransactionStorage) TransactionStorage {
+	return &tracingTransactionStorage{next: next}
+}
+
+func (s *tracingTransactionStorage) GetTransaction(ctx context.Context, transactionUuid string) (*Transaction, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "TransactionStorage.GetTransaction")
+	transaction, err := s.next.GetTransaction(ctx, transactionUuid)
+	endSpan(span, err)
+
+	return transaction, err
+}
+
+func (s *tracingTransactionStorage) GetActiveTransactionByOrder(ctx context.Context, orderUuid string) (*Transaction, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "TransactionStorage.GetActiveTransactionByOrder")
+	transaction, err := s.next.GetActiveTransactionByOrder(ctx, orderUuid)
+	endSpan(span, err)
+
+	return transaction, err
+}
+
+func (s *tracingTransactionStorage) CreateTransaction(ctx context.Context, transaction *Transaction) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "TransactionStorage.CreateTransaction")
+	err := s.next.CreateTransaction(ctx, transaction)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingTransactionStorage) UpdateTransaction(ctx context.Context, transaction *Transaction) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "TransactionStorage.UpdateTransaction")
+	err := s.next.UpdateTransaction(ctx, transaction)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingTransactionStorage) ListPendingTransactions(ctx context.Context, updatedBefore time.Time, limit int) ([]*Transaction, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "TransactionStorage.ListPendingTransactions")
+	transactions, err := s.next.ListPendingTransactions(ctx, updatedBefore, limit)
+	endSpan(span, err)
+
+	return transactions, err
+}
+
+func (s *tracingTransactionStorage) GetRefund(ctx context.Context, refundUuid string) (*Refund, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "TransactionStorage.GetRefund")
+	refund, err := s.next.GetRefund(ctx, refundUuid)
+	endSpan(span, err)
+
+	return refund, err
+}
+
+func (s *tracingTransactionStorage) CreateRefund(ctx context.Context, refund *Refund) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "TransactionStorage.CreateRefund")
+	err := s.next.CreateRefund(ctx, refund)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingTransactionStorage) UpdateRefund(ctx context.Context, refund *Refund) error {
+	ctx, span := tracing.StartChild(ctx, tracer, "TransactionStorage.UpdateRefund")
+	err := s.next.UpdateRefund(ctx, refund)
+	endSpan(span, err)
+
+	return err
+}
+
+func (s *tracingTransactionStorage) RefundedAmount(ctx context.Context, transactionUuid string) (int64, error) {
+	ctx, span := tracing.StartChild(ctx, tracer, "TransactionStorage.RefundedAmount")
+	amount, err := s.next.RefundedAmount(ctx, transactionUuid)
+	endSpan(span, err)
+
+	return amount, err
+}
+
+// endSpan завершает span обращения к хранилищу. Отсутствие записи — штатный результат обращения,
+// поэтому ошибкой в трассе не отмечается
+func endSpan(span trace.Span, err error) {
+	if errors.Is(err, ErrTransactionNotFound) ||
+		errors.Is(err, ErrRefundNotFound) {
+		err = nil
+	}
+
+	tracing.End(span, err)
+}
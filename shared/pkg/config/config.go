@@ -0,0 +1,258 @@
+// Package config загружает конфигурацию сервисов из значений по умолчанию, необязательного YAML файла
+// и переменных окружения, проверяет ее и выводит итоговые значения.
+//
+// Конфигурация описывается структурой с тегами полей:
+//
+//   - yaml — ключ поля в YAML файле;
+//   - env — часть имени переменной окружения. Имя переменной составляется из префикса сервиса
+//     и тегов env всех вложенных структур через "_", структура без тега env не добавляет часть имени;
+//   - secret:"true" — значение скрывается при выводе конфигурации.
+//
+// Значения применяются по возрастанию приоритета: значения по умолчанию, заданные в структуре до загрузки,
+// YAML файл, путь к которому задан переменной <PREFIX>_CONFIG_FILE, и переменные окружения
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFileEnv суффикс переменной окружения с путем к YAML файлу конфигурации
+const configFileEnv = "CONFIG_FILE"
+
+// Validator проверяет значения раздела конфигурации
+type Validator interface {
+	Validate() error
+}
+
+// durationType тип time.Duration, значения которого задаются строкой вида "1m30s"
+var durationType = reflect.TypeFor[time.Duration]()
+
+// field поле конфигурации, значение которого задается напрямую
+type field struct {
+	// path путь к полю в YAML файле через точку
+	path  string
+	env   string
+	value reflect.Value
+	// secret значение поля не выводится
+	secret bool
+}
+
+// Load заполняет cfg, указатель на структуру конфигурации, значениями из YAML файла и переменных окружения
+// с префиксом prefix и проверяет все разделы конфигурации, реализующие Validator.
+// Поля, которых нет ни в файле, ни в окружении, сохраняют значения по умолчанию
+func Load(prefix string, cfg any) error {
+	root := reflect.ValueOf(cfg)
+	if root.Kind() != reflect.Pointer || root.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a pointer to struct, got %T", cfg)
+	}
+
+	fileEnv := envName(prefix, configFileEnv)
+	if path := os.Getenv(fileEnv); path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return fmt.Errorf("%s: %w", fileEnv, err)
+		}
+	}
+
+	for _, f := range fields(prefix, root.Elem()) {
+		value, ok := os.LookupEnv(f.env)
+		if !ok || f.env == "" {
+			continue
+		}
+
+		if err := setValue(f.value, value); err != nil {
+			return fmt.Errorf("parse %s: %w", f.env, err)
+		}
+	}
+
+	return validate("", root)
+}
+
+// loadFile читает YAML файл конфигурации, неизвестные ключи считаются ошибкой
+func loadFile(path string, cfg any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err = decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// fields возвращает поля структуры v и вложенных структур в порядке объявления
+func fields(prefix string, v reflect.Value) []field {
+	return appendFields(nil, "", prefix, v)
+}
+
+func appendFields(result []field, path, env string, v reflect.Value) []field {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := yamlName(sf)
+		if name == "-" {
+			continue
+		}
+
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		fieldEnv := env
+		if tag := sf.Tag.Get("env"); tag != "" {
+			fieldEnv = envName(env, tag)
+		}
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct {
+			result = appendFields(result, fieldPath, fieldEnv, fv)
+			continue
+		}
+
+		if sf.Tag.Get("env") == "" {
+			fieldEnv = ""
+		}
+
+		result = append(result, field{
+			path:   fieldPath,
+			env:    fieldEnv,
+			value:  fv,
+			secret: sf.Tag.Get("secret") == "true",
+		})
+	}
+
+	return result
+}
+
+// yamlName возвращает ключ поля в YAML файле
+func yamlName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(sf.Name)
+	}
+
+	return name
+}
+
+// envName соединяет части имени переменной окружения
+func envName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "_" + name
+}
+
+// setValue записывает в поле значение переменной окружения
+func setValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items).Convert(v.Type()))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// validate проверяет разделы конфигурации, начиная с вложенных, и добавляет к ошибкам путь раздела
+func validate(path string, v reflect.Value) error {
+	s := v
+	if s.Kind() == reflect.Pointer {
+		s = s.Elem()
+	}
+
+	var errs []error
+	t := s.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() || s.Field(i).Kind() != reflect.Struct {
+			continue
+		}
+
+		fieldPath := yamlName(sf)
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+
+		if err := validate(fieldPath, s.Field(i).Addr()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if validator, ok := v.Interface().(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			if path != "" {
+				err = fmt.Errorf("%s: %w", path, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,398 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testPrefix = "CONFIGTEST"
+
+var (
+	errPortRequired = errors.New("port must be positive")
+	errHostRequired = errors.New("host is required")
+)
+
+type testConfig struct {
+	Name    string        `yaml:"name"`
+	Port    int           `yaml:"port" env:"PORT"`
+	Debug   bool          `yaml:"debug" env:"DEBUG"`
+	Timeout time.Duration `yaml:"timeout" env:"TIMEOUT"`
+	Origins []string      `yaml:"origins" env:"ORIGINS"`
+	DB      testDB        `yaml:"db" env:"DB"`
+	Limits  testLimits    `yaml:"limits"`
+}
+
+func (c *testConfig) Validate() error {
+	if c.Port <= 0 {
+		return errPortRequired
+	}
+
+	return nil
+}
+
+type testDB struct {
+	Host     string `yaml:"host" env:"HOST"`
+	Password string `yaml:"password" env:"PASSWORD" secret:"true"`
+}
+
+func (c *testDB) Validate() error {
+	if c.Host == "" {
+		return errHostRequired
+	}
+
+	return nil
+}
+
+type testLimits struct {
+	MaxItems uint `yaml:"max_items" env:"MAX_ITEMS"`
+}
+
+// defaultTestConfig возвращает конфигурацию со значениями по умолчанию
+func defaultTestConfig() *testConfig {
+	return &testConfig{
+		Name:    "default",
+		Port:    8080,
+		Timeout: 5 * time.Second,
+		Origins: []string{"*"},
+		DB:      testDB{Host: "localhost"},
+	}
+}
+
+// writeConfigFile записывает YAML файл конфигурации и задает путь к нему в окружении
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+
+	t.Setenv(testPrefix+"_CONFIG_FILE", path)
+}
+
+// setEnv задает переменные окружения на время теста
+func setEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		env      map[string]string
+		wantPort int
+		wantHost string
+		wantName string
+	}{
+		{
+			name:     "defaults",
+			wantPort: 8080,
+			wantHost: "localhost",
+			wantName: "default",
+		},
+		{
+			name:     "yaml overrides defaults",
+			file:     "port: 9090\nname: from-file\ndb:\n  host: db.local\n",
+			wantPort: 9090,
+			wantHost: "db.local",
+			wantName: "from-file",
+		},
+		{
+			name:     "env overrides yaml",
+			file:     "port: 9090\ndb:\n  host: db.local\n",
+			env:      map[string]string{"CONFIGTEST_PORT": "7070", "CONFIGTEST_DB_HOST": "db.env"},
+			wantPort: 7070,
+			wantHost: "db.env",
+			wantName: "default",
+		},
+		{
+			name:     "empty yaml keeps defaults",
+			file:     "# no overrides\n",
+			env:      map[string]string{"CONFIGTEST_PORT": "7070"},
+			wantPort: 7070,
+			wantHost: "localhost",
+			wantName: "default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.file != "" {
+				writeConfigFile(t, tt.file)
+			}
+			setEnv(t, tt.env)
+
+			cfg := defaultTestConfig()
+			if err := Load(testPrefix, cfg); err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			if cfg.Port != tt.wantPort || cfg.DB.Host != tt.wantHost || cfg.Name != tt.wantName {
+				t.Fatalf("config: got port %d host %q name %q, want port %d host %q name %q",
+					cfg.Port, cfg.DB.Host, cfg.Name, tt.wantPort, tt.wantHost, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestLoadEnvNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   string
+		value string
+		got   func(cfg *testConfig) string
+		want  string
+	}{
+		{
+			name:  "top level field",
+			env:   "CONFIGTEST_DEBUG",
+			value: "true",
+			got:   func(cfg *testConfig) string { return fmt.Sprint(cfg.Debug) },
+			want:  "true",
+		},
+		{
+			name:  "nested struct with env tag adds its name",
+			env:   "CONFIGTEST_DB_PASSWORD",
+			value: "secret",
+			got:   func(cfg *testConfig) string { return cfg.DB.Password },
+			want:  "secret",
+		},
+		{
+			name:  "nested struct without env tag adds no name",
+			env:   "CONFIGTEST_MAX_ITEMS",
+			value: "42",
+			got:   func(cfg *testConfig) string { return fmt.Sprint(cfg.Limits.MaxItems) },
+			want:  "42",
+		},
+		{
+			name:  "field without env tag is not read from env",
+			env:   "CONFIGTEST_NAME",
+			value: "from-env",
+			got:   func(cfg *testConfig) string { return cfg.Name },
+			want:  "default",
+		},
+		{
+			name:  "nested field name without struct prefix is not read",
+			env:   "CONFIGTEST_HOST",
+			value: "db.env",
+			got:   func(cfg *testConfig) string { return cfg.DB.Host },
+			want:  "localhost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+
+			cfg := defaultTestConfig()
+			if err := Load(testPrefix, cfg); err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			if got := tt.got(cfg); got != tt.want {
+				t.Fatalf("%s=%s: got %q, want %q", tt.env, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadParsing(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		env         map[string]string
+		wantTimeout time.Duration
+		wantOrigins []string
+		wantErr     string
+	}{
+		{
+			name:        "env duration",
+			env:         map[string]string{"CONFIGTEST_TIMEOUT": "1m30s"},
+			wantTimeout: 90 * time.Second,
+			wantOrigins: []string{"*"},
+		},
+		{
+			name:        "yaml duration",
+			file:        "timeout: 250ms\n",
+			wantTimeout: 250 * time.Millisecond,
+			wantOrigins: []string{"*"},
+		},
+		{
+			name:        "env slice trims and skips empty items",
+			env:         map[string]string{"CONFIGTEST_ORIGINS": " https://a.example, ,https://b.example "},
+			wantTimeout: 5 * time.Second,
+			wantOrigins: []string{"https://a.example", "https://b.example"},
+		},
+		{
+			name:        "yaml slice",
+			file:        "origins:\n  - https://a.example\n  - https://b.example\n",
+			wantTimeout: 5 * time.Second,
+			wantOrigins: []string{"https://a.example", "https://b.example"},
+		},
+		{
+			name:    "invalid duration",
+			env:     map[string]string{"CONFIGTEST_TIMEOUT": "5"},
+			wantErr: "parse CONFIGTEST_TIMEOUT",
+		},
+		{
+			name:    "invalid int",
+			env:     map[string]string{"CONFIGTEST_PORT": "http"},
+			wantErr: "parse CONFIGTEST_PORT",
+		},
+		{
+			name:    "negative uint",
+			env:     map[string]string{"CONFIGTEST_MAX_ITEMS": "-1"},
+			wantErr: "parse CONFIGTEST_MAX_ITEMS",
+		},
+		{
+			name:    "invalid bool",
+			env:     map[string]string{"CONFIGTEST_DEBUG": "maybe"},
+			wantErr: "parse CONFIGTEST_DEBUG",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.file != "" {
+				writeConfigFile(t, tt.file)
+			}
+			setEnv(t, tt.env)
+
+			cfg := defaultTestConfig()
+			err := Load(testPrefix, cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load: got %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			if cfg.Timeout != tt.wantTimeout {
+				t.Fatalf("timeout: got %s, want %s", cfg.Timeout, tt.wantTimeout)
+			}
+			if !slices.Equal(cfg.Origins, tt.wantOrigins) {
+				t.Fatalf("origins: got %q, want %q", cfg.Origins, tt.wantOrigins)
+			}
+		})
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		path    string
+		wantErr string
+	}{
+		{
+			name:    "unknown top level key",
+			file:    "port: 9090\nprot: 9091\n",
+			wantErr: "field prot not found",
+		},
+		{
+			name:    "unknown nested key",
+			file:    "db:\n  hots: db.local\n",
+			wantErr: "field hots not found",
+		},
+		{
+			name:    "invalid yaml",
+			file:    "port: [\n",
+			wantErr: "parse config file",
+		},
+		{
+			name:    "missing file",
+			path:    filepath.Join(os.TempDir(), "missing-config-test.yaml"),
+			wantErr: "CONFIGTEST_CONFIG_FILE: read config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.path != "" {
+				t.Setenv(testPrefix+"_CONFIG_FILE", tt.path)
+			} else {
+				writeConfigFile(t, tt.file)
+			}
+
+			err := Load(testPrefix, defaultTestConfig())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load: got %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		wantErrs []error
+		wantMsg  string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name:     "top level section",
+			env:      map[string]string{"CONFIGTEST_PORT": "0"},
+			wantErrs: []error{errPortRequired},
+			wantMsg:  "port must be positive",
+		},
+		{
+			name:     "nested section error has its path",
+			env:      map[string]string{"CONFIGTEST_DB_HOST": ""},
+			wantErrs: []error{errHostRequired},
+			wantMsg:  "db: host is required",
+		},
+		{
+			name:     "all sections are validated",
+			env:      map[string]string{"CONFIGTEST_PORT": "0", "CONFIGTEST_DB_HOST": ""},
+			wantErrs: []error{errPortRequired, errHostRequired},
+			wantMsg:  "db: host is required\nport must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+
+			err := Load(testPrefix, defaultTestConfig())
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("Load: %v", err)
+				}
+				return
+			}
+
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Fatalf("Load: got %v, want %v", err, want)
+				}
+			}
+			if err.Error() != tt.wantMsg {
+				t.Fatalf("Load: got %q, want %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestLoadRequiresStructPointer(t *testing.T) {
+	for _, cfg := range []any{testConfig{}, new(int), nil} {
+		if err := Load(testPrefix, cfg); err == nil {
+			t.Fatalf("Load(%T): got nil, want error", cfg)
+		}
+	}
+}
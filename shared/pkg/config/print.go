@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redacted значение секретного поля при выводе конфигурации
+const redacted = "xxxxx"
+
+// Format возвращает конфигурацию cfg в формате YAML файла. Для полей, которые задаются
+// переменными окружения, имя переменной выводится в комментарии. Значения секретных полей скрываются,
+// у строк подключения вида URL скрывается только пароль
+func Format(prefix string, cfg any) string {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	var b strings.Builder
+	formatStruct(&b, 0, prefix, v)
+
+	return b.String()
+}
+
+func formatStruct(b *strings.Builder, indent int, env string, v reflect.Value) {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := yamlName(sf)
+		if name == "-" {
+			continue
+		}
+
+		tag := sf.Tag.Get("env")
+		fieldEnv := env
+		if tag != "" {
+			fieldEnv = envName(env, tag)
+		}
+
+		fmt.Fprintf(b, "%s%s:", strings.Repeat("  ", indent), name)
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct {
+			b.WriteString("\n")
+			formatStruct(b, indent+1, fieldEnv, fv)
+			continue
+		}
+
+		b.WriteString(" ")
+		b.WriteString(formatValue(fv, sf.Tag.Get("secret") == "true"))
+		if tag != "" {
+			b.WriteString(" # ")
+			b.WriteString(fieldEnv)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// formatValue возвращает значение поля в формате YAML
+func formatValue(v reflect.Value, secret bool) string {
+	if v.Type() == durationType {
+		return strconv.Quote(time.Duration(v.Int()).String())
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if secret {
+			return strconv.Quote(redact(v.String()))
+		}
+		return strconv.Quote(v.String())
+	case reflect.Slice:
+		items := make([]string, 0, v.Len())
+		for i := range v.Len() {
+			items = append(items, formatValue(v.Index(i), secret))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	if secret && !v.IsZero() {
+		return strconv.Quote(redacted)
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// redact скрывает секретное значение. В строке подключения вида URL скрывается только пароль,
+// чтобы в выводе оставались адрес и имя базы
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return redacted
+	}
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+
+	return u.String()
+}
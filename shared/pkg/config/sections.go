@@ -0,0 +1,364 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Типы хранилищ сервисов
+const (
+	StorageTypeInMem    = "inmem"
+	StorageTypePostgres = "postgres"
+)
+
+// HTTPServer настройки HTTP сервера
+type HTTPServer struct {
+	Address string `yaml:"address" env:"ADDRESS"`
+	// ReadHeaderTimeout время чтения заголовков запроса
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"READ_HEADER_TIMEOUT"`
+	// RequestTimeout время обработки запроса
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"REQUEST_TIMEOUT"`
+	// DrainDelay время между отказом проверки готовности и остановкой приема запросов при остановке,
+	// за которое балансировщик успевает убрать сервер из ротации
+	DrainDelay time.Duration `yaml:"drain_delay" env:"DRAIN_DELAY"`
+	// ShutdownTimeout время завершения обрабатываемых запросов при остановке
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	TLS             ServerTLS     `yaml:"tls" env:"TLS"`
+}
+
+// Validate проверяет настройки HTTP сервера
+func (c *HTTPServer) Validate() error {
+	return errors.Join(
+		validateAddress(c.Address),
+		Positive("read_header_timeout", c.ReadHeaderTimeout),
+		Positive("request_timeout", c.RequestTimeout),
+		NotNegative("drain_delay", c.DrainDelay),
+		Positive("shutdown_timeout", c.ShutdownTimeout),
+	)
+}
+
+// GRPCServer настройки gRPC сервера
+type GRPCServer struct {
+	Address string `yaml:"address" env:"ADDRESS"`
+	// ShutdownTimeout время завершения обрабатываемых запросов при остановке,
+	// после которого незавершенные запросы прерываются
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	TLS             ServerTLS     `yaml:"tls" env:"TLS"`
+}
+
+// Validate проверяет настройки gRPC сервера
+func (c *GRPCServer) Validate() error {
+	return errors.Join(
+		validateAddress(c.Address),
+		Positive("shutdown_timeout", c.ShutdownTimeout),
+	)
+}
+
+// GRPCClient настройки клиента gRPC сервиса
+type GRPCClient struct {
+	Address string `yaml:"address" env:"ADDRESS"`
+	// Timeout время ожидания ответа на запрос
+	Timeout time.Duration `yaml:"timeout" env:"TIMEOUT"`
+	TLS     ClientTLS     `yaml:"tls" env:"TLS"`
+}
+
+// Validate проверяет настройки клиента gRPC сервиса
+func (c *GRPCClient) Validate() error {
+	return errors.Join(
+		validateAddress(c.Address),
+		Positive("timeout", c.Timeout),
+	)
+}
+
+// Storage настройки хранилища сервиса
+type Storage struct {
+	// Type тип хранилища: inmem или postgres
+	Type        string `yaml:"type" env:"STORAGE_TYPE"`
+	PostgresDSN string `yaml:"postgres_dsn" env:"POSTGRES_DSN" secret:"true"`
+}
+
+// Validate проверяет тип хранилища и наличие строки подключения к PostgreSQL
+func (c *Storage) Validate() error {
+	switch c.Type {
+	case StorageTypeInMem:
+		return nil
+	case StorageTypePostgres:
+		if c.PostgresDSN == "" {
+			return fmt.Errorf("postgres_dsn must be set for %s storage", StorageTypePostgres)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown storage type %q", c.Type)
+}
+
+// Log настройки логирования
+type Log struct {
+	// Level минимальный уровень записей: debug, info, warn или error
+	Level string `yaml:"level" env:"LEVEL"`
+}
+
+// Validate проверяет уровень логирования
+func (c *Log) Validate() error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Level)); err != nil {
+		return fmt.Errorf("invalid level %q", c.Level)
+	}
+
+	return nil
+}
+
+// SlogLevel возвращает уровень логирования, некорректный уровень отклоняется при загрузке конфигурации
+func (c Log) SlogLevel() slog.Level {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(c.Level))
+
+	return level
+}
+
+// Экспортеры трасс
+const (
+	TracingExporterNone   = "none"
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+)
+
+// Tracing настройки трассировки OpenTelemetry
+type Tracing struct {
+	// Exporter получатель трасс: none, otlp, stdout или file. При none трассы не выгружаются,
+	// но контекст трассы передается между сервисами и записывается в лог
+	Exporter string `yaml:"exporter" env:"EXPORTER"`
+	// Endpoint адрес OTLP коллектора, принимающего трассы по gRPC
+	Endpoint string `yaml:"endpoint" env:"ENDPOINT"`
+	// Insecure отключает TLS соединения с коллектором
+	Insecure bool `yaml:"insecure" env:"INSECURE"`
+	// File файл, в который экспортер file дописывает span по одному JSON объекту в строке
+	File string `yaml:"file" env:"FILE"`
+	// SampleRatio доля записываемых трасс, начатых сервисом. Для трасс, пришедших из другого сервиса,
+	// сохраняется решение вызывающего сервиса
+	SampleRatio float64 `yaml:"sample_ratio" env:"SAMPLE_RATIO"`
+}
+
+// Validate проверяет экспортер и долю записываемых трасс
+func (c *Tracing) Validate() error {
+	var err error
+	switch c.Exporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterOTLP:
+		err = validateAddress(c.Endpoint)
+	case TracingExporterFile:
+		if c.File == "" {
+			err = fmt.Errorf("file must be set for %s exporter", TracingExporterFile)
+		}
+	default:
+		err = fmt.Errorf("unknown exporter %q", c.Exporter)
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		err = errors.Join(err, fmt.Errorf("sample_ratio must be between 0 and 1, got %v", c.SampleRatio))
+	}
+
+	return err
+}
+
+// Metrics настройки HTTP сервера, отдающего метрики в формате Prometheus
+type Metrics struct {
+	// Address адрес, на котором метрики отдаются по пути /metrics
+	Address string `yaml:"address" env:"ADDRESS"`
+}
+
+// Validate проверяет адрес сервера метрик
+func (c *Metrics) Validate() error {
+	return validateAddress(c.Address)
+}
+
+// ServerTLS файлы сертификатов сервера. Без сертификата сервер принимает соединения без TLS
+type ServerTLS struct {
+	CertFile string `yaml:"cert_file" env:"CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"KEY_FILE"`
+	// ClientCAFile сертификат центра сертификации клиентов, если задан, клиенты обязаны предъявить сертификат
+	ClientCAFile string `yaml:"client_ca_file" env:"CLIENT_CA_FILE"`
+}
+
+// Enabled проверяет, что сервер принимает соединения по TLS
+func (c ServerTLS) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// Validate проверяет, что файлы сертификатов заданы вместе и существуют
+func (c *ServerTLS) Validate() error {
+	if c.CertFile == "" && c.KeyFile == "" {
+		if c.ClientCAFile != "" {
+			return errors.New("client_ca_file requires cert_file and key_file")
+		}
+		return nil
+	}
+
+	if c.CertFile == "" || c.KeyFile == "" {
+		return errors.New("cert_file and key_file must be set together")
+	}
+
+	return errors.Join(fileExists("cert_file", c.CertFile), fileExists("key_file", c.KeyFile),
+		fileExists("client_ca_file", c.ClientCAFile))
+}
+
+// Config возвращает настройки TLS сервера, nil — TLS выключен
+func (c ServerTLS) Config() (*tls.Config, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load server certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if c.ClientCAFile != "" {
+		config.ClientCAs, err = loadCertPool(c.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// GRPCCredentials возвращает транспортные учетные данные gRPC сервера
+func (c ServerTLS) GRPCCredentials() (credentials.TransportCredentials, error) {
+	config, err := c.Config()
+	if err != nil || config == nil {
+		return insecure.NewCredentials(), err
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// ClientTLS настройки TLS соединения клиента
+type ClientTLS struct {
+	Enabled bool `yaml:"enabled" env:"ENABLED"`
+	// CAFile сертификат центра сертификации сервера, по умолчанию используются системные сертификаты
+	CAFile string `yaml:"ca_file" env:"CA_FILE"`
+	// CertFile и KeyFile сертификат клиента для серверов, требующих сертификат клиента
+	CertFile string `yaml:"cert_file" env:"CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"KEY_FILE"`
+	// ServerName имя сервера в сертификате, по умолчанию берется из адреса
+	ServerName string `yaml:"server_name" env:"SERVER_NAME"`
+}
+
+// Validate проверяет, что файлы сертификатов заданы только при включенном TLS и существуют
+func (c *ClientTLS) Validate() error {
+	if !c.Enabled {
+		if c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" {
+			return errors.New("certificate files are set but tls is not enabled")
+		}
+		return nil
+	}
+
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("cert_file and key_file must be set together")
+	}
+
+	return errors.Join(fileExists("ca_file", c.CAFile), fileExists("cert_file", c.CertFile),
+		fileExists("key_file", c.KeyFile))
+}
+
+// GRPCCredentials возвращает транспортные учетные данные gRPC клиента
+func (c ClientTLS) GRPCCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	config := &tls.Config{
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// loadCertPool читает сертификаты центра сертификации в формате PEM
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read ca file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates in ca file %s", path)
+	}
+
+	return pool, nil
+}
+
+// validateAddress проверяет адрес вида host:port, host может быть пустым
+func validateAddress(address string) error {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("invalid address %q: %w", address, err)
+	}
+
+	return nil
+}
+
+// Positive проверяет, что длительность больше нуля
+func Positive(name string, d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("%s must be positive, got %s", name, d)
+	}
+
+	return nil
+}
+
+// NotNegative проверяет, что длительность не меньше нуля
+func NotNegative(name string, d time.Duration) error {
+	if d < 0 {
+		return fmt.Errorf("%s must not be negative, got %s", name, d)
+	}
+
+	return nil
+}
+
+// fileExists проверяет, что заданный файл существует
+func fileExists(name, path string) error {
+	if path == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadataKey ключ метаданных gRPC запроса с идентификатором запроса
+const requestIDMetadataKey = "x-request-id"
+
+// UnaryClientInterceptor передает идентификатор запроса из контекста в метаданных gRPC запроса
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor передает идентификатор запроса из контекста в метаданных gRPC потока
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// UnaryServerInterceptor добавляет в контекст идентификатор запроса из метаданных, создавая новый,
+// если клиент его не передал, и записывает в лог каждый запрос
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = incomingContext(ctx)
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor добавляет идентификатор запроса в контекст потока и записывает поток в лог после завершения
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := incomingContext(ss.Context())
+		start := time.Now()
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// outgoingContext добавляет идентификатор запроса в исходящие метаданные
+func outgoingContext(ctx context.Context) context.Context {
+	if requestID := RequestID(ctx); requestID != "" {
+		return metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, requestID)
+	}
+
+	return ctx
+}
+
+// incomingContext возвращает контекст с идентификатором запроса из входящих метаданных или новым идентификатором
+func incomingContext(ctx context.Context) context.Context {
+	if values := metadata.ValueFromIncomingContext(ctx, requestIDMetadataKey); len(values) > 0 && validRequestID(values[0]) {
+		return WithRequestID(ctx, values[0])
+	}
+
+	return WithRequestID(ctx, uuid.NewString())
+}
+
+// logCall записывает в лог завершенный gRPC запрос. Ошибки сервера записываются с уровнем ERROR,
+// ошибки клиента — с уровнем INFO
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	logLevel := slog.LevelInfo
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented:
+		logLevel = slog.LevelError
+	}
+
+	// Проверки здоровья приходят постоянно, поэтому без ошибок сервера пишутся только на уровне debug
+	if logLevel == slog.LevelInfo && method == healthpb.Health_Check_FullMethodName {
+		logLevel = slog.LevelDebug
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		durationAttr(time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, Err(err))
+	}
+
+	slog.LogAttrs(ctx, logLevel, "grpc request", attrs...)
+}
+
+// serverStream подменяет контекст gRPC потока
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
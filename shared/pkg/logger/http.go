@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader заголовок HTTP запроса и ответа с идентификатором запроса
+const RequestIDHeader = "X-Request-Id"
+
+// maxRequestIDLength максимальная длина идентификатора запроса, принимаемого от клиента
+const maxRequestIDLength = 128
+
+// HTTPMiddleware создает идентификатор запроса или принимает его из заголовка X-Request-Id,
+// возвращает его в заголовке ответа, записывает в лог каждый запрос и перехватывает панику обработчика
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		ctx := WithRequestID(r.Context(), requestID)
+		w.Header().Set(RequestIDHeader, requestID)
+
+		rw := &responseWriter{ResponseWriter: w}
+		start := time.Now()
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(rec)
+				}
+
+				slog.ErrorContext(ctx, "http handler panic",
+					slog.Any("panic", rec),
+					slog.String("stack", string(debug.Stack())),
+				)
+				if rw.status == 0 {
+					rw.WriteHeader(http.StatusInternalServerError)
+				}
+			}
+
+			status := rw.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			logLevel := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				logLevel = slog.LevelError
+			}
+
+			slog.Log(ctx, logLevel, "http request",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Int("bytes", rw.bytes),
+				durationAttr(time.Since(start)),
+				slog.String("remote_addr", r.RemoteAddr),
+			)
+		}()
+
+		next.ServeHTTP(rw, r.WithContext(ctx))
+	})
+}
+
+// validRequestID проверяет, что идентификатор запроса от клиента можно записать в лог и передать дальше
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range requestID {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// responseWriter запоминает код и размер ответа
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+
+	return n, err
+}
+
+// Unwrap возвращает исходный http.ResponseWriter для http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
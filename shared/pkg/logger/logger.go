@@ -0,0 +1,88 @@
+// Package logger настраивает структурированное логирование сервисов через log/slog в формате JSON
+// и передает идентификатор запроса между сервисами: он создается на входе HTTP запроса в order service,
+// передается в метаданных gRPC запросов и добавляется к каждой записи лога, сделанной с контекстом запроса
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDKey ключ идентификатора запроса в контексте
+type requestIDKey struct{}
+
+// level уровень логирования, может меняться после загрузки конфигурации
+var level = new(slog.LevelVar)
+
+// WithRequestID возвращает контекст с идентификатором запроса
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID возвращает идентификатор запроса из контекста или пустую строку
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// Init делает логгер сервиса service логгером по умолчанию для log/slog и стандартного пакета log.
+// До вызова SetLevel записываются сообщения уровня INFO и выше
+func Init(service string) {
+	slog.SetDefault(New(os.Stdout, service))
+}
+
+// SetLevel меняет уровень логирования логгеров, созданных Init и New
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// New создает логгер, записывающий JSON в w. Каждая запись содержит имя сервиса,
+// идентификатор запроса и идентификаторы трассы и span, если они есть в контексте записи
+func New(w io.Writer, service string) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})
+
+	return slog.New(contextHandler{Handler: handler}).With(slog.String("service", service))
+}
+
+// Err возвращает атрибут с текстом ошибки
+func Err(err error) slog.Attr {
+	return slog.Any("error", err)
+}
+
+// contextHandler добавляет к записи идентификаторы запроса и трассы из контекста
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// durationAttr возвращает атрибут с длительностью в миллисекундах
+func durationAttr(d time.Duration) slog.Attr {
+	return slog.Float64("duration_ms", float64(d)/float64(time.Millisecond))
+}
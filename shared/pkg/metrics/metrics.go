@@ -0,0 +1,83 @@
+// Package metrics настраивает метрики сервисов через OpenTelemetry и отдает их в формате Prometheus.
+// Через провайдера по умолчанию метрики пишут обработчик OpenAPI (запросы, ошибки и длительность
+// по операциям), gRPC серверы и клиенты (длительность и коды ответов по методам) и бизнес-метрики сервисов
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	otelPrometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// readHeaderTimeout время чтения заголовков запроса к серверу метрик
+const readHeaderTimeout = 5 * time.Second
+
+// Init делает провайдера метрик сервиса service провайдером по умолчанию для OpenTelemetry и возвращает
+// обработчик, отдающий метрики в формате Prometheus вместе с метриками среды выполнения Go и процесса.
+// Возвращаемая функция останавливает провайдера при остановке сервиса
+func Init(ctx context.Context, service string) (handler http.Handler, shutdown func(context.Context) error, err error) {
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(service)),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create metrics resource: %w", err)
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	exporter, err := otelPrometheus.New(otelPrometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, fmt.Errorf("create prometheus exporter: %w", err)
+	}
+
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(exporter),
+		sdkmetric.WithResource(res),
+		sdkmetric.WithView(dropAnnotationUnits),
+	)
+	otel.SetMeterProvider(provider)
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), provider.Shutdown, nil
+}
+
+// dropAnnotationUnits убирает единицы измерения в фигурных скобках, например {count} у метрик OpenAPI:
+// по спецификации OpenTelemetry это аннотации, а экспортер Prometheus добавил бы их в имя метрики
+func dropAnnotationUnits(instrument sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+	if !strings.HasPrefix(instrument.Unit, "{") {
+		return sdkmetric.Stream{}, false
+	}
+
+	return sdkmetric.Stream{
+		Name:        instrument.Name,
+		Description: instrument.Description,
+	}, true
+}
+
+// NewServer создает HTTP сервер, отдающий метрики по пути /metrics
+func NewServer(address string, handler http.Handler) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", handler)
+
+	return &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+}
@@ -0,0 +1,80 @@
+// Package money содержит общие правила работы с денежными суммами: суммы хранятся в целых
+// минимальных единицах валюты (копейках, центах), валюта задается кодом ISO 4217
+package money
+
+import (
+	"errors"
+	"math"
+	"regexp"
+)
+
+// DefaultCurrency валюта по умолчанию для цен без указанной валюты
+const DefaultCurrency = "RUB"
+
+var (
+	ErrInvalidCurrency = errors.New("currency must be an ISO 4217 code")
+	ErrAmountOverflow  = errors.New("amount is out of range")
+)
+
+// currencyPattern формат кода валюты ISO 4217
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// exponents количество знаков после запятой у валют, отличающихся от стандартных двух
+var exponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// ValidCurrency проверяет формат кода валюты
+func ValidCurrency(currency string) bool {
+	return currencyPattern.MatchString(currency)
+}
+
+// Exponent возвращает количество минимальных единиц валюты в основной единице в виде степени десяти
+func Exponent(currency string) int {
+	if e, ok := exponents[currency]; ok {
+		return e
+	}
+
+	return 2
+}
+
+// ToMinor переводит сумму в основных единицах валюты в минимальные с округлением до ближайшей
+func ToMinor(amount float64, currency string) (int64, error) {
+	minor := math.Round(amount * math.Pow10(Exponent(currency)))
+	if math.IsNaN(minor) || minor >= math.MaxInt64 || minor < math.MinInt64 {
+		return 0, ErrAmountOverflow
+	}
+
+	return int64(minor), nil
+}
+
+// FromMinor переводит сумму в минимальных единицах валюты в основные
+func FromMinor(minor int64, currency string) float64 {
+	return float64(minor) / math.Pow10(Exponent(currency))
+}
+
+// Mul умножает цену в минимальных единицах на количество с проверкой переполнения
+func Mul(minor, quantity int64) (int64, error) {
+	if minor == 0 || quantity == 0 {
+		return 0, nil
+	}
+
+	result := minor * quantity
+	if result/quantity != minor {
+		return 0, ErrAmountOverflow
+	}
+
+	return result, nil
+}
+
+// Add складывает суммы в минимальных единицах с проверкой переполнения
+func Add(a, b int64) (int64, error) {
+	result := a + b
+	if (b > 0 && result < a) || (b < 0 && result > a) {
+		return 0, ErrAmountOverflow
+	}
+
+	return result, nil
+}
@@ -12,9 +12,15 @@ import (
 	ht "github.com/ogen-go/ogen/http"
 	"github.com/ogen-go/ogen/middleware"
 	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/ogen-go/ogen/ogenregex"
 	"github.com/ogen-go/ogen/otelogen"
 )
 
+var regexMap = map[string]ogenregex.Regexp{
+	"^[A-Z]{2}$":            ogenregex.MustCompile("^[A-Z]{2}$"),
+	"^[A-Z]{3}$":            ogenregex.MustCompile("^[A-Z]{3}$"),
+	"^[A-Za-z0-9_-]{3,32}$": ogenregex.MustCompile("^[A-Za-z0-9_-]{3,32}$"),
+}
 var (
 	// Allocate option closure once.
 	clientSpanKind = trace.WithSpanKind(trace.SpanKindClient)
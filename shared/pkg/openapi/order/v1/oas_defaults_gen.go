@@ -0,0 +1,23 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package order_v1
+
+// setDefaults set default value of fields.
+func (s *CreateOrderRequest) setDefaults() {
+	{
+		val := string("RU")
+		s.Country.SetTo(val)
+	}
+}
+
+// setDefaults set default value of fields.
+func (s *PromoCodeRequest) setDefaults() {
+	{
+		val := int32(0)
+		s.MaxUsesPerUser.SetTo(val)
+	}
+	{
+		val := bool(true)
+		s.Active.SetTo(val)
+	}
+}
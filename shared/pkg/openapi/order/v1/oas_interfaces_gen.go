@@ -9,10 +9,42 @@ type CreateOrderRes interface {
 	createOrderRes()
 }
 
+type DeletePromoCodeRes interface {
+	deletePromoCodeRes()
+}
+
 type GetOrderByUUIDRes interface {
 	getOrderByUUIDRes()
 }
 
+type GetOrderHistoryRes interface {
+	getOrderHistoryRes()
+}
+
+type GetPromoCodeRes interface {
+	getPromoCodeRes()
+}
+
+type ListOrdersRes interface {
+	listOrdersRes()
+}
+
+type ListPromoCodesRes interface {
+	listPromoCodesRes()
+}
+
 type PayOrderRes interface {
 	payOrderRes()
 }
+
+type PutPromoCodeRes interface {
+	putPromoCodeRes()
+}
+
+type RefundOrderRes interface {
+	refundOrderRes()
+}
+
+type UpdateOrderFulfilmentRes interface {
+	updateOrderFulfilmentRes()
+}
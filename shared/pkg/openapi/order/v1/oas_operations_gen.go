@@ -6,8 +6,16 @@ package order_v1
 type OperationName = string
 
 const (
-	CancelOrderOperation    OperationName = "CancelOrder"
-	CreateOrderOperation    OperationName = "CreateOrder"
-	GetOrderByUUIDOperation OperationName = "GetOrderByUUID"
-	PayOrderOperation       OperationName = "PayOrder"
+	CancelOrderOperation           OperationName = "CancelOrder"
+	CreateOrderOperation           OperationName = "CreateOrder"
+	DeletePromoCodeOperation       OperationName = "DeletePromoCode"
+	GetOrderByUUIDOperation        OperationName = "GetOrderByUUID"
+	GetOrderHistoryOperation       OperationName = "GetOrderHistory"
+	GetPromoCodeOperation          OperationName = "GetPromoCode"
+	ListOrdersOperation            OperationName = "ListOrders"
+	ListPromoCodesOperation        OperationName = "ListPromoCodes"
+	PayOrderOperation              OperationName = "PayOrder"
+	PutPromoCodeOperation          OperationName = "PutPromoCode"
+	RefundOrderOperation           OperationName = "RefundOrder"
+	UpdateOrderFulfilmentOperation OperationName = "UpdateOrderFulfilment"
 )
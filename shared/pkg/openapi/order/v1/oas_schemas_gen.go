@@ -4,6 +4,7 @@ package order_v1
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-faster/errors"
 )
@@ -40,7 +41,9 @@ func (s *BadRequestError) SetMessage(val string) {
 	s.Message = val
 }
 
-func (*BadRequestError) createOrderRes() {}
+func (*BadRequestError) createOrderRes()  {}
+func (*BadRequestError) listOrdersRes()   {}
+func (*BadRequestError) putPromoCodeRes() {}
 
 // CancelOrderNoContent is response for CancelOrder operation.
 type CancelOrderNoContent struct{}
@@ -75,15 +78,28 @@ func (s *ConflictError) SetMessage(val string) {
 	s.Message = val
 }
 
-func (*ConflictError) cancelOrderRes() {}
-func (*ConflictError) payOrderRes()    {}
+func (*ConflictError) cancelOrderRes()           {}
+func (*ConflictError) createOrderRes()           {}
+func (*ConflictError) payOrderRes()              {}
+func (*ConflictError) refundOrderRes()           {}
+func (*ConflictError) updateOrderFulfilmentRes() {}
 
 // Ref: #
 type CreateOrderRequest struct {
 	// UUID пользователя.
 	UserUUID string `json:"user_uuid"`
-	// Список UUID деталей.
+	// Позиции заказа с количеством деталей.
+	Items []OrderItem `json:"items"`
+	// Список UUID деталей, каждая деталь в количестве одной
+	// штуки. Устарело, используйте items.
+	//
+	// Deprecated: schema marks this property as deprecated.
 	PartUuids []string `json:"part_uuids"`
+	// Промокод на скидку, регистр не учитывается.
+	PromoCode OptString `json:"promo_code"`
+	// Страна доставки ISO 3166-1 alpha-2, определяет ставку НДС и
+	// тариф доставки.
+	Country OptString `json:"country"`
 }
 
 // GetUserUUID returns the value of UserUUID.
@@ -91,27 +107,86 @@ func (s *CreateOrderRequest) GetUserUUID() string {
 	return s.UserUUID
 }
 
+// GetItems returns the value of Items.
+func (s *CreateOrderRequest) GetItems() []OrderItem {
+	return s.Items
+}
+
 // GetPartUuids returns the value of PartUuids.
 func (s *CreateOrderRequest) GetPartUuids() []string {
 	return s.PartUuids
 }
 
+// GetPromoCode returns the value of PromoCode.
+func (s *CreateOrderRequest) GetPromoCode() OptString {
+	return s.PromoCode
+}
+
+// GetCountry returns the value of Country.
+func (s *CreateOrderRequest) GetCountry() OptString {
+	return s.Country
+}
+
 // SetUserUUID sets the value of UserUUID.
 func (s *CreateOrderRequest) SetUserUUID(val string) {
 	s.UserUUID = val
 }
 
+// SetItems sets the value of Items.
+func (s *CreateOrderRequest) SetItems(val []OrderItem) {
+	s.Items = val
+}
+
 // SetPartUuids sets the value of PartUuids.
 func (s *CreateOrderRequest) SetPartUuids(val []string) {
 	s.PartUuids = val
 }
 
+// SetPromoCode sets the value of PromoCode.
+func (s *CreateOrderRequest) SetPromoCode(val OptString) {
+	s.PromoCode = val
+}
+
+// SetCountry sets the value of Country.
+func (s *CreateOrderRequest) SetCountry(val OptString) {
+	s.Country = val
+}
+
 // Ref: #
 type CreateOrderResponse struct {
 	// Уникальный идентификатор заказа.
 	OrderUUID string `json:"order_uuid"`
-	// Итоговая стоимость.
+	// Итоговая стоимость в основных единицах валюты.
+	// Устарело, используйте total_price_minor.
+	//
+	// Deprecated: schema marks this property as deprecated.
 	TotalPrice float64 `json:"total_price"`
+	// Стоимость позиций без скидки в минимальных единицах
+	// валюты.
+	SubtotalMinor int64 `json:"subtotal_minor"`
+	// Сумма скидки по промокоду в минимальных единицах
+	// валюты, 0 без скидки.
+	DiscountMinor int64 `json:"discount_minor"`
+	// Расчетный вес отправления в кг, для габаритных
+	// деталей учитывается объемный вес.
+	ShippingWeightKg float64 `json:"shipping_weight_kg"`
+	// Стоимость доставки в минимальных единицах валюты.
+	ShippingMinor int64 `json:"shipping_minor"`
+	// Ставка НДС страны доставки в сотых долях процента, 2200
+	// — 22%.
+	TaxRateBp int32 `json:"tax_rate_bp"`
+	// Сумма НДС на позиции со скидкой и доставку в
+	// минимальных единицах валюты.
+	TaxMinor int64 `json:"tax_minor"`
+	// Итоговая стоимость позиций со скидкой, доставки и НДС
+	// в минимальных единицах валюты (копейках).
+	TotalPriceMinor int64 `json:"total_price_minor"`
+	// Примененный промокод.
+	PromoCode OptString `json:"promo_code"`
+	// Страна доставки ISO 3166-1 alpha-2.
+	Country string `json:"country"`
+	// Код валюты заказа ISO 4217.
+	Currency string `json:"currency"`
 }
 
 // GetOrderUUID returns the value of OrderUUID.
@@ -124,6 +199,56 @@ func (s *CreateOrderResponse) GetTotalPrice() float64 {
 	return s.TotalPrice
 }
 
+// GetSubtotalMinor returns the value of SubtotalMinor.
+func (s *CreateOrderResponse) GetSubtotalMinor() int64 {
+	return s.SubtotalMinor
+}
+
+// GetDiscountMinor returns the value of DiscountMinor.
+func (s *CreateOrderResponse) GetDiscountMinor() int64 {
+	return s.DiscountMinor
+}
+
+// GetShippingWeightKg returns the value of ShippingWeightKg.
+func (s *CreateOrderResponse) GetShippingWeightKg() float64 {
+	return s.ShippingWeightKg
+}
+
+// GetShippingMinor returns the value of ShippingMinor.
+func (s *CreateOrderResponse) GetShippingMinor() int64 {
+	return s.ShippingMinor
+}
+
+// GetTaxRateBp returns the value of TaxRateBp.
+func (s *CreateOrderResponse) GetTaxRateBp() int32 {
+	return s.TaxRateBp
+}
+
+// GetTaxMinor returns the value of TaxMinor.
+func (s *CreateOrderResponse) GetTaxMinor() int64 {
+	return s.TaxMinor
+}
+
+// GetTotalPriceMinor returns the value of TotalPriceMinor.
+func (s *CreateOrderResponse) GetTotalPriceMinor() int64 {
+	return s.TotalPriceMinor
+}
+
+// GetPromoCode returns the value of PromoCode.
+func (s *CreateOrderResponse) GetPromoCode() OptString {
+	return s.PromoCode
+}
+
+// GetCountry returns the value of Country.
+func (s *CreateOrderResponse) GetCountry() string {
+	return s.Country
+}
+
+// GetCurrency returns the value of Currency.
+func (s *CreateOrderResponse) GetCurrency() string {
+	return s.Currency
+}
+
 // SetOrderUUID sets the value of OrderUUID.
 func (s *CreateOrderResponse) SetOrderUUID(val string) {
 	s.OrderUUID = val
@@ -134,8 +259,160 @@ func (s *CreateOrderResponse) SetTotalPrice(val float64) {
 	s.TotalPrice = val
 }
 
+// SetSubtotalMinor sets the value of SubtotalMinor.
+func (s *CreateOrderResponse) SetSubtotalMinor(val int64) {
+	s.SubtotalMinor = val
+}
+
+// SetDiscountMinor sets the value of DiscountMinor.
+func (s *CreateOrderResponse) SetDiscountMinor(val int64) {
+	s.DiscountMinor = val
+}
+
+// SetShippingWeightKg sets the value of ShippingWeightKg.
+func (s *CreateOrderResponse) SetShippingWeightKg(val float64) {
+	s.ShippingWeightKg = val
+}
+
+// SetShippingMinor sets the value of ShippingMinor.
+func (s *CreateOrderResponse) SetShippingMinor(val int64) {
+	s.ShippingMinor = val
+}
+
+// SetTaxRateBp sets the value of TaxRateBp.
+func (s *CreateOrderResponse) SetTaxRateBp(val int32) {
+	s.TaxRateBp = val
+}
+
+// SetTaxMinor sets the value of TaxMinor.
+func (s *CreateOrderResponse) SetTaxMinor(val int64) {
+	s.TaxMinor = val
+}
+
+// SetTotalPriceMinor sets the value of TotalPriceMinor.
+func (s *CreateOrderResponse) SetTotalPriceMinor(val int64) {
+	s.TotalPriceMinor = val
+}
+
+// SetPromoCode sets the value of PromoCode.
+func (s *CreateOrderResponse) SetPromoCode(val OptString) {
+	s.PromoCode = val
+}
+
+// SetCountry sets the value of Country.
+func (s *CreateOrderResponse) SetCountry(val string) {
+	s.Country = val
+}
+
+// SetCurrency sets the value of Currency.
+func (s *CreateOrderResponse) SetCurrency(val string) {
+	s.Currency = val
+}
+
 func (*CreateOrderResponse) createOrderRes() {}
 
+// DeletePromoCodeNoContent is response for DeletePromoCode operation.
+type DeletePromoCodeNoContent struct{}
+
+func (*DeletePromoCodeNoContent) deletePromoCodeRes() {}
+
+// Тип скидки промокода: PERCENTAGE — процент от стоимости
+// подходящих позиций, FIXED — фиксированная сумма,
+// распределяемая по подходящим позициям.
+// Ref: #
+type DiscountType string
+
+const (
+	DiscountTypePERCENTAGE DiscountType = "PERCENTAGE"
+	DiscountTypeFIXED      DiscountType = "FIXED"
+)
+
+// AllValues returns all DiscountType values.
+func (DiscountType) AllValues() []DiscountType {
+	return []DiscountType{
+		DiscountTypePERCENTAGE,
+		DiscountTypeFIXED,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s DiscountType) MarshalText() ([]byte, error) {
+	switch s {
+	case DiscountTypePERCENTAGE:
+		return []byte(s), nil
+	case DiscountTypeFIXED:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *DiscountType) UnmarshalText(data []byte) error {
+	switch DiscountType(data) {
+	case DiscountTypePERCENTAGE:
+		*s = DiscountTypePERCENTAGE
+		return nil
+	case DiscountTypeFIXED:
+		*s = DiscountTypeFIXED
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Этап выполнения оплаченного заказа: ASSEMBLING — сборка,
+// SHIPPED — отгрузка, COMPLETED — заказ получен. Этапы
+// проходятся по порядку, начиная со статуса PAID.
+// Ref: #
+type FulfilmentStatus string
+
+const (
+	FulfilmentStatusASSEMBLING FulfilmentStatus = "ASSEMBLING"
+	FulfilmentStatusSHIPPED    FulfilmentStatus = "SHIPPED"
+	FulfilmentStatusCOMPLETED  FulfilmentStatus = "COMPLETED"
+)
+
+// AllValues returns all FulfilmentStatus values.
+func (FulfilmentStatus) AllValues() []FulfilmentStatus {
+	return []FulfilmentStatus{
+		FulfilmentStatusASSEMBLING,
+		FulfilmentStatusSHIPPED,
+		FulfilmentStatusCOMPLETED,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s FulfilmentStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case FulfilmentStatusASSEMBLING:
+		return []byte(s), nil
+	case FulfilmentStatusSHIPPED:
+		return []byte(s), nil
+	case FulfilmentStatusCOMPLETED:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *FulfilmentStatus) UnmarshalText(data []byte) error {
+	switch FulfilmentStatus(data) {
+	case FulfilmentStatusASSEMBLING:
+		*s = FulfilmentStatusASSEMBLING
+		return nil
+	case FulfilmentStatusSHIPPED:
+		*s = FulfilmentStatusSHIPPED
+		return nil
+	case FulfilmentStatusCOMPLETED:
+		*s = FulfilmentStatusCOMPLETED
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
 // Ref: #
 type GenericError struct {
 	// HTTP-код ошибки.
@@ -190,6 +467,36 @@ func (s *GenericErrorStatusCode) SetResponse(val GenericError) {
 	s.Response = val
 }
 
+// Ref: #
+type GetOrderHistoryResponse struct {
+	// UUID заказа.
+	OrderUUID string `json:"order_uuid"`
+	// Переходы статуса заказа в порядке их выполнения.
+	Transitions []OrderTransition `json:"transitions"`
+}
+
+// GetOrderUUID returns the value of OrderUUID.
+func (s *GetOrderHistoryResponse) GetOrderUUID() string {
+	return s.OrderUUID
+}
+
+// GetTransitions returns the value of Transitions.
+func (s *GetOrderHistoryResponse) GetTransitions() []OrderTransition {
+	return s.Transitions
+}
+
+// SetOrderUUID sets the value of OrderUUID.
+func (s *GetOrderHistoryResponse) SetOrderUUID(val string) {
+	s.OrderUUID = val
+}
+
+// SetTransitions sets the value of Transitions.
+func (s *GetOrderHistoryResponse) SetTransitions(val []OrderTransition) {
+	s.Transitions = val
+}
+
+func (*GetOrderHistoryResponse) getOrderHistoryRes() {}
+
 // Ref: #
 type InternalServerError struct {
 	// HTTP-код ошибки.
@@ -218,10 +525,67 @@ func (s *InternalServerError) SetMessage(val string) {
 	s.Message = val
 }
 
-func (*InternalServerError) cancelOrderRes()    {}
-func (*InternalServerError) createOrderRes()    {}
-func (*InternalServerError) getOrderByUUIDRes() {}
-func (*InternalServerError) payOrderRes()       {}
+func (*InternalServerError) cancelOrderRes()           {}
+func (*InternalServerError) createOrderRes()           {}
+func (*InternalServerError) deletePromoCodeRes()       {}
+func (*InternalServerError) getOrderByUUIDRes()        {}
+func (*InternalServerError) getOrderHistoryRes()       {}
+func (*InternalServerError) getPromoCodeRes()          {}
+func (*InternalServerError) listOrdersRes()            {}
+func (*InternalServerError) listPromoCodesRes()        {}
+func (*InternalServerError) payOrderRes()              {}
+func (*InternalServerError) putPromoCodeRes()          {}
+func (*InternalServerError) refundOrderRes()           {}
+func (*InternalServerError) updateOrderFulfilmentRes() {}
+
+// Ref: #
+type ListOrdersResponse struct {
+	// Заказы текущей страницы.
+	Orders []OrderDto `json:"orders"`
+	// Курсор следующей страницы, отсутствует на последней
+	// странице.
+	NextPageToken OptString `json:"next_page_token"`
+}
+
+// GetOrders returns the value of Orders.
+func (s *ListOrdersResponse) GetOrders() []OrderDto {
+	return s.Orders
+}
+
+// GetNextPageToken returns the value of NextPageToken.
+func (s *ListOrdersResponse) GetNextPageToken() OptString {
+	return s.NextPageToken
+}
+
+// SetOrders sets the value of Orders.
+func (s *ListOrdersResponse) SetOrders(val []OrderDto) {
+	s.Orders = val
+}
+
+// SetNextPageToken sets the value of NextPageToken.
+func (s *ListOrdersResponse) SetNextPageToken(val OptString) {
+	s.NextPageToken = val
+}
+
+func (*ListOrdersResponse) listOrdersRes() {}
+
+// Ref: #
+type ListPromoCodesResponse struct {
+	// Промокоды, упорядоченные по коду.
+	PromoCodes []PromoCodeDto `json:"promo_codes"`
+}
+
+// GetPromoCodes returns the value of PromoCodes.
+func (s *ListPromoCodesResponse) GetPromoCodes() []PromoCodeDto {
+	return s.PromoCodes
+}
+
+// SetPromoCodes sets the value of PromoCodes.
+func (s *ListPromoCodesResponse) SetPromoCodes(val []PromoCodeDto) {
+	s.PromoCodes = val
+}
+
+func (*ListPromoCodesResponse) listPromoCodesRes() {}
 
 // Ref: #
 type NotFoundError struct {
@@ -251,43 +615,48 @@ func (s *NotFoundError) SetMessage(val string) {
 	s.Message = val
 }
 
-func (*NotFoundError) cancelOrderRes()    {}
-func (*NotFoundError) createOrderRes()    {}
-func (*NotFoundError) getOrderByUUIDRes() {}
-func (*NotFoundError) payOrderRes()       {}
+func (*NotFoundError) cancelOrderRes()           {}
+func (*NotFoundError) createOrderRes()           {}
+func (*NotFoundError) deletePromoCodeRes()       {}
+func (*NotFoundError) getOrderByUUIDRes()        {}
+func (*NotFoundError) getOrderHistoryRes()       {}
+func (*NotFoundError) getPromoCodeRes()          {}
+func (*NotFoundError) payOrderRes()              {}
+func (*NotFoundError) refundOrderRes()           {}
+func (*NotFoundError) updateOrderFulfilmentRes() {}
 
-// NewOptInt returns new OptInt with value set to v.
-func NewOptInt(v int) OptInt {
-	return OptInt{
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptInt is optional int.
-type OptInt struct {
-	Value int
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
 	Set   bool
 }
 
-// IsSet returns true if OptInt was set.
-func (o OptInt) IsSet() bool { return o.Set }
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptInt) Reset() {
-	var v int
+func (o *OptBool) Reset() {
+	var v bool
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptInt) SetTo(v int) {
+func (o *OptBool) SetTo(v bool) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptInt) Get() (v int, ok bool) {
+func (o OptBool) Get() (v bool, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -295,45 +664,45 @@ func (o OptInt) Get() (v int, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptInt) Or(d int) int {
+func (o OptBool) Or(d bool) bool {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptPaymentMethod returns new OptPaymentMethod with value set to v.
-func NewOptPaymentMethod(v PaymentMethod) OptPaymentMethod {
-	return OptPaymentMethod{
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptPaymentMethod is optional PaymentMethod.
-type OptPaymentMethod struct {
-	Value PaymentMethod
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
 	Set   bool
 }
 
-// IsSet returns true if OptPaymentMethod was set.
-func (o OptPaymentMethod) IsSet() bool { return o.Set }
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptPaymentMethod) Reset() {
-	var v PaymentMethod
+func (o *OptDateTime) Reset() {
+	var v time.Time
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptPaymentMethod) SetTo(v PaymentMethod) {
+func (o *OptDateTime) SetTo(v time.Time) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptPaymentMethod) Get() (v PaymentMethod, ok bool) {
+func (o OptDateTime) Get() (v time.Time, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -341,45 +710,45 @@ func (o OptPaymentMethod) Get() (v PaymentMethod, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptPaymentMethod) Or(d PaymentMethod) PaymentMethod {
+func (o OptDateTime) Or(d time.Time) time.Time {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptString returns new OptString with value set to v.
-func NewOptString(v string) OptString {
-	return OptString{
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptString is optional string.
-type OptString struct {
-	Value string
+// OptInt is optional int.
+type OptInt struct {
+	Value int
 	Set   bool
 }
 
-// IsSet returns true if OptString was set.
-func (o OptString) IsSet() bool { return o.Set }
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptString) Reset() {
-	var v string
+func (o *OptInt) Reset() {
+	var v int
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptString) SetTo(v string) {
+func (o *OptInt) SetTo(v int) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptString) Get() (v string, ok bool) {
+func (o OptInt) Get() (v int, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -387,67 +756,544 @@ func (o OptString) Get() (v string, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptString) Or(d string) string {
+func (o OptInt) Or(d int) int {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// Ref: #
-type OrderDto struct {
-	// Уникальный идентификатор заказа.
-	OrderUUID string `json:"order_uuid"`
-	// UUID пользователя.
-	UserUUID string `json:"user_uuid"`
-	// Список UUID деталей.
-	PartUuids []string `json:"part_uuids"`
-	// Итоговая стоимость.
-	TotalPrice float64 `json:"total_price"`
-	// UUID транзакции (если оплачен).
-	TransactionUUID OptString        `json:"transaction_uuid"`
-	PaymentMethod   OptPaymentMethod `json:"payment_method"`
-	Status          OrderStatus      `json:"status"`
+// NewOptInt32 returns new OptInt32 with value set to v.
+func NewOptInt32(v int32) OptInt32 {
+	return OptInt32{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// GetOrderUUID returns the value of OrderUUID.
-func (s *OrderDto) GetOrderUUID() string {
-	return s.OrderUUID
+// OptInt32 is optional int32.
+type OptInt32 struct {
+	Value int32
+	Set   bool
 }
 
-// GetUserUUID returns the value of UserUUID.
-func (s *OrderDto) GetUserUUID() string {
-	return s.UserUUID
-}
+// IsSet returns true if OptInt32 was set.
+func (o OptInt32) IsSet() bool { return o.Set }
 
-// GetPartUuids returns the value of PartUuids.
-func (s *OrderDto) GetPartUuids() []string {
-	return s.PartUuids
+// Reset unsets value.
+func (o *OptInt32) Reset() {
+	var v int32
+	o.Value = v
+	o.Set = false
 }
 
-// GetTotalPrice returns the value of TotalPrice.
-func (s *OrderDto) GetTotalPrice() float64 {
-	return s.TotalPrice
+// SetTo sets value to v.
+func (o *OptInt32) SetTo(v int32) {
+	o.Set = true
+	o.Value = v
 }
 
-// GetTransactionUUID returns the value of TransactionUUID.
-func (s *OrderDto) GetTransactionUUID() OptString {
-	return s.TransactionUUID
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt32) Get() (v int32, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// GetPaymentMethod returns the value of PaymentMethod.
-func (s *OrderDto) GetPaymentMethod() OptPaymentMethod {
-	return s.PaymentMethod
+// Or returns value if set, or given parameter if does not.
+func (o OptInt32) Or(d int32) int32 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// GetStatus returns the value of Status.
-func (s *OrderDto) GetStatus() OrderStatus {
-	return s.Status
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// SetOrderUUID sets the value of OrderUUID.
-func (s *OrderDto) SetOrderUUID(val string) {
-	s.OrderUUID = val
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
+}
+
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptOrderSortField returns new OptOrderSortField with value set to v.
+func NewOptOrderSortField(v OrderSortField) OptOrderSortField {
+	return OptOrderSortField{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptOrderSortField is optional OrderSortField.
+type OptOrderSortField struct {
+	Value OrderSortField
+	Set   bool
+}
+
+// IsSet returns true if OptOrderSortField was set.
+func (o OptOrderSortField) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptOrderSortField) Reset() {
+	var v OrderSortField
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptOrderSortField) SetTo(v OrderSortField) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptOrderSortField) Get() (v OrderSortField, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptOrderSortField) Or(d OrderSortField) OrderSortField {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptOrderStatus returns new OptOrderStatus with value set to v.
+func NewOptOrderStatus(v OrderStatus) OptOrderStatus {
+	return OptOrderStatus{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptOrderStatus is optional OrderStatus.
+type OptOrderStatus struct {
+	Value OrderStatus
+	Set   bool
+}
+
+// IsSet returns true if OptOrderStatus was set.
+func (o OptOrderStatus) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptOrderStatus) Reset() {
+	var v OrderStatus
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptOrderStatus) SetTo(v OrderStatus) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptOrderStatus) Get() (v OrderStatus, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptOrderStatus) Or(d OrderStatus) OrderStatus {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPartCategory returns new OptPartCategory with value set to v.
+func NewOptPartCategory(v PartCategory) OptPartCategory {
+	return OptPartCategory{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPartCategory is optional PartCategory.
+type OptPartCategory struct {
+	Value PartCategory
+	Set   bool
+}
+
+// IsSet returns true if OptPartCategory was set.
+func (o OptPartCategory) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPartCategory) Reset() {
+	var v PartCategory
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPartCategory) SetTo(v PartCategory) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPartCategory) Get() (v PartCategory, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPartCategory) Or(d PartCategory) PartCategory {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPaymentMethod returns new OptPaymentMethod with value set to v.
+func NewOptPaymentMethod(v PaymentMethod) OptPaymentMethod {
+	return OptPaymentMethod{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPaymentMethod is optional PaymentMethod.
+type OptPaymentMethod struct {
+	Value PaymentMethod
+	Set   bool
+}
+
+// IsSet returns true if OptPaymentMethod was set.
+func (o OptPaymentMethod) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPaymentMethod) Reset() {
+	var v PaymentMethod
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPaymentMethod) SetTo(v PaymentMethod) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPaymentMethod) Get() (v PaymentMethod, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPaymentMethod) Or(d PaymentMethod) PaymentMethod {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSortOrder returns new OptSortOrder with value set to v.
+func NewOptSortOrder(v SortOrder) OptSortOrder {
+	return OptSortOrder{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSortOrder is optional SortOrder.
+type OptSortOrder struct {
+	Value SortOrder
+	Set   bool
+}
+
+// IsSet returns true if OptSortOrder was set.
+func (o OptSortOrder) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSortOrder) Reset() {
+	var v SortOrder
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSortOrder) SetTo(v SortOrder) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSortOrder) Get() (v SortOrder, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSortOrder) Or(d SortOrder) SortOrder {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Ref: #
+type OrderDto struct {
+	// Уникальный идентификатор заказа.
+	OrderUUID string `json:"order_uuid"`
+	// UUID пользователя.
+	UserUUID string `json:"user_uuid"`
+	// Позиции заказа с количеством деталей и ценой на
+	// момент создания заказа.
+	Items []OrderLine `json:"items"`
+	// Список UUID деталей заказа.
+	PartUuids []string `json:"part_uuids"`
+	// Итоговая стоимость в основных единицах валюты.
+	// Устарело, используйте total_price_minor.
+	//
+	// Deprecated: schema marks this property as deprecated.
+	TotalPrice float64 `json:"total_price"`
+	// Стоимость позиций без скидки в минимальных единицах
+	// валюты.
+	SubtotalMinor int64 `json:"subtotal_minor"`
+	// Сумма скидки по промокоду в минимальных единицах
+	// валюты, 0 без скидки.
+	DiscountMinor int64 `json:"discount_minor"`
+	// Расчетный вес отправления в кг, для габаритных
+	// деталей учитывается объемный вес.
+	ShippingWeightKg float64 `json:"shipping_weight_kg"`
+	// Стоимость доставки в минимальных единицах валюты.
+	ShippingMinor int64 `json:"shipping_minor"`
+	// Ставка НДС страны доставки в сотых долях процента, 2200
+	// — 22%.
+	TaxRateBp int32 `json:"tax_rate_bp"`
+	// Сумма НДС на позиции со скидкой и доставку в
+	// минимальных единицах валюты.
+	TaxMinor int64 `json:"tax_minor"`
+	// Итоговая стоимость позиций со скидкой, доставки и НДС
+	// в минимальных единицах валюты (копейках).
+	TotalPriceMinor int64 `json:"total_price_minor"`
+	// Сумма возвратов по заказу в минимальных единицах
+	// валюты, 0 без возвратов.
+	RefundedMinor int64 `json:"refunded_minor"`
+	// Примененный промокод.
+	PromoCode OptString `json:"promo_code"`
+	// Страна доставки ISO 3166-1 alpha-2.
+	Country string `json:"country"`
+	// Код валюты заказа ISO 4217.
+	Currency string `json:"currency"`
+	// UUID транзакции (если оплачен).
+	TransactionUUID OptString        `json:"transaction_uuid"`
+	PaymentMethod   OptPaymentMethod `json:"payment_method"`
+	Status          OrderStatus      `json:"status"`
+	// Время создания заказа.
+	CreatedAt time.Time `json:"created_at"`
+	// Время последнего изменения заказа.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetOrderUUID returns the value of OrderUUID.
+func (s *OrderDto) GetOrderUUID() string {
+	return s.OrderUUID
+}
+
+// GetUserUUID returns the value of UserUUID.
+func (s *OrderDto) GetUserUUID() string {
+	return s.UserUUID
+}
+
+// GetItems returns the value of Items.
+func (s *OrderDto) GetItems() []OrderLine {
+	return s.Items
+}
+
+// GetPartUuids returns the value of PartUuids.
+func (s *OrderDto) GetPartUuids() []string {
+	return s.PartUuids
+}
+
+// GetTotalPrice returns the value of TotalPrice.
+func (s *OrderDto) GetTotalPrice() float64 {
+	return s.TotalPrice
+}
+
+// GetSubtotalMinor returns the value of SubtotalMinor.
+func (s *OrderDto) GetSubtotalMinor() int64 {
+	return s.SubtotalMinor
+}
+
+// GetDiscountMinor returns the value of DiscountMinor.
+func (s *OrderDto) GetDiscountMinor() int64 {
+	return s.DiscountMinor
+}
+
+// GetShippingWeightKg returns the value of ShippingWeightKg.
+func (s *OrderDto) GetShippingWeightKg() float64 {
+	return s.ShippingWeightKg
+}
+
+// GetShippingMinor returns the value of ShippingMinor.
+func (s *OrderDto) GetShippingMinor() int64 {
+	return s.ShippingMinor
+}
+
+// GetTaxRateBp returns the value of TaxRateBp.
+func (s *OrderDto) GetTaxRateBp() int32 {
+	return s.TaxRateBp
+}
+
+// GetTaxMinor returns the value of TaxMinor.
+func (s *OrderDto) GetTaxMinor() int64 {
+	return s.TaxMinor
+}
+
+// GetTotalPriceMinor returns the value of TotalPriceMinor.
+func (s *OrderDto) GetTotalPriceMinor() int64 {
+	return s.TotalPriceMinor
+}
+
+// GetRefundedMinor returns the value of RefundedMinor.
+func (s *OrderDto) GetRefundedMinor() int64 {
+	return s.RefundedMinor
+}
+
+// GetPromoCode returns the value of PromoCode.
+func (s *OrderDto) GetPromoCode() OptString {
+	return s.PromoCode
+}
+
+// GetCountry returns the value of Country.
+func (s *OrderDto) GetCountry() string {
+	return s.Country
+}
+
+// GetCurrency returns the value of Currency.
+func (s *OrderDto) GetCurrency() string {
+	return s.Currency
+}
+
+// GetTransactionUUID returns the value of TransactionUUID.
+func (s *OrderDto) GetTransactionUUID() OptString {
+	return s.TransactionUUID
+}
+
+// GetPaymentMethod returns the value of PaymentMethod.
+func (s *OrderDto) GetPaymentMethod() OptPaymentMethod {
+	return s.PaymentMethod
+}
+
+// GetStatus returns the value of Status.
+func (s *OrderDto) GetStatus() OrderStatus {
+	return s.Status
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *OrderDto) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *OrderDto) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetOrderUUID sets the value of OrderUUID.
+func (s *OrderDto) SetOrderUUID(val string) {
+	s.OrderUUID = val
 }
 
 // SetUserUUID sets the value of UserUUID.
@@ -455,6 +1301,11 @@ func (s *OrderDto) SetUserUUID(val string) {
 	s.UserUUID = val
 }
 
+// SetItems sets the value of Items.
+func (s *OrderDto) SetItems(val []OrderLine) {
+	s.Items = val
+}
+
 // SetPartUuids sets the value of PartUuids.
 func (s *OrderDto) SetPartUuids(val []string) {
 	s.PartUuids = val
@@ -465,49 +1316,412 @@ func (s *OrderDto) SetTotalPrice(val float64) {
 	s.TotalPrice = val
 }
 
-// SetTransactionUUID sets the value of TransactionUUID.
-func (s *OrderDto) SetTransactionUUID(val OptString) {
-	s.TransactionUUID = val
+// SetSubtotalMinor sets the value of SubtotalMinor.
+func (s *OrderDto) SetSubtotalMinor(val int64) {
+	s.SubtotalMinor = val
+}
+
+// SetDiscountMinor sets the value of DiscountMinor.
+func (s *OrderDto) SetDiscountMinor(val int64) {
+	s.DiscountMinor = val
+}
+
+// SetShippingWeightKg sets the value of ShippingWeightKg.
+func (s *OrderDto) SetShippingWeightKg(val float64) {
+	s.ShippingWeightKg = val
+}
+
+// SetShippingMinor sets the value of ShippingMinor.
+func (s *OrderDto) SetShippingMinor(val int64) {
+	s.ShippingMinor = val
+}
+
+// SetTaxRateBp sets the value of TaxRateBp.
+func (s *OrderDto) SetTaxRateBp(val int32) {
+	s.TaxRateBp = val
+}
+
+// SetTaxMinor sets the value of TaxMinor.
+func (s *OrderDto) SetTaxMinor(val int64) {
+	s.TaxMinor = val
+}
+
+// SetTotalPriceMinor sets the value of TotalPriceMinor.
+func (s *OrderDto) SetTotalPriceMinor(val int64) {
+	s.TotalPriceMinor = val
+}
+
+// SetRefundedMinor sets the value of RefundedMinor.
+func (s *OrderDto) SetRefundedMinor(val int64) {
+	s.RefundedMinor = val
+}
+
+// SetPromoCode sets the value of PromoCode.
+func (s *OrderDto) SetPromoCode(val OptString) {
+	s.PromoCode = val
+}
+
+// SetCountry sets the value of Country.
+func (s *OrderDto) SetCountry(val string) {
+	s.Country = val
+}
+
+// SetCurrency sets the value of Currency.
+func (s *OrderDto) SetCurrency(val string) {
+	s.Currency = val
+}
+
+// SetTransactionUUID sets the value of TransactionUUID.
+func (s *OrderDto) SetTransactionUUID(val OptString) {
+	s.TransactionUUID = val
+}
+
+// SetPaymentMethod sets the value of PaymentMethod.
+func (s *OrderDto) SetPaymentMethod(val OptPaymentMethod) {
+	s.PaymentMethod = val
+}
+
+// SetStatus sets the value of Status.
+func (s *OrderDto) SetStatus(val OrderStatus) {
+	s.Status = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *OrderDto) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *OrderDto) SetUpdatedAt(val time.Time) {
+	s.UpdatedAt = val
+}
+
+func (*OrderDto) getOrderByUUIDRes()        {}
+func (*OrderDto) updateOrderFulfilmentRes() {}
+
+// Ref: #
+type OrderItem struct {
+	// UUID детали.
+	PartUUID string `json:"part_uuid"`
+	// Количество деталей.
+	Quantity int64 `json:"quantity"`
+}
+
+// GetPartUUID returns the value of PartUUID.
+func (s *OrderItem) GetPartUUID() string {
+	return s.PartUUID
+}
+
+// GetQuantity returns the value of Quantity.
+func (s *OrderItem) GetQuantity() int64 {
+	return s.Quantity
+}
+
+// SetPartUUID sets the value of PartUUID.
+func (s *OrderItem) SetPartUUID(val string) {
+	s.PartUUID = val
+}
+
+// SetQuantity sets the value of Quantity.
+func (s *OrderItem) SetQuantity(val int64) {
+	s.Quantity = val
+}
+
+// Ref: #
+type OrderLine struct {
+	// UUID детали.
+	PartUUID string `json:"part_uuid"`
+	// Количество деталей.
+	Quantity int64 `json:"quantity"`
+	// Цена детали за единицу на момент создания заказа в
+	// минимальных единицах валюты заказа. Отсутствует у
+	// заказов, созданных до сохранения цен позиций.
+	UnitPriceMinor OptInt64 `json:"unit_price_minor"`
+	// Скидка по промокоду на позицию в минимальных
+	// единицах валюты заказа, отсутствует без скидки.
+	DiscountMinor OptInt64 `json:"discount_minor"`
+	// Стоимость позиции без скидки в минимальных единицах
+	// валюты заказа. Отсутствует у заказов, созданных до
+	// сохранения цен позиций.
+	LineTotalMinor OptInt64 `json:"line_total_minor"`
+}
+
+// GetPartUUID returns the value of PartUUID.
+func (s *OrderLine) GetPartUUID() string {
+	return s.PartUUID
+}
+
+// GetQuantity returns the value of Quantity.
+func (s *OrderLine) GetQuantity() int64 {
+	return s.Quantity
+}
+
+// GetUnitPriceMinor returns the value of UnitPriceMinor.
+func (s *OrderLine) GetUnitPriceMinor() OptInt64 {
+	return s.UnitPriceMinor
+}
+
+// GetDiscountMinor returns the value of DiscountMinor.
+func (s *OrderLine) GetDiscountMinor() OptInt64 {
+	return s.DiscountMinor
+}
+
+// GetLineTotalMinor returns the value of LineTotalMinor.
+func (s *OrderLine) GetLineTotalMinor() OptInt64 {
+	return s.LineTotalMinor
+}
+
+// SetPartUUID sets the value of PartUUID.
+func (s *OrderLine) SetPartUUID(val string) {
+	s.PartUUID = val
+}
+
+// SetQuantity sets the value of Quantity.
+func (s *OrderLine) SetQuantity(val int64) {
+	s.Quantity = val
+}
+
+// SetUnitPriceMinor sets the value of UnitPriceMinor.
+func (s *OrderLine) SetUnitPriceMinor(val OptInt64) {
+	s.UnitPriceMinor = val
+}
+
+// SetDiscountMinor sets the value of DiscountMinor.
+func (s *OrderLine) SetDiscountMinor(val OptInt64) {
+	s.DiscountMinor = val
+}
+
+// SetLineTotalMinor sets the value of LineTotalMinor.
+func (s *OrderLine) SetLineTotalMinor(val OptInt64) {
+	s.LineTotalMinor = val
+}
+
+// Поле сортировки заказов, при равенстве значений
+// заказы упорядочиваются по UUID.
+// Ref: #
+type OrderSortField string
+
+const (
+	OrderSortFieldCreatedAt  OrderSortField = "created_at"
+	OrderSortFieldUpdatedAt  OrderSortField = "updated_at"
+	OrderSortFieldTotalPrice OrderSortField = "total_price"
+)
+
+// AllValues returns all OrderSortField values.
+func (OrderSortField) AllValues() []OrderSortField {
+	return []OrderSortField{
+		OrderSortFieldCreatedAt,
+		OrderSortFieldUpdatedAt,
+		OrderSortFieldTotalPrice,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s OrderSortField) MarshalText() ([]byte, error) {
+	switch s {
+	case OrderSortFieldCreatedAt:
+		return []byte(s), nil
+	case OrderSortFieldUpdatedAt:
+		return []byte(s), nil
+	case OrderSortFieldTotalPrice:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *OrderSortField) UnmarshalText(data []byte) error {
+	switch OrderSortField(data) {
+	case OrderSortFieldCreatedAt:
+		*s = OrderSortFieldCreatedAt
+		return nil
+	case OrderSortFieldUpdatedAt:
+		*s = OrderSortFieldUpdatedAt
+		return nil
+	case OrderSortFieldTotalPrice:
+		*s = OrderSortFieldTotalPrice
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Статус заказа. Допустимые переходы: PENDING_PAYMENT -> PAID |
+// CANCELLED, PAID -> ASSEMBLING | REFUNDED, ASSEMBLING -> SHIPPED | REFUNDED, SHIPPED -> COMPLETED,
+// COMPLETED -> REFUNDED.
+// Ref: #
+type OrderStatus string
+
+const (
+	OrderStatusPENDINGPAYMENT OrderStatus = "PENDING_PAYMENT"
+	OrderStatusPAID           OrderStatus = "PAID"
+	OrderStatusASSEMBLING     OrderStatus = "ASSEMBLING"
+	OrderStatusSHIPPED        OrderStatus = "SHIPPED"
+	OrderStatusCOMPLETED      OrderStatus = "COMPLETED"
+	OrderStatusCANCELLED      OrderStatus = "CANCELLED"
+	OrderStatusREFUNDED       OrderStatus = "REFUNDED"
+)
+
+// AllValues returns all OrderStatus values.
+func (OrderStatus) AllValues() []OrderStatus {
+	return []OrderStatus{
+		OrderStatusPENDINGPAYMENT,
+		OrderStatusPAID,
+		OrderStatusASSEMBLING,
+		OrderStatusSHIPPED,
+		OrderStatusCOMPLETED,
+		OrderStatusCANCELLED,
+		OrderStatusREFUNDED,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s OrderStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case OrderStatusPENDINGPAYMENT:
+		return []byte(s), nil
+	case OrderStatusPAID:
+		return []byte(s), nil
+	case OrderStatusASSEMBLING:
+		return []byte(s), nil
+	case OrderStatusSHIPPED:
+		return []byte(s), nil
+	case OrderStatusCOMPLETED:
+		return []byte(s), nil
+	case OrderStatusCANCELLED:
+		return []byte(s), nil
+	case OrderStatusREFUNDED:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *OrderStatus) UnmarshalText(data []byte) error {
+	switch OrderStatus(data) {
+	case OrderStatusPENDINGPAYMENT:
+		*s = OrderStatusPENDINGPAYMENT
+		return nil
+	case OrderStatusPAID:
+		*s = OrderStatusPAID
+		return nil
+	case OrderStatusASSEMBLING:
+		*s = OrderStatusASSEMBLING
+		return nil
+	case OrderStatusSHIPPED:
+		*s = OrderStatusSHIPPED
+		return nil
+	case OrderStatusCOMPLETED:
+		*s = OrderStatusCOMPLETED
+		return nil
+	case OrderStatusCANCELLED:
+		*s = OrderStatusCANCELLED
+		return nil
+	case OrderStatusREFUNDED:
+		*s = OrderStatusREFUNDED
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #
+type OrderTransition struct {
+	// Статус до перехода, отсутствует у записи о создании
+	// заказа.
+	FromStatus OptOrderStatus `json:"from_status"`
+	ToStatus   OrderStatus    `json:"to_status"`
+	// Инициатор перехода, например user:<uuid> или system.
+	Actor string `json:"actor"`
+	// Причина перехода.
+	Reason string `json:"reason"`
+	// Время перехода.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetFromStatus returns the value of FromStatus.
+func (s *OrderTransition) GetFromStatus() OptOrderStatus {
+	return s.FromStatus
+}
+
+// GetToStatus returns the value of ToStatus.
+func (s *OrderTransition) GetToStatus() OrderStatus {
+	return s.ToStatus
+}
+
+// GetActor returns the value of Actor.
+func (s *OrderTransition) GetActor() string {
+	return s.Actor
+}
+
+// GetReason returns the value of Reason.
+func (s *OrderTransition) GetReason() string {
+	return s.Reason
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *OrderTransition) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetFromStatus sets the value of FromStatus.
+func (s *OrderTransition) SetFromStatus(val OptOrderStatus) {
+	s.FromStatus = val
+}
+
+// SetToStatus sets the value of ToStatus.
+func (s *OrderTransition) SetToStatus(val OrderStatus) {
+	s.ToStatus = val
 }
 
-// SetPaymentMethod sets the value of PaymentMethod.
-func (s *OrderDto) SetPaymentMethod(val OptPaymentMethod) {
-	s.PaymentMethod = val
+// SetActor sets the value of Actor.
+func (s *OrderTransition) SetActor(val string) {
+	s.Actor = val
 }
 
-// SetStatus sets the value of Status.
-func (s *OrderDto) SetStatus(val OrderStatus) {
-	s.Status = val
+// SetReason sets the value of Reason.
+func (s *OrderTransition) SetReason(val string) {
+	s.Reason = val
 }
 
-func (*OrderDto) getOrderByUUIDRes() {}
+// SetCreatedAt sets the value of CreatedAt.
+func (s *OrderTransition) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
 
+// Категория детали.
 // Ref: #
-type OrderStatus string
+type PartCategory string
 
 const (
-	OrderStatusPENDINGPAYMENT OrderStatus = "PENDING_PAYMENT"
-	OrderStatusPAID           OrderStatus = "PAID"
-	OrderStatusCANCELLED      OrderStatus = "CANCELLED"
+	PartCategoryCATEGORYENGINE   PartCategory = "CATEGORY_ENGINE"
+	PartCategoryCATEGORYFUEL     PartCategory = "CATEGORY_FUEL"
+	PartCategoryCATEGORYPORTHOLE PartCategory = "CATEGORY_PORTHOLE"
+	PartCategoryCATEGORYWING     PartCategory = "CATEGORY_WING"
 )
 
-// AllValues returns all OrderStatus values.
-func (OrderStatus) AllValues() []OrderStatus {
-	return []OrderStatus{
-		OrderStatusPENDINGPAYMENT,
-		OrderStatusPAID,
-		OrderStatusCANCELLED,
+// AllValues returns all PartCategory values.
+func (PartCategory) AllValues() []PartCategory {
+	return []PartCategory{
+		PartCategoryCATEGORYENGINE,
+		PartCategoryCATEGORYFUEL,
+		PartCategoryCATEGORYPORTHOLE,
+		PartCategoryCATEGORYWING,
 	}
 }
 
 // MarshalText implements encoding.TextMarshaler.
-func (s OrderStatus) MarshalText() ([]byte, error) {
+func (s PartCategory) MarshalText() ([]byte, error) {
 	switch s {
-	case OrderStatusPENDINGPAYMENT:
+	case PartCategoryCATEGORYENGINE:
 		return []byte(s), nil
-	case OrderStatusPAID:
+	case PartCategoryCATEGORYFUEL:
 		return []byte(s), nil
-	case OrderStatusCANCELLED:
+	case PartCategoryCATEGORYPORTHOLE:
+		return []byte(s), nil
+	case PartCategoryCATEGORYWING:
 		return []byte(s), nil
 	default:
 		return nil, errors.Errorf("invalid value: %q", s)
@@ -515,16 +1729,19 @@ func (s OrderStatus) MarshalText() ([]byte, error) {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (s *OrderStatus) UnmarshalText(data []byte) error {
-	switch OrderStatus(data) {
-	case OrderStatusPENDINGPAYMENT:
-		*s = OrderStatusPENDINGPAYMENT
+func (s *PartCategory) UnmarshalText(data []byte) error {
+	switch PartCategory(data) {
+	case PartCategoryCATEGORYENGINE:
+		*s = PartCategoryCATEGORYENGINE
 		return nil
-	case OrderStatusPAID:
-		*s = OrderStatusPAID
+	case PartCategoryCATEGORYFUEL:
+		*s = PartCategoryCATEGORYFUEL
 		return nil
-	case OrderStatusCANCELLED:
-		*s = OrderStatusCANCELLED
+	case PartCategoryCATEGORYPORTHOLE:
+		*s = PartCategoryCATEGORYPORTHOLE
+		return nil
+	case PartCategoryCATEGORYWING:
+		*s = PartCategoryCATEGORYWING
 		return nil
 	default:
 		return errors.Errorf("invalid value: %q", data)
@@ -626,3 +1843,497 @@ func (s *PaymentMethod) UnmarshalText(data []byte) error {
 		return errors.Errorf("invalid value: %q", data)
 	}
 }
+
+// Ref: #
+type PaymentRequiredError struct {
+	// HTTP-код ошибки.
+	Code int `json:"code"`
+	// Описание ошибки.
+	Message string `json:"message"`
+}
+
+// GetCode returns the value of Code.
+func (s *PaymentRequiredError) GetCode() int {
+	return s.Code
+}
+
+// GetMessage returns the value of Message.
+func (s *PaymentRequiredError) GetMessage() string {
+	return s.Message
+}
+
+// SetCode sets the value of Code.
+func (s *PaymentRequiredError) SetCode(val int) {
+	s.Code = val
+}
+
+// SetMessage sets the value of Message.
+func (s *PaymentRequiredError) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*PaymentRequiredError) payOrderRes()    {}
+func (*PaymentRequiredError) refundOrderRes() {}
+
+// Ref: #
+type PromoCodeDto struct {
+	// Промокод.
+	Code         string       `json:"code"`
+	DiscountType DiscountType `json:"discount_type"`
+	// Процент скидки для типа PERCENTAGE.
+	Percent OptInt32 `json:"percent"`
+	// Сумма скидки для типа FIXED в минимальных единицах
+	// валюты.
+	AmountMinor OptInt64 `json:"amount_minor"`
+	// Код валюты ISO 4217 суммы скидки для типа FIXED.
+	Currency OptString       `json:"currency"`
+	Category OptPartCategory `json:"category"`
+	// Сколько раз один пользователь может применить
+	// промокод, 0 — без ограничений.
+	MaxUsesPerUser int32 `json:"max_uses_per_user"`
+	// Начало действия промокода.
+	StartsAt OptDateTime `json:"starts_at"`
+	// Окончание действия промокода.
+	ExpiresAt OptDateTime `json:"expires_at"`
+	// Промокод включен.
+	Active bool `json:"active"`
+	// Время создания промокода.
+	CreatedAt time.Time `json:"created_at"`
+	// Время последнего изменения промокода.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetCode returns the value of Code.
+func (s *PromoCodeDto) GetCode() string {
+	return s.Code
+}
+
+// GetDiscountType returns the value of DiscountType.
+func (s *PromoCodeDto) GetDiscountType() DiscountType {
+	return s.DiscountType
+}
+
+// GetPercent returns the value of Percent.
+func (s *PromoCodeDto) GetPercent() OptInt32 {
+	return s.Percent
+}
+
+// GetAmountMinor returns the value of AmountMinor.
+func (s *PromoCodeDto) GetAmountMinor() OptInt64 {
+	return s.AmountMinor
+}
+
+// GetCurrency returns the value of Currency.
+func (s *PromoCodeDto) GetCurrency() OptString {
+	return s.Currency
+}
+
+// GetCategory returns the value of Category.
+func (s *PromoCodeDto) GetCategory() OptPartCategory {
+	return s.Category
+}
+
+// GetMaxUsesPerUser returns the value of MaxUsesPerUser.
+func (s *PromoCodeDto) GetMaxUsesPerUser() int32 {
+	return s.MaxUsesPerUser
+}
+
+// GetStartsAt returns the value of StartsAt.
+func (s *PromoCodeDto) GetStartsAt() OptDateTime {
+	return s.StartsAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *PromoCodeDto) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// GetActive returns the value of Active.
+func (s *PromoCodeDto) GetActive() bool {
+	return s.Active
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PromoCodeDto) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetUpdatedAt returns the value of UpdatedAt.
+func (s *PromoCodeDto) GetUpdatedAt() time.Time {
+	return s.UpdatedAt
+}
+
+// SetCode sets the value of Code.
+func (s *PromoCodeDto) SetCode(val string) {
+	s.Code = val
+}
+
+// SetDiscountType sets the value of DiscountType.
+func (s *PromoCodeDto) SetDiscountType(val DiscountType) {
+	s.DiscountType = val
+}
+
+// SetPercent sets the value of Percent.
+func (s *PromoCodeDto) SetPercent(val OptInt32) {
+	s.Percent = val
+}
+
+// SetAmountMinor sets the value of AmountMinor.
+func (s *PromoCodeDto) SetAmountMinor(val OptInt64) {
+	s.AmountMinor = val
+}
+
+// SetCurrency sets the value of Currency.
+func (s *PromoCodeDto) SetCurrency(val OptString) {
+	s.Currency = val
+}
+
+// SetCategory sets the value of Category.
+func (s *PromoCodeDto) SetCategory(val OptPartCategory) {
+	s.Category = val
+}
+
+// SetMaxUsesPerUser sets the value of MaxUsesPerUser.
+func (s *PromoCodeDto) SetMaxUsesPerUser(val int32) {
+	s.MaxUsesPerUser = val
+}
+
+// SetStartsAt sets the value of StartsAt.
+func (s *PromoCodeDto) SetStartsAt(val OptDateTime) {
+	s.StartsAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *PromoCodeDto) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// SetActive sets the value of Active.
+func (s *PromoCodeDto) SetActive(val bool) {
+	s.Active = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PromoCodeDto) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetUpdatedAt sets the value of UpdatedAt.
+func (s *PromoCodeDto) SetUpdatedAt(val time.Time) {
+	s.UpdatedAt = val
+}
+
+func (*PromoCodeDto) getPromoCodeRes() {}
+func (*PromoCodeDto) putPromoCodeRes() {}
+
+// Ref: #
+type PromoCodeRequest struct {
+	DiscountType DiscountType `json:"discount_type"`
+	// Процент скидки для типа PERCENTAGE.
+	Percent OptInt32 `json:"percent"`
+	// Сумма скидки для типа FIXED в минимальных единицах
+	// валюты.
+	AmountMinor OptInt64 `json:"amount_minor"`
+	// Код валюты ISO 4217 суммы скидки, обязателен для типа FIXED.
+	Currency OptString       `json:"currency"`
+	Category OptPartCategory `json:"category"`
+	// Сколько раз один пользователь может применить
+	// промокод, 0 — без ограничений.
+	MaxUsesPerUser OptInt32 `json:"max_uses_per_user"`
+	// Начало действия промокода, не задано — действует
+	// сразу.
+	StartsAt OptDateTime `json:"starts_at"`
+	// Окончание действия промокода, не задано — бессрочный.
+	ExpiresAt OptDateTime `json:"expires_at"`
+	// Промокод включен.
+	Active OptBool `json:"active"`
+}
+
+// GetDiscountType returns the value of DiscountType.
+func (s *PromoCodeRequest) GetDiscountType() DiscountType {
+	return s.DiscountType
+}
+
+// GetPercent returns the value of Percent.
+func (s *PromoCodeRequest) GetPercent() OptInt32 {
+	return s.Percent
+}
+
+// GetAmountMinor returns the value of AmountMinor.
+func (s *PromoCodeRequest) GetAmountMinor() OptInt64 {
+	return s.AmountMinor
+}
+
+// GetCurrency returns the value of Currency.
+func (s *PromoCodeRequest) GetCurrency() OptString {
+	return s.Currency
+}
+
+// GetCategory returns the value of Category.
+func (s *PromoCodeRequest) GetCategory() OptPartCategory {
+	return s.Category
+}
+
+// GetMaxUsesPerUser returns the value of MaxUsesPerUser.
+func (s *PromoCodeRequest) GetMaxUsesPerUser() OptInt32 {
+	return s.MaxUsesPerUser
+}
+
+// GetStartsAt returns the value of StartsAt.
+func (s *PromoCodeRequest) GetStartsAt() OptDateTime {
+	return s.StartsAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *PromoCodeRequest) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// GetActive returns the value of Active.
+func (s *PromoCodeRequest) GetActive() OptBool {
+	return s.Active
+}
+
+// SetDiscountType sets the value of DiscountType.
+func (s *PromoCodeRequest) SetDiscountType(val DiscountType) {
+	s.DiscountType = val
+}
+
+// SetPercent sets the value of Percent.
+func (s *PromoCodeRequest) SetPercent(val OptInt32) {
+	s.Percent = val
+}
+
+// SetAmountMinor sets the value of AmountMinor.
+func (s *PromoCodeRequest) SetAmountMinor(val OptInt64) {
+	s.AmountMinor = val
+}
+
+// SetCurrency sets the value of Currency.
+func (s *PromoCodeRequest) SetCurrency(val OptString) {
+	s.Currency = val
+}
+
+// SetCategory sets the value of Category.
+func (s *PromoCodeRequest) SetCategory(val OptPartCategory) {
+	s.Category = val
+}
+
+// SetMaxUsesPerUser sets the value of MaxUsesPerUser.
+func (s *PromoCodeRequest) SetMaxUsesPerUser(val OptInt32) {
+	s.MaxUsesPerUser = val
+}
+
+// SetStartsAt sets the value of StartsAt.
+func (s *PromoCodeRequest) SetStartsAt(val OptDateTime) {
+	s.StartsAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *PromoCodeRequest) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// SetActive sets the value of Active.
+func (s *PromoCodeRequest) SetActive(val OptBool) {
+	s.Active = val
+}
+
+// Ref: #
+type RefundOrderRequest struct {
+	// Сумма возврата в минимальных единицах валюты заказа,
+	// без указания возвращается весь остаток.
+	AmountMinor OptInt64 `json:"amount_minor"`
+	// Причина возврата.
+	Reason OptString `json:"reason"`
+}
+
+// GetAmountMinor returns the value of AmountMinor.
+func (s *RefundOrderRequest) GetAmountMinor() OptInt64 {
+	return s.AmountMinor
+}
+
+// GetReason returns the value of Reason.
+func (s *RefundOrderRequest) GetReason() OptString {
+	return s.Reason
+}
+
+// SetAmountMinor sets the value of AmountMinor.
+func (s *RefundOrderRequest) SetAmountMinor(val OptInt64) {
+	s.AmountMinor = val
+}
+
+// SetReason sets the value of Reason.
+func (s *RefundOrderRequest) SetReason(val OptString) {
+	s.Reason = val
+}
+
+// Ref: #
+type RefundOrderResponse struct {
+	// UUID возврата.
+	RefundUUID string `json:"refund_uuid"`
+	// Сумма проведенного возврата в минимальных единицах
+	// валюты.
+	AmountMinor int64 `json:"amount_minor"`
+	// Сумма всех возвратов по заказу в минимальных
+	// единицах валюты.
+	RefundedMinor int64 `json:"refunded_minor"`
+	// Сумма заказа, которую еще можно вернуть, в
+	// минимальных единицах валюты.
+	RemainingMinor int64       `json:"remaining_minor"`
+	Status         OrderStatus `json:"status"`
+}
+
+// GetRefundUUID returns the value of RefundUUID.
+func (s *RefundOrderResponse) GetRefundUUID() string {
+	return s.RefundUUID
+}
+
+// GetAmountMinor returns the value of AmountMinor.
+func (s *RefundOrderResponse) GetAmountMinor() int64 {
+	return s.AmountMinor
+}
+
+// GetRefundedMinor returns the value of RefundedMinor.
+func (s *RefundOrderResponse) GetRefundedMinor() int64 {
+	return s.RefundedMinor
+}
+
+// GetRemainingMinor returns the value of RemainingMinor.
+func (s *RefundOrderResponse) GetRemainingMinor() int64 {
+	return s.RemainingMinor
+}
+
+// GetStatus returns the value of Status.
+func (s *RefundOrderResponse) GetStatus() OrderStatus {
+	return s.Status
+}
+
+// SetRefundUUID sets the value of RefundUUID.
+func (s *RefundOrderResponse) SetRefundUUID(val string) {
+	s.RefundUUID = val
+}
+
+// SetAmountMinor sets the value of AmountMinor.
+func (s *RefundOrderResponse) SetAmountMinor(val int64) {
+	s.AmountMinor = val
+}
+
+// SetRefundedMinor sets the value of RefundedMinor.
+func (s *RefundOrderResponse) SetRefundedMinor(val int64) {
+	s.RefundedMinor = val
+}
+
+// SetRemainingMinor sets the value of RemainingMinor.
+func (s *RefundOrderResponse) SetRemainingMinor(val int64) {
+	s.RemainingMinor = val
+}
+
+// SetStatus sets the value of Status.
+func (s *RefundOrderResponse) SetStatus(val OrderStatus) {
+	s.Status = val
+}
+
+func (*RefundOrderResponse) refundOrderRes() {}
+
+// Направление сортировки.
+// Ref: #
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// AllValues returns all SortOrder values.
+func (SortOrder) AllValues() []SortOrder {
+	return []SortOrder{
+		SortOrderAsc,
+		SortOrderDesc,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s SortOrder) MarshalText() ([]byte, error) {
+	switch s {
+	case SortOrderAsc:
+		return []byte(s), nil
+	case SortOrderDesc:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *SortOrder) UnmarshalText(data []byte) error {
+	switch SortOrder(data) {
+	case SortOrderAsc:
+		*s = SortOrderAsc
+		return nil
+	case SortOrderDesc:
+		*s = SortOrderDesc
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #
+type UnprocessableEntityError struct {
+	// HTTP-код ошибки.
+	Code int `json:"code"`
+	// Описание ошибки.
+	Message string `json:"message"`
+}
+
+// GetCode returns the value of Code.
+func (s *UnprocessableEntityError) GetCode() int {
+	return s.Code
+}
+
+// GetMessage returns the value of Message.
+func (s *UnprocessableEntityError) GetMessage() string {
+	return s.Message
+}
+
+// SetCode sets the value of Code.
+func (s *UnprocessableEntityError) SetCode(val int) {
+	s.Code = val
+}
+
+// SetMessage sets the value of Message.
+func (s *UnprocessableEntityError) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*UnprocessableEntityError) createOrderRes() {}
+func (*UnprocessableEntityError) payOrderRes()    {}
+func (*UnprocessableEntityError) refundOrderRes() {}
+
+// Ref: #
+type UpdateOrderFulfilmentRequest struct {
+	Status FulfilmentStatus `json:"status"`
+	// Комментарий к переходу, сохраняется в истории заказа.
+	Reason OptString `json:"reason"`
+}
+
+// GetStatus returns the value of Status.
+func (s *UpdateOrderFulfilmentRequest) GetStatus() FulfilmentStatus {
+	return s.Status
+}
+
+// GetReason returns the value of Reason.
+func (s *UpdateOrderFulfilmentRequest) GetReason() OptString {
+	return s.Reason
+}
+
+// SetStatus sets the value of Status.
+func (s *UpdateOrderFulfilmentRequest) SetStatus(val FulfilmentStatus) {
+	s.Status = val
+}
+
+// SetReason sets the value of Reason.
+func (s *UpdateOrderFulfilmentRequest) SetReason(val OptString) {
+	s.Reason = val
+}
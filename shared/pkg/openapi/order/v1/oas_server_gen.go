@@ -19,19 +19,78 @@ type Handler interface {
 	// Создание заказа.
 	//
 	// POST /api/v1/orders
-	CreateOrder(ctx context.Context, req *CreateOrderRequest) (CreateOrderRes, error)
+	CreateOrder(ctx context.Context, req *CreateOrderRequest, params CreateOrderParams) (CreateOrderRes, error)
+	// DeletePromoCode implements DeletePromoCode operation.
+	//
+	// Удаление промокода.
+	//
+	// DELETE /api/v1/admin/promo-codes/{code}
+	DeletePromoCode(ctx context.Context, params DeletePromoCodeParams) (DeletePromoCodeRes, error)
 	// GetOrderByUUID implements GetOrderByUUID operation.
 	//
 	// Получение заказа по UUID.
 	//
 	// GET /api/v1/orders/{order_uuid}
 	GetOrderByUUID(ctx context.Context, params GetOrderByUUIDParams) (GetOrderByUUIDRes, error)
+	// GetOrderHistory implements GetOrderHistory operation.
+	//
+	// Получение истории статусов заказа.
+	//
+	// GET /api/v1/orders/{order_uuid}/history
+	GetOrderHistory(ctx context.Context, params GetOrderHistoryParams) (GetOrderHistoryRes, error)
+	// GetPromoCode implements GetPromoCode operation.
+	//
+	// Получение промокода.
+	//
+	// GET /api/v1/admin/promo-codes/{code}
+	GetPromoCode(ctx context.Context, params GetPromoCodeParams) (GetPromoCodeRes, error)
+	// ListOrders implements ListOrders operation.
+	//
+	// Возвращает заказы, подходящие под все переданные
+	// фильтры, с курсорной пагинацией. Для получения
+	// следующей страницы передается next_page_token из
+	// предыдущего ответа.
+	//
+	// GET /api/v1/orders
+	ListOrders(ctx context.Context, params ListOrdersParams) (ListOrdersRes, error)
+	// ListPromoCodes implements ListPromoCodes operation.
+	//
+	// Список промокодов.
+	//
+	// GET /api/v1/admin/promo-codes
+	ListPromoCodes(ctx context.Context) (ListPromoCodesRes, error)
 	// PayOrder implements PayOrder operation.
 	//
 	// Оплата заказа.
 	//
 	// POST /api/v1/orders/{order_uuid}/pay
 	PayOrder(ctx context.Context, req *PayOrderRequest, params PayOrderParams) (PayOrderRes, error)
+	// PutPromoCode implements PutPromoCode operation.
+	//
+	// Создает промокод или полностью заменяет параметры
+	// существующего. Изменение не затрагивает уже
+	// созданные заказы, скидка в них зафиксирована.
+	//
+	// PUT /api/v1/admin/promo-codes/{code}
+	PutPromoCode(ctx context.Context, req *PromoCodeRequest, params PutPromoCodeParams) (PutPromoCodeRes, error)
+	// RefundOrder implements RefundOrder operation.
+	//
+	// Возвращает покупателю всю невозвращенную сумму
+	// заказа или ее часть. После возврата всей суммы заказ
+	// переходит в статус REFUNDED, а детали заказа возвращаются
+	// на склад.
+	//
+	// POST /api/v1/orders/{order_uuid}/refund
+	RefundOrder(ctx context.Context, req *RefundOrderRequest, params RefundOrderParams) (RefundOrderRes, error)
+	// UpdateOrderFulfilment implements UpdateOrderFulfilment operation.
+	//
+	// Переводит оплаченный заказ на следующий этап
+	// выполнения: PAID -> ASSEMBLING -> SHIPPED -> COMPLETED. Повтор перехода в
+	// текущий статус заказа не меняет заказ и возвращает
+	// его.
+	//
+	// POST /api/v1/orders/{order_uuid}/fulfilment
+	UpdateOrderFulfilment(ctx context.Context, req *UpdateOrderFulfilmentRequest, params UpdateOrderFulfilmentParams) (UpdateOrderFulfilmentRes, error)
 	// NewError creates *GenericErrorStatusCode from error returned by handler.
 	//
 	// Used for common default response.
@@ -27,7 +27,16 @@ func (UnimplementedHandler) CancelOrder(ctx context.Context, params CancelOrderP
 // Создание заказа.
 //
 // POST /api/v1/orders
-func (UnimplementedHandler) CreateOrder(ctx context.Context, req *CreateOrderRequest) (r CreateOrderRes, _ error) {
+func (UnimplementedHandler) CreateOrder(ctx context.Context, req *CreateOrderRequest, params CreateOrderParams) (r CreateOrderRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeletePromoCode implements DeletePromoCode operation.
+//
+// Удаление промокода.
+//
+// DELETE /api/v1/admin/promo-codes/{code}
+func (UnimplementedHandler) DeletePromoCode(ctx context.Context, params DeletePromoCodeParams) (r DeletePromoCodeRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -40,6 +49,45 @@ func (UnimplementedHandler) GetOrderByUUID(ctx context.Context, params GetOrderB
 	return r, ht.ErrNotImplemented
 }
 
+// GetOrderHistory implements GetOrderHistory operation.
+//
+// Получение истории статусов заказа.
+//
+// GET /api/v1/orders/{order_uuid}/history
+func (UnimplementedHandler) GetOrderHistory(ctx context.Context, params GetOrderHistoryParams) (r GetOrderHistoryRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetPromoCode implements GetPromoCode operation.
+//
+// Получение промокода.
+//
+// GET /api/v1/admin/promo-codes/{code}
+func (UnimplementedHandler) GetPromoCode(ctx context.Context, params GetPromoCodeParams) (r GetPromoCodeRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListOrders implements ListOrders operation.
+//
+// Возвращает заказы, подходящие под все переданные
+// фильтры, с курсорной пагинацией. Для получения
+// следующей страницы передается next_page_token из
+// предыдущего ответа.
+//
+// GET /api/v1/orders
+func (UnimplementedHandler) ListOrders(ctx context.Context, params ListOrdersParams) (r ListOrdersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListPromoCodes implements ListPromoCodes operation.
+//
+// Список промокодов.
+//
+// GET /api/v1/admin/promo-codes
+func (UnimplementedHandler) ListPromoCodes(ctx context.Context) (r ListPromoCodesRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // PayOrder implements PayOrder operation.
 //
 // Оплата заказа.
@@ -49,6 +97,41 @@ func (UnimplementedHandler) PayOrder(ctx context.Context, req *PayOrderRequest,
 	return r, ht.ErrNotImplemented
 }
 
+// PutPromoCode implements PutPromoCode operation.
+//
+// Создает промокод или полностью заменяет параметры
+// существующего. Изменение не затрагивает уже
+// созданные заказы, скидка в них зафиксирована.
+//
+// PUT /api/v1/admin/promo-codes/{code}
+func (UnimplementedHandler) PutPromoCode(ctx context.Context, req *PromoCodeRequest, params PutPromoCodeParams) (r PutPromoCodeRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RefundOrder implements RefundOrder operation.
+//
+// Возвращает покупателю всю невозвращенную сумму
+// заказа или ее часть. После возврата всей суммы заказ
+// переходит в статус REFUNDED, а детали заказа возвращаются
+// на склад.
+//
+// POST /api/v1/orders/{order_uuid}/refund
+func (UnimplementedHandler) RefundOrder(ctx context.Context, req *RefundOrderRequest, params RefundOrderParams) (r RefundOrderRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UpdateOrderFulfilment implements UpdateOrderFulfilment operation.
+//
+// Переводит оплаченный заказ на следующий этап
+// выполнения: PAID -> ASSEMBLING -> SHIPPED -> COMPLETED. Повтор перехода в
+// текущий статус заказа не меняет заказ и возвращает
+// его.
+//
+// POST /api/v1/orders/{order_uuid}/fulfilment
+func (UnimplementedHandler) UpdateOrderFulfilment(ctx context.Context, req *UpdateOrderFulfilmentRequest, params UpdateOrderFulfilmentParams) (r UpdateOrderFulfilmentRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // NewError creates *GenericErrorStatusCode from error returned by handler.
 //
 // Used for common default response.
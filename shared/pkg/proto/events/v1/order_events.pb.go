@@ -0,0 +1,481 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: events/v1/order_events.proto
+
+// Package events.v1 содержит схемы доменных событий, которые сервисы публикуют в брокер сообщений
+
+package events_v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OrderEventType тип события заказа
+type OrderEventType int32
+
+const (
+	// UNSPECIFIED тип не задан
+	OrderEventType_ORDER_EVENT_TYPE_UNSPECIFIED OrderEventType = 0
+	// CREATED заказ создан и ожидает оплаты
+	OrderEventType_ORDER_EVENT_TYPE_CREATED OrderEventType = 1
+	// PAID заказ оплачен
+	OrderEventType_ORDER_EVENT_TYPE_PAID OrderEventType = 2
+	// CANCELLED заказ отменен до оплаты
+	OrderEventType_ORDER_EVENT_TYPE_CANCELLED OrderEventType = 3
+	// REFUNDED по заказу возвращена вся сумма
+	OrderEventType_ORDER_EVENT_TYPE_REFUNDED OrderEventType = 4
+	// STATUS_CHANGED заказ перешел в другой статус выполнения: сборка, отгрузка, завершение
+	OrderEventType_ORDER_EVENT_TYPE_STATUS_CHANGED OrderEventType = 5
+)
+
+// Enum value maps for OrderEventType.
+var (
+	OrderEventType_name = map[int32]string{
+		0: "ORDER_EVENT_TYPE_UNSPECIFIED",
+		1: "ORDER_EVENT_TYPE_CREATED",
+		2: "ORDER_EVENT_TYPE_PAID",
+		3: "ORDER_EVENT_TYPE_CANCELLED",
+		4: "ORDER_EVENT_TYPE_REFUNDED",
+		5: "ORDER_EVENT_TYPE_STATUS_CHANGED",
+	}
+	OrderEventType_value = map[string]int32{
+		"ORDER_EVENT_TYPE_UNSPECIFIED":    0,
+		"ORDER_EVENT_TYPE_CREATED":        1,
+		"ORDER_EVENT_TYPE_PAID":           2,
+		"ORDER_EVENT_TYPE_CANCELLED":      3,
+		"ORDER_EVENT_TYPE_REFUNDED":       4,
+		"ORDER_EVENT_TYPE_STATUS_CHANGED": 5,
+	}
+)
+
+func (x OrderEventType) Enum() *OrderEventType {
+	p := new(OrderEventType)
+	*p = x
+	return p
+}
+
+func (x OrderEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_v1_order_events_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderEventType) Type() protoreflect.EnumType {
+	return &file_events_v1_order_events_proto_enumTypes[0]
+}
+
+func (x OrderEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderEventType.Descriptor instead.
+func (OrderEventType) EnumDescriptor() ([]byte, []int) {
+	return file_events_v1_order_events_proto_rawDescGZIP(), []int{0}
+}
+
+// OrderStatus статус заказа
+type OrderStatus int32
+
+const (
+	// UNSPECIFIED статус не задан, используется в from_status события о создании заказа
+	OrderStatus_ORDER_STATUS_UNSPECIFIED     OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING_PAYMENT OrderStatus = 1
+	OrderStatus_ORDER_STATUS_PAID            OrderStatus = 2
+	OrderStatus_ORDER_STATUS_ASSEMBLING      OrderStatus = 3
+	OrderStatus_ORDER_STATUS_SHIPPED         OrderStatus = 4
+	OrderStatus_ORDER_STATUS_COMPLETED       OrderStatus = 5
+	OrderStatus_ORDER_STATUS_CANCELLED       OrderStatus = 6
+	OrderStatus_ORDER_STATUS_REFUNDED        OrderStatus = 7
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_PENDING_PAYMENT",
+		2: "ORDER_STATUS_PAID",
+		3: "ORDER_STATUS_ASSEMBLING",
+		4: "ORDER_STATUS_SHIPPED",
+		5: "ORDER_STATUS_COMPLETED",
+		6: "ORDER_STATUS_CANCELLED",
+		7: "ORDER_STATUS_REFUNDED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED":     0,
+		"ORDER_STATUS_PENDING_PAYMENT": 1,
+		"ORDER_STATUS_PAID":            2,
+		"ORDER_STATUS_ASSEMBLING":      3,
+		"ORDER_STATUS_SHIPPED":         4,
+		"ORDER_STATUS_COMPLETED":       5,
+		"ORDER_STATUS_CANCELLED":       6,
+		"ORDER_STATUS_REFUNDED":        7,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_v1_order_events_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_events_v1_order_events_proto_enumTypes[1]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_events_v1_order_events_proto_rawDescGZIP(), []int{1}
+}
+
+// OrderEvent событие изменения статуса заказа. Публикуется в топик order.events с ключом order_uuid,
+// поэтому события одного заказа попадают в одну партицию. Доставка at-least-once: одно событие может
+// прийти повторно, получатели должны отбрасывать дубликаты по event_uuid
+type OrderEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid UUID события
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// type тип события
+	Type OrderEventType `protobuf:"varint,2,opt,name=type,proto3,enum=events.v1.OrderEventType" json:"type,omitempty"`
+	// order_uuid UUID заказа
+	OrderUuid string `protobuf:"bytes,3,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid UUID пользователя
+	UserUuid string `protobuf:"bytes,4,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// from_status статус до перехода
+	FromStatus OrderStatus `protobuf:"varint,5,opt,name=from_status,json=fromStatus,proto3,enum=events.v1.OrderStatus" json:"from_status,omitempty"`
+	// to_status статус после перехода
+	ToStatus OrderStatus `protobuf:"varint,6,opt,name=to_status,json=toStatus,proto3,enum=events.v1.OrderStatus" json:"to_status,omitempty"`
+	// actor инициатор перехода: user:<uuid> или system
+	Actor string `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	// reason причина перехода
+	Reason string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	// items позиции заказа
+	Items []*OrderEventItem `protobuf:"bytes,9,rep,name=items,proto3" json:"items,omitempty"`
+	// total_price_minor итоговая стоимость заказа в минимальных единицах валюты
+	TotalPriceMinor int64 `protobuf:"varint,10,opt,name=total_price_minor,json=totalPriceMinor,proto3" json:"total_price_minor,omitempty"`
+	// refunded_minor сумма возвратов по заказу в минимальных единицах валюты
+	RefundedMinor int64 `protobuf:"varint,11,opt,name=refunded_minor,json=refundedMinor,proto3" json:"refunded_minor,omitempty"`
+	// currency код валюты заказа ISO 4217
+	Currency string `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`
+	// transaction_uuid UUID транзакции оплаты, пустой до оплаты
+	TransactionUuid string `protobuf:"bytes,13,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// occurred_at время перехода
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderEvent) Reset() {
+	*x = OrderEvent{}
+	mi := &file_events_v1_order_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderEvent) ProtoMessage() {}
+
+func (x *OrderEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_order_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderEvent.ProtoReflect.Descriptor instead.
+func (*OrderEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_order_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrderEvent) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetType() OrderEventType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderEventType_ORDER_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *OrderEvent) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetFromStatus() OrderStatus {
+	if x != nil {
+		return x.FromStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderEvent) GetToStatus() OrderStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetItems() []*OrderEventItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderEvent) GetTotalPriceMinor() int64 {
+	if x != nil {
+		return x.TotalPriceMinor
+	}
+	return 0
+}
+
+func (x *OrderEvent) GetRefundedMinor() int64 {
+	if x != nil {
+		return x.RefundedMinor
+	}
+	return 0
+}
+
+func (x *OrderEvent) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// OrderEventItem позиция заказа в событии
+type OrderEventItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part_uuid UUID детали
+	PartUuid string `protobuf:"bytes,1,opt,name=part_uuid,json=partUuid,proto3" json:"part_uuid,omitempty"`
+	// quantity количество деталей
+	Quantity      int64 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderEventItem) Reset() {
+	*x = OrderEventItem{}
+	mi := &file_events_v1_order_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderEventItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderEventItem) ProtoMessage() {}
+
+func (x *OrderEventItem) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_order_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderEventItem.ProtoReflect.Descriptor instead.
+func (*OrderEventItem) Descriptor() ([]byte, []int) {
+	return file_events_v1_order_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderEventItem) GetPartUuid() string {
+	if x != nil {
+		return x.PartUuid
+	}
+	return ""
+}
+
+func (x *OrderEventItem) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+var File_events_v1_order_events_proto protoreflect.FileDescriptor
+
+const file_events_v1_order_events_proto_rawDesc = "" +
+	"\n" +
+	"\x1cevents/v1/order_events.proto\x12\tevents.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xba\x04\n" +
+	"\n" +
+	"OrderEvent\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12-\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x19.events.v1.OrderEventTypeR\x04type\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x03 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x04 \x01(\tR\buserUuid\x127\n" +
+	"\vfrom_status\x18\x05 \x01(\x0e2\x16.events.v1.OrderStatusR\n" +
+	"fromStatus\x123\n" +
+	"\tto_status\x18\x06 \x01(\x0e2\x16.events.v1.OrderStatusR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12/\n" +
+	"\x05items\x18\t \x03(\v2\x19.events.v1.OrderEventItemR\x05items\x12*\n" +
+	"\x11total_price_minor\x18\n" +
+	" \x01(\x03R\x0ftotalPriceMinor\x12%\n" +
+	"\x0erefunded_minor\x18\v \x01(\x03R\rrefundedMinor\x12\x1a\n" +
+	"\bcurrency\x18\f \x01(\tR\bcurrency\x12)\n" +
+	"\x10transaction_uuid\x18\r \x01(\tR\x0ftransactionUuid\x12;\n" +
+	"\voccurred_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"I\n" +
+	"\x0eOrderEventItem\x12\x1b\n" +
+	"\tpart_uuid\x18\x01 \x01(\tR\bpartUuid\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x03R\bquantity*\xcf\x01\n" +
+	"\x0eOrderEventType\x12 \n" +
+	"\x1cORDER_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18ORDER_EVENT_TYPE_CREATED\x10\x01\x12\x19\n" +
+	"\x15ORDER_EVENT_TYPE_PAID\x10\x02\x12\x1e\n" +
+	"\x1aORDER_EVENT_TYPE_CANCELLED\x10\x03\x12\x1d\n" +
+	"\x19ORDER_EVENT_TYPE_REFUNDED\x10\x04\x12#\n" +
+	"\x1fORDER_EVENT_TYPE_STATUS_CHANGED\x10\x05*\xee\x01\n" +
+	"\vOrderStatus\x12\x1c\n" +
+	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cORDER_STATUS_PENDING_PAYMENT\x10\x01\x12\x15\n" +
+	"\x11ORDER_STATUS_PAID\x10\x02\x12\x1b\n" +
+	"\x17ORDER_STATUS_ASSEMBLING\x10\x03\x12\x18\n" +
+	"\x14ORDER_STATUS_SHIPPED\x10\x04\x12\x1a\n" +
+	"\x16ORDER_STATUS_COMPLETED\x10\x05\x12\x1a\n" +
+	"\x16ORDER_STATUS_CANCELLED\x10\x06\x12\x19\n" +
+	"\x15ORDER_STATUS_REFUNDED\x10\aBIZGgithub.com/Igorezka/rocket-factory/shared/pkg/proto/events/v1;events_v1b\x06proto3"
+
+var (
+	file_events_v1_order_events_proto_rawDescOnce sync.Once
+	file_events_v1_order_events_proto_rawDescData []byte
+)
+
+func file_events_v1_order_events_proto_rawDescGZIP() []byte {
+	file_events_v1_order_events_proto_rawDescOnce.Do(func() {
+		file_events_v1_order_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_v1_order_events_proto_rawDesc), len(file_events_v1_order_events_proto_rawDesc)))
+	})
+	return file_events_v1_order_events_proto_rawDescData
+}
+
+var file_events_v1_order_events_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_events_v1_order_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_events_v1_order_events_proto_goTypes = []any{
+	(OrderEventType)(0),           // 0: events.v1.OrderEventType
+	(OrderStatus)(0),              // 1: events.v1.OrderStatus
+	(*OrderEvent)(nil),            // 2: events.v1.OrderEvent
+	(*OrderEventItem)(nil),        // 3: events.v1.OrderEventItem
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_events_v1_order_events_proto_depIdxs = []int32{
+	0, // 0: events.v1.OrderEvent.type:type_name -> events.v1.OrderEventType
+	1, // 1: events.v1.OrderEvent.from_status:type_name -> events.v1.OrderStatus
+	1, // 2: events.v1.OrderEvent.to_status:type_name -> events.v1.OrderStatus
+	3, // 3: events.v1.OrderEvent.items:type_name -> events.v1.OrderEventItem
+	4, // 4: events.v1.OrderEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_events_v1_order_events_proto_init() }
+func file_events_v1_order_events_proto_init() {
+	if File_events_v1_order_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_v1_order_events_proto_rawDesc), len(file_events_v1_order_events_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_v1_order_events_proto_goTypes,
+		DependencyIndexes: file_events_v1_order_events_proto_depIdxs,
+		EnumInfos:         file_events_v1_order_events_proto_enumTypes,
+		MessageInfos:      file_events_v1_order_events_proto_msgTypes,
+	}.Build()
+	File_events_v1_order_events_proto = out.File
+	file_events_v1_order_events_proto_goTypes = nil
+	file_events_v1_order_events_proto_depIdxs = nil
+}
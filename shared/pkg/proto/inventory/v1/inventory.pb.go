@@ -11,6 +11,7 @@ package inventory_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -85,6 +86,316 @@ func (Category) EnumDescriptor() ([]byte, []int) {
 	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{0}
 }
 
+// ReservationStatus состояние резерва деталей под заказ
+type ReservationStatus int32
+
+const (
+	// UNKNOWN неизвестное состояние
+	ReservationStatus_RESERVATION_STATUS_UNKNOWN_UNSPECIFIED ReservationStatus = 0
+	// RESERVED детали зарезервированы и ожидают оплаты заказа
+	ReservationStatus_RESERVATION_STATUS_RESERVED ReservationStatus = 1
+	// COMMITTED заказ оплачен, детали списаны со склада
+	ReservationStatus_RESERVATION_STATUS_COMMITTED ReservationStatus = 2
+	// RELEASED резерв снят, детали возвращены на склад
+	ReservationStatus_RESERVATION_STATUS_RELEASED ReservationStatus = 3
+	// EXPIRED резерв не был подтвержден вовремя, детали возвращены на склад
+	ReservationStatus_RESERVATION_STATUS_EXPIRED ReservationStatus = 4
+	// RETURNED по оплаченному заказу оформлен возврат, списанные детали возвращены на склад
+	ReservationStatus_RESERVATION_STATUS_RETURNED ReservationStatus = 5
+)
+
+// Enum value maps for ReservationStatus.
+var (
+	ReservationStatus_name = map[int32]string{
+		0: "RESERVATION_STATUS_UNKNOWN_UNSPECIFIED",
+		1: "RESERVATION_STATUS_RESERVED",
+		2: "RESERVATION_STATUS_COMMITTED",
+		3: "RESERVATION_STATUS_RELEASED",
+		4: "RESERVATION_STATUS_EXPIRED",
+		5: "RESERVATION_STATUS_RETURNED",
+	}
+	ReservationStatus_value = map[string]int32{
+		"RESERVATION_STATUS_UNKNOWN_UNSPECIFIED": 0,
+		"RESERVATION_STATUS_RESERVED":            1,
+		"RESERVATION_STATUS_COMMITTED":           2,
+		"RESERVATION_STATUS_RELEASED":            3,
+		"RESERVATION_STATUS_EXPIRED":             4,
+		"RESERVATION_STATUS_RETURNED":            5,
+	}
+)
+
+func (x ReservationStatus) Enum() *ReservationStatus {
+	p := new(ReservationStatus)
+	*p = x
+	return p
+}
+
+func (x ReservationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[1].Descriptor()
+}
+
+func (ReservationStatus) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[1]
+}
+
+func (x ReservationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationStatus.Descriptor instead.
+func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+// PartsOrderBy поле сортировки списка деталей. При равенстве значений детали
+// упорядочиваются по uuid, поэтому порядок всегда детерминирован
+type PartsOrderBy int32
+
+const (
+	// UNSPECIFIED сортировка только по uuid
+	PartsOrderBy_PARTS_ORDER_BY_UNSPECIFIED PartsOrderBy = 0
+	// PRICE по цене за единицу
+	PartsOrderBy_PARTS_ORDER_BY_PRICE PartsOrderBy = 1
+	// NAME по названию
+	PartsOrderBy_PARTS_ORDER_BY_NAME PartsOrderBy = 2
+	// CREATED_AT по дате создания
+	PartsOrderBy_PARTS_ORDER_BY_CREATED_AT PartsOrderBy = 3
+	// STOCK по количеству на складе
+	PartsOrderBy_PARTS_ORDER_BY_STOCK PartsOrderBy = 4
+)
+
+// Enum value maps for PartsOrderBy.
+var (
+	PartsOrderBy_name = map[int32]string{
+		0: "PARTS_ORDER_BY_UNSPECIFIED",
+		1: "PARTS_ORDER_BY_PRICE",
+		2: "PARTS_ORDER_BY_NAME",
+		3: "PARTS_ORDER_BY_CREATED_AT",
+		4: "PARTS_ORDER_BY_STOCK",
+	}
+	PartsOrderBy_value = map[string]int32{
+		"PARTS_ORDER_BY_UNSPECIFIED": 0,
+		"PARTS_ORDER_BY_PRICE":       1,
+		"PARTS_ORDER_BY_NAME":        2,
+		"PARTS_ORDER_BY_CREATED_AT":  3,
+		"PARTS_ORDER_BY_STOCK":       4,
+	}
+)
+
+func (x PartsOrderBy) Enum() *PartsOrderBy {
+	p := new(PartsOrderBy)
+	*p = x
+	return p
+}
+
+func (x PartsOrderBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PartsOrderBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[2].Descriptor()
+}
+
+func (PartsOrderBy) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[2]
+}
+
+func (x PartsOrderBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PartsOrderBy.Descriptor instead.
+func (PartsOrderBy) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+// TagsMatchMode режим сравнения тегов детали с тегами фильтра
+type TagsMatchMode int32
+
+const (
+	// ANY деталь подходит, если у нее есть хотя бы один из тегов фильтра
+	TagsMatchMode_TAGS_MATCH_MODE_ANY_UNSPECIFIED TagsMatchMode = 0
+	// ALL деталь подходит, если у нее есть все теги фильтра
+	TagsMatchMode_TAGS_MATCH_MODE_ALL TagsMatchMode = 1
+)
+
+// Enum value maps for TagsMatchMode.
+var (
+	TagsMatchMode_name = map[int32]string{
+		0: "TAGS_MATCH_MODE_ANY_UNSPECIFIED",
+		1: "TAGS_MATCH_MODE_ALL",
+	}
+	TagsMatchMode_value = map[string]int32{
+		"TAGS_MATCH_MODE_ANY_UNSPECIFIED": 0,
+		"TAGS_MATCH_MODE_ALL":             1,
+	}
+)
+
+func (x TagsMatchMode) Enum() *TagsMatchMode {
+	p := new(TagsMatchMode)
+	*p = x
+	return p
+}
+
+func (x TagsMatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TagsMatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[3].Descriptor()
+}
+
+func (TagsMatchMode) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[3]
+}
+
+func (x TagsMatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TagsMatchMode.Descriptor instead.
+func (TagsMatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+// MetadataOperator операция сравнения значения метаданных детали
+type MetadataOperator int32
+
+const (
+	// UNSPECIFIED недопустимое значение
+	MetadataOperator_METADATA_OPERATOR_UNSPECIFIED MetadataOperator = 0
+	// EQUALS значение равно заданному, числа int64 и double сравниваются между собой
+	MetadataOperator_METADATA_OPERATOR_EQUALS MetadataOperator = 1
+	// EXISTS у детали есть ключ, значение предиката не используется
+	MetadataOperator_METADATA_OPERATOR_EXISTS MetadataOperator = 2
+	// LESS_THAN числовое значение меньше заданного
+	MetadataOperator_METADATA_OPERATOR_LESS_THAN MetadataOperator = 3
+	// LESS_OR_EQUAL числовое значение меньше или равно заданному
+	MetadataOperator_METADATA_OPERATOR_LESS_OR_EQUAL MetadataOperator = 4
+	// GREATER_THAN числовое значение больше заданного
+	MetadataOperator_METADATA_OPERATOR_GREATER_THAN MetadataOperator = 5
+	// GREATER_OR_EQUAL числовое значение больше или равно заданному
+	MetadataOperator_METADATA_OPERATOR_GREATER_OR_EQUAL MetadataOperator = 6
+	// PREFIX строковое значение начинается с заданной строки
+	MetadataOperator_METADATA_OPERATOR_PREFIX MetadataOperator = 7
+)
+
+// Enum value maps for MetadataOperator.
+var (
+	MetadataOperator_name = map[int32]string{
+		0: "METADATA_OPERATOR_UNSPECIFIED",
+		1: "METADATA_OPERATOR_EQUALS",
+		2: "METADATA_OPERATOR_EXISTS",
+		3: "METADATA_OPERATOR_LESS_THAN",
+		4: "METADATA_OPERATOR_LESS_OR_EQUAL",
+		5: "METADATA_OPERATOR_GREATER_THAN",
+		6: "METADATA_OPERATOR_GREATER_OR_EQUAL",
+		7: "METADATA_OPERATOR_PREFIX",
+	}
+	MetadataOperator_value = map[string]int32{
+		"METADATA_OPERATOR_UNSPECIFIED":      0,
+		"METADATA_OPERATOR_EQUALS":           1,
+		"METADATA_OPERATOR_EXISTS":           2,
+		"METADATA_OPERATOR_LESS_THAN":        3,
+		"METADATA_OPERATOR_LESS_OR_EQUAL":    4,
+		"METADATA_OPERATOR_GREATER_THAN":     5,
+		"METADATA_OPERATOR_GREATER_OR_EQUAL": 6,
+		"METADATA_OPERATOR_PREFIX":           7,
+	}
+)
+
+func (x MetadataOperator) Enum() *MetadataOperator {
+	p := new(MetadataOperator)
+	*p = x
+	return p
+}
+
+func (x MetadataOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MetadataOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[4].Descriptor()
+}
+
+func (MetadataOperator) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[4]
+}
+
+func (x MetadataOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MetadataOperator.Descriptor instead.
+func (MetadataOperator) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+// PartEventType вид изменения детали
+type PartEventType int32
+
+const (
+	// UNSPECIFIED неизвестное изменение
+	PartEventType_PART_EVENT_TYPE_UNSPECIFIED PartEventType = 0
+	// CREATED деталь добавлена в каталог
+	PartEventType_PART_EVENT_TYPE_CREATED PartEventType = 1
+	// UPDATED изменены поля детали через UpdatePart
+	PartEventType_PART_EVENT_TYPE_UPDATED PartEventType = 2
+	// DELETED деталь удалена из каталога
+	PartEventType_PART_EVENT_TYPE_DELETED PartEventType = 3
+	// STOCK_CHANGED изменился остаток на складе: резервирование, возврат резерва или AdjustStock
+	PartEventType_PART_EVENT_TYPE_STOCK_CHANGED PartEventType = 4
+)
+
+// Enum value maps for PartEventType.
+var (
+	PartEventType_name = map[int32]string{
+		0: "PART_EVENT_TYPE_UNSPECIFIED",
+		1: "PART_EVENT_TYPE_CREATED",
+		2: "PART_EVENT_TYPE_UPDATED",
+		3: "PART_EVENT_TYPE_DELETED",
+		4: "PART_EVENT_TYPE_STOCK_CHANGED",
+	}
+	PartEventType_value = map[string]int32{
+		"PART_EVENT_TYPE_UNSPECIFIED":   0,
+		"PART_EVENT_TYPE_CREATED":       1,
+		"PART_EVENT_TYPE_UPDATED":       2,
+		"PART_EVENT_TYPE_DELETED":       3,
+		"PART_EVENT_TYPE_STOCK_CHANGED": 4,
+	}
+)
+
+func (x PartEventType) Enum() *PartEventType {
+	p := new(PartEventType)
+	*p = x
+	return p
+}
+
+func (x PartEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PartEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[5].Descriptor()
+}
+
+func (PartEventType) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[5]
+}
+
+func (x PartEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PartEventType.Descriptor instead.
+func (PartEventType) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{5}
+}
+
 // Dimensions размеры детали
 type Dimensions struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -350,7 +661,8 @@ type Part struct {
 	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	// description описание детали
 	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	// price цена за единицу
+	// price цена за единицу в основных единицах валюты, вычисляется из price_minor.
+	// Устарело, используйте price_minor и currency
 	Price float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
 	// stock_quantity количество на складе
 	StockQuantity int64 `protobuf:"varint,5,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
@@ -367,7 +679,12 @@ type Part struct {
 	// created_at дата создания
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// updated_at дата последнего изменения
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// price_minor цена за единицу в минимальных единицах валюты (копейках).
+	// Если при создании не задана, вычисляется из price
+	PriceMinor int64 `protobuf:"varint,13,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	// currency код валюты цены ISO 4217, по умолчанию RUB
+	Currency      string `protobuf:"bytes,14,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -486,37 +803,45 @@ func (x *Part) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// PartsFilter фильтр с опциональными полями по которым детали могут быть отфильтрованы
-type PartsFilter struct {
+func (x *Part) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *Part) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// DoubleRange диапазон дробных значений, границы включаются. Отсутствующая граница не ограничивает диапазон
+type DoubleRange struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// uuids список UUID'ов. Пусто — не фильтруем по UUID
-	Uuids []string `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
-	// names список имён. Пусто — не фильтруем по имени
-	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
-	// categories список категорий. Пусто — не фильтруем по категории
-	Categories []Category `protobuf:"varint,3,rep,packed,name=categories,proto3,enum=inventory.v1.Category" json:"categories,omitempty"`
-	// manufacturer_countries список стран производителей. Пусто — не фильтруем по стране
-	ManufacturerCountries []string `protobuf:"bytes,4,rep,name=manufacturer_countries,json=manufacturerCountries,proto3" json:"manufacturer_countries,omitempty"`
-	// tags список тегов. Пусто — не фильтруем по тегам
-	Tags          []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// min нижняя граница
+	Min *float64 `protobuf:"fixed64,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	// max верхняя граница
+	Max           *float64 `protobuf:"fixed64,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PartsFilter) Reset() {
-	*x = PartsFilter{}
+func (x *DoubleRange) Reset() {
+	*x = DoubleRange{}
 	mi := &file_inventory_v1_inventory_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PartsFilter) String() string {
+func (x *DoubleRange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PartsFilter) ProtoMessage() {}
+func (*DoubleRange) ProtoMessage() {}
 
-func (x *PartsFilter) ProtoReflect() protoreflect.Message {
+func (x *DoubleRange) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_v1_inventory_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -528,69 +853,50 @@ func (x *PartsFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PartsFilter.ProtoReflect.Descriptor instead.
-func (*PartsFilter) Descriptor() ([]byte, []int) {
+// Deprecated: Use DoubleRange.ProtoReflect.Descriptor instead.
+func (*DoubleRange) Descriptor() ([]byte, []int) {
 	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PartsFilter) GetUuids() []string {
-	if x != nil {
-		return x.Uuids
-	}
-	return nil
-}
-
-func (x *PartsFilter) GetNames() []string {
-	if x != nil {
-		return x.Names
-	}
-	return nil
-}
-
-func (x *PartsFilter) GetCategories() []Category {
-	if x != nil {
-		return x.Categories
-	}
-	return nil
-}
-
-func (x *PartsFilter) GetManufacturerCountries() []string {
-	if x != nil {
-		return x.ManufacturerCountries
+func (x *DoubleRange) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
 	}
-	return nil
+	return 0
 }
 
-func (x *PartsFilter) GetTags() []string {
-	if x != nil {
-		return x.Tags
+func (x *DoubleRange) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
 	}
-	return nil
+	return 0
 }
 
-// GetPartRequest запрос на получение детали по уникальному идентификатору
-type GetPartRequest struct {
+// Int64Range диапазон целых значений, границы включаются. Отсутствующая граница не ограничивает диапазон
+type Int64Range struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// uuid идентификатор детали
-	Uuid          string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// min нижняя граница
+	Min *int64 `protobuf:"varint,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	// max верхняя граница
+	Max           *int64 `protobuf:"varint,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPartRequest) Reset() {
-	*x = GetPartRequest{}
+func (x *Int64Range) Reset() {
+	*x = Int64Range{}
 	mi := &file_inventory_v1_inventory_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPartRequest) String() string {
+func (x *Int64Range) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPartRequest) ProtoMessage() {}
+func (*Int64Range) ProtoMessage() {}
 
-func (x *GetPartRequest) ProtoReflect() protoreflect.Message {
+func (x *Int64Range) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_v1_inventory_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -602,41 +908,50 @@ func (x *GetPartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPartRequest.ProtoReflect.Descriptor instead.
-func (*GetPartRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Int64Range.ProtoReflect.Descriptor instead.
+func (*Int64Range) Descriptor() ([]byte, []int) {
 	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetPartRequest) GetUuid() string {
-	if x != nil {
-		return x.Uuid
+func (x *Int64Range) GetMin() int64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
 	}
-	return ""
+	return 0
 }
 
-// GetPartResponse ответ на запрос получения детали по уникальному идентификатору
-type GetPartResponse struct {
+func (x *Int64Range) GetMax() int64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+// TimestampRange интервал времени. Отсутствующая граница не ограничивает интервал
+type TimestampRange struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// part полная информация о детали
-	Part          *Part `protobuf:"bytes,1,opt,name=part,proto3" json:"part,omitempty"`
+	// from начало интервала, включительно
+	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// to конец интервала, не включительно
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPartResponse) Reset() {
-	*x = GetPartResponse{}
+func (x *TimestampRange) Reset() {
+	*x = TimestampRange{}
 	mi := &file_inventory_v1_inventory_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPartResponse) String() string {
+func (x *TimestampRange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPartResponse) ProtoMessage() {}
+func (*TimestampRange) ProtoMessage() {}
 
-func (x *GetPartResponse) ProtoReflect() protoreflect.Message {
+func (x *TimestampRange) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_v1_inventory_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -648,42 +963,53 @@ func (x *GetPartResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPartResponse.ProtoReflect.Descriptor instead.
-func (*GetPartResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TimestampRange.ProtoReflect.Descriptor instead.
+func (*TimestampRange) Descriptor() ([]byte, []int) {
 	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetPartResponse) GetPart() *Part {
+func (x *TimestampRange) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Part
+		return x.From
 	}
 	return nil
 }
 
-// ListPartsRequest запрос на получения списка деталей отфильтрованных по опциональному фильтру,
-// или если фильтр не передается возвращает все имеющиеся детали
-type ListPartsRequest struct {
+func (x *TimestampRange) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// MetadataPredicate условие на значение метаданных детали по ключу. Деталь без ключа под условие не подходит.
+// Сравнение несовместимых типов, например числовое сравнение со строковым значением, является ошибкой запроса
+type MetadataPredicate struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// filter опциональный фильтр
-	Filter        *PartsFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// key ключ метаданных
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// operator операция сравнения
+	Operator MetadataOperator `protobuf:"varint,2,opt,name=operator,proto3,enum=inventory.v1.MetadataOperator" json:"operator,omitempty"`
+	// value значение для сравнения, не задается для EXISTS
+	Value         *Value `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPartsRequest) Reset() {
-	*x = ListPartsRequest{}
+func (x *MetadataPredicate) Reset() {
+	*x = MetadataPredicate{}
 	mi := &file_inventory_v1_inventory_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPartsRequest) String() string {
+func (x *MetadataPredicate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPartsRequest) ProtoMessage() {}
+func (*MetadataPredicate) ProtoMessage() {}
 
-func (x *ListPartsRequest) ProtoReflect() protoreflect.Message {
+func (x *MetadataPredicate) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_v1_inventory_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -695,42 +1021,1378 @@ func (x *ListPartsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPartsRequest.ProtoReflect.Descriptor instead.
-func (*ListPartsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MetadataPredicate.ProtoReflect.Descriptor instead.
+func (*MetadataPredicate) Descriptor() ([]byte, []int) {
 	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListPartsRequest) GetFilter() *PartsFilter {
+func (x *MetadataPredicate) GetKey() string {
 	if x != nil {
-		return x.Filter
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-// ListPartsResponse ответ на запрос получения деталей по опциональному фильтру
-type ListPartsResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// parts список деталей
-	Parts         []*Part `protobuf:"bytes,1,rep,name=parts,proto3" json:"parts,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *MetadataPredicate) GetOperator() MetadataOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return MetadataOperator_METADATA_OPERATOR_UNSPECIFIED
 }
 
-func (x *ListPartsResponse) Reset() {
-	*x = ListPartsResponse{}
-	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *MetadataPredicate) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// PartsFilter фильтр с опциональными полями по которым детали могут быть отфильтрованы.
+// Деталь попадает в выборку, только если подходит под все заданные условия
+type PartsFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// uuids список UUID'ов. Пусто — не фильтруем по UUID
+	Uuids []string `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	// names список имён. Пусто — не фильтруем по имени
+	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+	// categories список категорий. Пусто — не фильтруем по категории
+	Categories []Category `protobuf:"varint,3,rep,packed,name=categories,proto3,enum=inventory.v1.Category" json:"categories,omitempty"`
+	// manufacturer_countries список стран производителей. Пусто — не фильтруем по стране
+	ManufacturerCountries []string `protobuf:"bytes,4,rep,name=manufacturer_countries,json=manufacturerCountries,proto3" json:"manufacturer_countries,omitempty"`
+	// tags список тегов. Пусто — не фильтруем по тегам
+	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// tags_mode режим сравнения тегов: любой из тегов (по умолчанию) или все теги
+	TagsMode TagsMatchMode `protobuf:"varint,6,opt,name=tags_mode,json=tagsMode,proto3,enum=inventory.v1.TagsMatchMode" json:"tags_mode,omitempty"`
+	// price диапазон цены за единицу
+	Price *DoubleRange `protobuf:"bytes,7,opt,name=price,proto3" json:"price,omitempty"`
+	// stock_quantity диапазон количества на складе, например min = 1 — только детали в наличии
+	StockQuantity *Int64Range `protobuf:"bytes,8,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	// length диапазон длины в см
+	Length *DoubleRange `protobuf:"bytes,9,opt,name=length,proto3" json:"length,omitempty"`
+	// width диапазон ширины в см
+	Width *DoubleRange `protobuf:"bytes,10,opt,name=width,proto3" json:"width,omitempty"`
+	// height диапазон высоты в см
+	Height *DoubleRange `protobuf:"bytes,11,opt,name=height,proto3" json:"height,omitempty"`
+	// weight диапазон веса в кг
+	Weight *DoubleRange `protobuf:"bytes,12,opt,name=weight,proto3" json:"weight,omitempty"`
+	// created_at интервал даты создания
+	CreatedAt *TimestampRange `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// exclude_uuids исключить детали с этими UUID
+	ExcludeUuids []string `protobuf:"bytes,14,rep,name=exclude_uuids,json=excludeUuids,proto3" json:"exclude_uuids,omitempty"`
+	// exclude_names исключить детали с этими именами
+	ExcludeNames []string `protobuf:"bytes,15,rep,name=exclude_names,json=excludeNames,proto3" json:"exclude_names,omitempty"`
+	// exclude_categories исключить детали этих категорий
+	ExcludeCategories []Category `protobuf:"varint,16,rep,packed,name=exclude_categories,json=excludeCategories,proto3,enum=inventory.v1.Category" json:"exclude_categories,omitempty"`
+	// exclude_manufacturer_countries исключить детали производителей из этих стран
+	ExcludeManufacturerCountries []string `protobuf:"bytes,17,rep,name=exclude_manufacturer_countries,json=excludeManufacturerCountries,proto3" json:"exclude_manufacturer_countries,omitempty"`
+	// exclude_tags исключить детали, у которых есть хотя бы один из этих тегов
+	ExcludeTags []string `protobuf:"bytes,18,rep,name=exclude_tags,json=excludeTags,proto3" json:"exclude_tags,omitempty"`
+	// metadata условия на метаданные детали, должны выполняться все условия
+	Metadata      []*MetadataPredicate `protobuf:"bytes,19,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartsFilter) Reset() {
+	*x = PartsFilter{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartsFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartsFilter) ProtoMessage() {}
+
+func (x *PartsFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartsFilter.ProtoReflect.Descriptor instead.
+func (*PartsFilter) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PartsFilter) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetCategories() []Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetManufacturerCountries() []string {
+	if x != nil {
+		return x.ManufacturerCountries
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetTagsMode() TagsMatchMode {
+	if x != nil {
+		return x.TagsMode
+	}
+	return TagsMatchMode_TAGS_MATCH_MODE_ANY_UNSPECIFIED
+}
+
+func (x *PartsFilter) GetPrice() *DoubleRange {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetStockQuantity() *Int64Range {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetLength() *DoubleRange {
+	if x != nil {
+		return x.Length
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetWidth() *DoubleRange {
+	if x != nil {
+		return x.Width
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetHeight() *DoubleRange {
+	if x != nil {
+		return x.Height
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetWeight() *DoubleRange {
+	if x != nil {
+		return x.Weight
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetCreatedAt() *TimestampRange {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetExcludeUuids() []string {
+	if x != nil {
+		return x.ExcludeUuids
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetExcludeNames() []string {
+	if x != nil {
+		return x.ExcludeNames
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetExcludeCategories() []Category {
+	if x != nil {
+		return x.ExcludeCategories
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetExcludeManufacturerCountries() []string {
+	if x != nil {
+		return x.ExcludeManufacturerCountries
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetExcludeTags() []string {
+	if x != nil {
+		return x.ExcludeTags
+	}
+	return nil
+}
+
+func (x *PartsFilter) GetMetadata() []*MetadataPredicate {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// GetPartRequest запрос на получение детали по уникальному идентификатору
+type GetPartRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// uuid идентификатор детали
+	Uuid          string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPartRequest) Reset() {
+	*x = GetPartRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPartRequest) ProtoMessage() {}
+
+func (x *GetPartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPartRequest.ProtoReflect.Descriptor instead.
+func (*GetPartRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPartRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+// GetPartResponse ответ на запрос получения детали по уникальному идентификатору
+type GetPartResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part полная информация о детали
+	Part          *Part `protobuf:"bytes,1,opt,name=part,proto3" json:"part,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPartResponse) Reset() {
+	*x = GetPartResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPartResponse) ProtoMessage() {}
+
+func (x *GetPartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPartResponse.ProtoReflect.Descriptor instead.
+func (*GetPartResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPartResponse) GetPart() *Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+// ListPartsRequest запрос на получения списка деталей отфильтрованных по опциональному фильтру,
+// или если фильтр не передается возвращает все имеющиеся детали
+type ListPartsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter опциональный фильтр
+	Filter *PartsFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// page_size максимальное количество деталей на странице, 0 — значение по умолчанию (50), не больше 1000
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token курсор следующей страницы из предыдущего ответа, пусто — первая страница.
+	// Курсор действителен только с теми же filter, order_by и descending
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// order_by поле сортировки
+	OrderBy PartsOrderBy `protobuf:"varint,4,opt,name=order_by,json=orderBy,proto3,enum=inventory.v1.PartsOrderBy" json:"order_by,omitempty"`
+	// descending сортировка по убыванию
+	Descending    bool `protobuf:"varint,5,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPartsRequest) Reset() {
+	*x = ListPartsRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPartsRequest) ProtoMessage() {}
+
+func (x *ListPartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPartsRequest.ProtoReflect.Descriptor instead.
+func (*ListPartsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListPartsRequest) GetFilter() *PartsFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListPartsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPartsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListPartsRequest) GetOrderBy() PartsOrderBy {
+	if x != nil {
+		return x.OrderBy
+	}
+	return PartsOrderBy_PARTS_ORDER_BY_UNSPECIFIED
+}
+
+func (x *ListPartsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+// ListPartsResponse ответ на запрос получения деталей по опциональному фильтру
+type ListPartsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// parts детали текущей страницы
+	Parts []*Part `protobuf:"bytes,1,rep,name=parts,proto3" json:"parts,omitempty"`
+	// next_page_token курсор следующей страницы, пусто на последней странице
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size общее количество деталей, подходящих под фильтр
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPartsResponse) Reset() {
+	*x = ListPartsResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *ListPartsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPartsResponse) ProtoMessage() {}
+func (*ListPartsResponse) ProtoMessage() {}
+
+func (x *ListPartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPartsResponse.ProtoReflect.Descriptor instead.
+func (*ListPartsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListPartsResponse) GetParts() []*Part {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *ListPartsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListPartsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// SearchPartsRequest запрос полнотекстового поиска деталей
+type SearchPartsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query поисковая строка, слова ищутся в названии, описании, производителе, тегах
+	// и строковых значениях метаданных, допускаются опечатки и начала слов
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// filter опциональный фильтр, применяемый к найденным деталям
+	Filter *PartsFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// limit максимальное количество результатов, 0 — значение по умолчанию (20), не больше 100
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPartsRequest) Reset() {
+	*x = SearchPartsRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPartsRequest) ProtoMessage() {}
+
+func (x *SearchPartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPartsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPartsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchPartsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchPartsRequest) GetFilter() *PartsFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SearchPartsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// SearchHighlight фрагмент поля детали с выделенными найденными словами
+type SearchHighlight struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field поле детали: name, description, manufacturer, tags или metadata.<ключ>
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// snippet фрагмент текста, найденные слова обрамлены тегами <em> и </em>
+	Snippet       string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHighlight) Reset() {
+	*x = SearchHighlight{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHighlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHighlight) ProtoMessage() {}
+
+func (x *SearchHighlight) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHighlight.ProtoReflect.Descriptor instead.
+func (*SearchHighlight) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchHighlight) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SearchHighlight) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+// SearchResult найденная деталь
+type SearchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part деталь
+	Part *Part `protobuf:"bytes,1,opt,name=part,proto3" json:"part,omitempty"`
+	// score релевантность детали запросу, больше — релевантнее
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// highlights фрагменты полей, в которых найдены слова запроса
+	Highlights    []*SearchHighlight `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SearchResult) GetPart() *Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetHighlights() []*SearchHighlight {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+// SearchPartsResponse ответ на запрос полнотекстового поиска деталей
+type SearchPartsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// results найденные детали по убыванию релевантности
+	Results       []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPartsResponse) Reset() {
+	*x = SearchPartsResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPartsResponse) ProtoMessage() {}
+
+func (x *SearchPartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPartsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPartsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchPartsResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// WatchPartsRequest запрос подписки на изменения деталей
+type WatchPartsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter опциональный фильтр, событие передается, если деталь подходит под него до или после изменения
+	Filter *PartsFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// resume_token позиция последнего полученного события, пусто — только новые события
+	ResumeToken   string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPartsRequest) Reset() {
+	*x = WatchPartsRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPartsRequest) ProtoMessage() {}
+
+func (x *WatchPartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPartsRequest.ProtoReflect.Descriptor instead.
+func (*WatchPartsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WatchPartsRequest) GetFilter() *PartsFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *WatchPartsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+// PartEvent событие изменения детали
+type PartEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// resume_token позиция события, передается в WatchPartsRequest при переподключении
+	ResumeToken string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// type вид изменения
+	Type PartEventType `protobuf:"varint,2,opt,name=type,proto3,enum=inventory.v1.PartEventType" json:"type,omitempty"`
+	// part состояние детали после изменения, для удаленной детали — последнее состояние
+	Part *Part `protobuf:"bytes,3,opt,name=part,proto3" json:"part,omitempty"`
+	// occurred_at время изменения
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartEvent) Reset() {
+	*x = PartEvent{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartEvent) ProtoMessage() {}
+
+func (x *PartEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartEvent.ProtoReflect.Descriptor instead.
+func (*PartEvent) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PartEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *PartEvent) GetType() PartEventType {
+	if x != nil {
+		return x.Type
+	}
+	return PartEventType_PART_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *PartEvent) GetPart() *Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+func (x *PartEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// ReservationItem позиция резерва
+type ReservationItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part_uuid UUID детали
+	PartUuid string `protobuf:"bytes,1,opt,name=part_uuid,json=partUuid,proto3" json:"part_uuid,omitempty"`
+	// quantity количество резервируемых деталей
+	Quantity      int64 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationItem) Reset() {
+	*x = ReservationItem{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationItem) ProtoMessage() {}
+
+func (x *ReservationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationItem.ProtoReflect.Descriptor instead.
+func (*ReservationItem) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReservationItem) GetPartUuid() string {
+	if x != nil {
+		return x.PartUuid
+	}
+	return ""
+}
+
+func (x *ReservationItem) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Reservation резерв деталей под заказ
+type Reservation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_uuid UUID заказа, под который зарезервированы детали
+	OrderUuid string `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// items зарезервированные позиции
+	Items []*ReservationItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// status состояние резерва
+	Status ReservationStatus `protobuf:"varint,3,opt,name=status,proto3,enum=inventory.v1.ReservationStatus" json:"status,omitempty"`
+	// created_at дата создания резерва
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// expires_at дата, после которой неподтвержденный резерв снимается автоматически
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Reservation) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *Reservation) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Reservation) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_RESERVATION_STATUS_UNKNOWN_UNSPECIFIED
+}
+
+func (x *Reservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// ReservePartsRequest запрос на резервирование деталей под заказ
+type ReservePartsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_uuid UUID заказа
+	OrderUuid string `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// items резервируемые позиции
+	Items         []*ReservationItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservePartsRequest) Reset() {
+	*x = ReservePartsRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservePartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservePartsRequest) ProtoMessage() {}
+
+func (x *ReservePartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservePartsRequest.ProtoReflect.Descriptor instead.
+func (*ReservePartsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReservePartsRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *ReservePartsRequest) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ReservePartsResponse ответ на запрос резервирования деталей
+type ReservePartsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// reservation созданный резерв
+	Reservation   *Reservation `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservePartsResponse) Reset() {
+	*x = ReservePartsResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservePartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservePartsResponse) ProtoMessage() {}
+
+func (x *ReservePartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservePartsResponse.ProtoReflect.Descriptor instead.
+func (*ReservePartsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReservePartsResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// CommitReservationRequest запрос на подтверждение резерва после оплаты заказа
+type CommitReservationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_uuid UUID заказа
+	OrderUuid     string `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CommitReservationRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+// CommitReservationResponse ответ на запрос подтверждения резерва
+type CommitReservationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// reservation подтвержденный резерв
+	Reservation   *Reservation `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CommitReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// ReleaseReservationRequest запрос на снятие резерва
+type ReleaseReservationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_uuid UUID заказа
+	OrderUuid     string `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReleaseReservationRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+// ReleaseReservationResponse ответ на запрос снятия резерва
+type ReleaseReservationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// reservation снятый резерв
+	Reservation   *Reservation `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReleaseReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// ReturnReservationRequest запрос на возврат деталей заказа на склад
+type ReturnReservationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_uuid UUID заказа
+	OrderUuid     string `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnReservationRequest) Reset() {
+	*x = ReturnReservationRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnReservationRequest) ProtoMessage() {}
+
+func (x *ReturnReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReturnReservationRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReturnReservationRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+// ReturnReservationResponse ответ на запрос возврата деталей заказа на склад
+type ReturnReservationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// reservation резерв после возврата деталей
+	Reservation   *Reservation `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnReservationResponse) Reset() {
+	*x = ReturnReservationResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnReservationResponse) ProtoMessage() {}
+
+func (x *ReturnReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReturnReservationResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReturnReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// CreatePartRequest запрос на добавление детали в каталог
+type CreatePartRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part добавляемая деталь. Если uuid не передан, он будет сгенерирован,
+	// created_at и updated_at заполняются автоматически
+	Part          *Part `protobuf:"bytes,1,opt,name=part,proto3" json:"part,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePartRequest) Reset() {
+	*x = CreatePartRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePartRequest) ProtoMessage() {}
 
-func (x *ListPartsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
+func (x *CreatePartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -741,14 +2403,346 @@ func (x *ListPartsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPartsResponse.ProtoReflect.Descriptor instead.
-func (*ListPartsResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CreatePartRequest.ProtoReflect.Descriptor instead.
+func (*CreatePartRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *ListPartsResponse) GetParts() []*Part {
+func (x *CreatePartRequest) GetPart() *Part {
 	if x != nil {
-		return x.Parts
+		return x.Part
+	}
+	return nil
+}
+
+// CreatePartResponse ответ на запрос добавления детали
+type CreatePartResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part добавленная деталь
+	Part          *Part `protobuf:"bytes,1,opt,name=part,proto3" json:"part,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePartResponse) Reset() {
+	*x = CreatePartResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePartResponse) ProtoMessage() {}
+
+func (x *CreatePartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePartResponse.ProtoReflect.Descriptor instead.
+func (*CreatePartResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreatePartResponse) GetPart() *Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+// UpdatePartRequest запрос на обновление детали
+type UpdatePartRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part новые значения полей детали, uuid определяет обновляемую деталь
+	Part *Part `protobuf:"bytes,1,opt,name=part,proto3" json:"part,omitempty"`
+	// update_mask список обновляемых полей, "*" обновляет все изменяемые поля.
+	// Поля uuid, stock_quantity, created_at и updated_at не изменяются через маску
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePartRequest) Reset() {
+	*x = UpdatePartRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePartRequest) ProtoMessage() {}
+
+func (x *UpdatePartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePartRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePartRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdatePartRequest) GetPart() *Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+func (x *UpdatePartRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// UpdatePartResponse ответ на запрос обновления детали
+type UpdatePartResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part обновленная деталь
+	Part          *Part `protobuf:"bytes,1,opt,name=part,proto3" json:"part,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePartResponse) Reset() {
+	*x = UpdatePartResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePartResponse) ProtoMessage() {}
+
+func (x *UpdatePartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePartResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePartResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdatePartResponse) GetPart() *Part {
+	if x != nil {
+		return x.Part
+	}
+	return nil
+}
+
+// DeletePartRequest запрос на удаление детали
+type DeletePartRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// uuid идентификатор детали
+	Uuid          string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePartRequest) Reset() {
+	*x = DeletePartRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePartRequest) ProtoMessage() {}
+
+func (x *DeletePartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePartRequest.ProtoReflect.Descriptor instead.
+func (*DeletePartRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeletePartRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+// DeletePartResponse ответ на запрос удаления детали
+type DeletePartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePartResponse) Reset() {
+	*x = DeletePartResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePartResponse) ProtoMessage() {}
+
+func (x *DeletePartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePartResponse.ProtoReflect.Descriptor instead.
+func (*DeletePartResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{34}
+}
+
+// AdjustStockRequest запрос на изменение остатка детали на складе
+type AdjustStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// uuid идентификатор детали
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// delta изменение остатка, положительное при поступлении и отрицательное при списании
+	Delta         int64 `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AdjustStockRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+// AdjustStockResponse ответ на запрос изменения остатка
+type AdjustStockResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part деталь с обновленным остатком
+	Part          *Part `protobuf:"bytes,1,opt,name=part,proto3" json:"part,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockResponse) ProtoMessage() {}
+
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AdjustStockResponse) GetPart() *Part {
+	if x != nil {
+		return x.Part
 	}
 	return nil
 }
@@ -757,7 +2751,7 @@ var File_inventory_v1_inventory_proto protoreflect.FileDescriptor
 
 const file_inventory_v1_inventory_proto_rawDesc = "" +
 	"\n" +
-	"\x1cinventory/v1/inventory.proto\x12\finventory.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"j\n" +
+	"\x1cinventory/v1/inventory.proto\x12\finventory.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"j\n" +
 	"\n" +
 	"Dimensions\x12\x16\n" +
 	"\x06length\x18\x01 \x01(\x01R\x06length\x12\x14\n" +
@@ -776,7 +2770,7 @@ const file_inventory_v1_inventory_proto_rawDesc = "" +
 	"\n" +
 	"bool_value\x18\x04 \x01(\bH\x00R\tboolValueB\f\n" +
 	"\n" +
-	"value_type\"\xd5\x04\n" +
+	"value_type\"\x92\x05\n" +
 	"\x04Part\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -794,10 +2788,31 @@ const file_inventory_v1_inventory_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1aP\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1f\n" +
+	"\vprice_minor\x18\r \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\x0e \x01(\tR\bcurrency\x1aP\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
-	"\x05value\x18\x02 \x01(\v2\x13.inventory.v1.ValueR\x05value:\x028\x01\"\xbc\x01\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.inventory.v1.ValueR\x05value:\x028\x01\"K\n" +
+	"\vDoubleRange\x12\x15\n" +
+	"\x03min\x18\x01 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x02 \x01(\x01H\x01R\x03max\x88\x01\x01B\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"J\n" +
+	"\n" +
+	"Int64Range\x12\x15\n" +
+	"\x03min\x18\x01 \x01(\x03H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x02 \x01(\x03H\x01R\x03max\x88\x01\x01B\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"l\n" +
+	"\x0eTimestampRange\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\x8c\x01\n" +
+	"\x11MetadataPredicate\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12:\n" +
+	"\boperator\x18\x02 \x01(\x0e2\x1e.inventory.v1.MetadataOperatorR\boperator\x12)\n" +
+	"\x05value\x18\x03 \x01(\v2\x13.inventory.v1.ValueR\x05value\"\xa6\a\n" +
 	"\vPartsFilter\x12\x14\n" +
 	"\x05uuids\x18\x01 \x03(\tR\x05uuids\x12\x14\n" +
 	"\x05names\x18\x02 \x03(\tR\x05names\x126\n" +
@@ -805,24 +2820,170 @@ const file_inventory_v1_inventory_proto_rawDesc = "" +
 	"categories\x18\x03 \x03(\x0e2\x16.inventory.v1.CategoryR\n" +
 	"categories\x125\n" +
 	"\x16manufacturer_countries\x18\x04 \x03(\tR\x15manufacturerCountries\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\"$\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x128\n" +
+	"\ttags_mode\x18\x06 \x01(\x0e2\x1b.inventory.v1.TagsMatchModeR\btagsMode\x12/\n" +
+	"\x05price\x18\a \x01(\v2\x19.inventory.v1.DoubleRangeR\x05price\x12?\n" +
+	"\x0estock_quantity\x18\b \x01(\v2\x18.inventory.v1.Int64RangeR\rstockQuantity\x121\n" +
+	"\x06length\x18\t \x01(\v2\x19.inventory.v1.DoubleRangeR\x06length\x12/\n" +
+	"\x05width\x18\n" +
+	" \x01(\v2\x19.inventory.v1.DoubleRangeR\x05width\x121\n" +
+	"\x06height\x18\v \x01(\v2\x19.inventory.v1.DoubleRangeR\x06height\x121\n" +
+	"\x06weight\x18\f \x01(\v2\x19.inventory.v1.DoubleRangeR\x06weight\x12;\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1c.inventory.v1.TimestampRangeR\tcreatedAt\x12#\n" +
+	"\rexclude_uuids\x18\x0e \x03(\tR\fexcludeUuids\x12#\n" +
+	"\rexclude_names\x18\x0f \x03(\tR\fexcludeNames\x12E\n" +
+	"\x12exclude_categories\x18\x10 \x03(\x0e2\x16.inventory.v1.CategoryR\x11excludeCategories\x12D\n" +
+	"\x1eexclude_manufacturer_countries\x18\x11 \x03(\tR\x1cexcludeManufacturerCountries\x12!\n" +
+	"\fexclude_tags\x18\x12 \x03(\tR\vexcludeTags\x12;\n" +
+	"\bmetadata\x18\x13 \x03(\v2\x1f.inventory.v1.MetadataPredicateR\bmetadata\"$\n" +
 	"\x0eGetPartRequest\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"9\n" +
 	"\x0fGetPartResponse\x12&\n" +
-	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part\"E\n" +
+	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part\"\xd8\x01\n" +
 	"\x10ListPartsRequest\x121\n" +
-	"\x06filter\x18\x01 \x01(\v2\x19.inventory.v1.PartsFilterR\x06filter\"=\n" +
+	"\x06filter\x18\x01 \x01(\v2\x19.inventory.v1.PartsFilterR\x06filter\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x125\n" +
+	"\border_by\x18\x04 \x01(\x0e2\x1a.inventory.v1.PartsOrderByR\aorderBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x05 \x01(\bR\n" +
+	"descending\"\x84\x01\n" +
 	"\x11ListPartsResponse\x12(\n" +
-	"\x05parts\x18\x01 \x03(\v2\x12.inventory.v1.PartR\x05parts*~\n" +
+	"\x05parts\x18\x01 \x03(\v2\x12.inventory.v1.PartR\x05parts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"s\n" +
+	"\x12SearchPartsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x121\n" +
+	"\x06filter\x18\x02 \x01(\v2\x19.inventory.v1.PartsFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"A\n" +
+	"\x0fSearchHighlight\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\"\x8b\x01\n" +
+	"\fSearchResult\x12&\n" +
+	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12=\n" +
+	"\n" +
+	"highlights\x18\x03 \x03(\v2\x1d.inventory.v1.SearchHighlightR\n" +
+	"highlights\"K\n" +
+	"\x13SearchPartsResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.inventory.v1.SearchResultR\aresults\"i\n" +
+	"\x11WatchPartsRequest\x121\n" +
+	"\x06filter\x18\x01 \x01(\v2\x19.inventory.v1.PartsFilterR\x06filter\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\"\xc4\x01\n" +
+	"\tPartEvent\x12!\n" +
+	"\fresume_token\x18\x01 \x01(\tR\vresumeToken\x12/\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1b.inventory.v1.PartEventTypeR\x04type\x12&\n" +
+	"\x04part\x18\x03 \x01(\v2\x12.inventory.v1.PartR\x04part\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"J\n" +
+	"\x0fReservationItem\x12\x1b\n" +
+	"\tpart_uuid\x18\x01 \x01(\tR\bpartUuid\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x03R\bquantity\"\x90\x02\n" +
+	"\vReservation\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\x123\n" +
+	"\x05items\x18\x02 \x03(\v2\x1d.inventory.v1.ReservationItemR\x05items\x127\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1f.inventory.v1.ReservationStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"i\n" +
+	"\x13ReservePartsRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\x123\n" +
+	"\x05items\x18\x02 \x03(\v2\x1d.inventory.v1.ReservationItemR\x05items\"S\n" +
+	"\x14ReservePartsResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.inventory.v1.ReservationR\vreservation\"9\n" +
+	"\x18CommitReservationRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\"X\n" +
+	"\x19CommitReservationResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.inventory.v1.ReservationR\vreservation\":\n" +
+	"\x19ReleaseReservationRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\"Y\n" +
+	"\x1aReleaseReservationResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.inventory.v1.ReservationR\vreservation\"9\n" +
+	"\x18ReturnReservationRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\"X\n" +
+	"\x19ReturnReservationResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.inventory.v1.ReservationR\vreservation\";\n" +
+	"\x11CreatePartRequest\x12&\n" +
+	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part\"<\n" +
+	"\x12CreatePartResponse\x12&\n" +
+	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part\"x\n" +
+	"\x11UpdatePartRequest\x12&\n" +
+	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"<\n" +
+	"\x12UpdatePartResponse\x12&\n" +
+	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part\"'\n" +
+	"\x11DeletePartRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x14\n" +
+	"\x12DeletePartResponse\">\n" +
+	"\x12AdjustStockRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x14\n" +
+	"\x05delta\x18\x02 \x01(\x03R\x05delta\"=\n" +
+	"\x13AdjustStockResponse\x12&\n" +
+	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part*~\n" +
 	"\bCategory\x12 \n" +
 	"\x1cCATEGORY_UNKNOWN_UNSPECIFIED\x10\x00\x12\x13\n" +
 	"\x0fCATEGORY_ENGINE\x10\x01\x12\x11\n" +
 	"\rCATEGORY_FUEL\x10\x02\x12\x15\n" +
 	"\x11CATEGORY_PORTHOLE\x10\x03\x12\x11\n" +
-	"\rCATEGORY_WING\x10\x042\xa8\x01\n" +
+	"\rCATEGORY_WING\x10\x04*\xe4\x01\n" +
+	"\x11ReservationStatus\x12*\n" +
+	"&RESERVATION_STATUS_UNKNOWN_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bRESERVATION_STATUS_RESERVED\x10\x01\x12 \n" +
+	"\x1cRESERVATION_STATUS_COMMITTED\x10\x02\x12\x1f\n" +
+	"\x1bRESERVATION_STATUS_RELEASED\x10\x03\x12\x1e\n" +
+	"\x1aRESERVATION_STATUS_EXPIRED\x10\x04\x12\x1f\n" +
+	"\x1bRESERVATION_STATUS_RETURNED\x10\x05*\x9a\x01\n" +
+	"\fPartsOrderBy\x12\x1e\n" +
+	"\x1aPARTS_ORDER_BY_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14PARTS_ORDER_BY_PRICE\x10\x01\x12\x17\n" +
+	"\x13PARTS_ORDER_BY_NAME\x10\x02\x12\x1d\n" +
+	"\x19PARTS_ORDER_BY_CREATED_AT\x10\x03\x12\x18\n" +
+	"\x14PARTS_ORDER_BY_STOCK\x10\x04*M\n" +
+	"\rTagsMatchMode\x12#\n" +
+	"\x1fTAGS_MATCH_MODE_ANY_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13TAGS_MATCH_MODE_ALL\x10\x01*\xa1\x02\n" +
+	"\x10MetadataOperator\x12!\n" +
+	"\x1dMETADATA_OPERATOR_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18METADATA_OPERATOR_EQUALS\x10\x01\x12\x1c\n" +
+	"\x18METADATA_OPERATOR_EXISTS\x10\x02\x12\x1f\n" +
+	"\x1bMETADATA_OPERATOR_LESS_THAN\x10\x03\x12#\n" +
+	"\x1fMETADATA_OPERATOR_LESS_OR_EQUAL\x10\x04\x12\"\n" +
+	"\x1eMETADATA_OPERATOR_GREATER_THAN\x10\x05\x12&\n" +
+	"\"METADATA_OPERATOR_GREATER_OR_EQUAL\x10\x06\x12\x1c\n" +
+	"\x18METADATA_OPERATOR_PREFIX\x10\a*\xaa\x01\n" +
+	"\rPartEventType\x12\x1f\n" +
+	"\x1bPART_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17PART_EVENT_TYPE_CREATED\x10\x01\x12\x1b\n" +
+	"\x17PART_EVENT_TYPE_UPDATED\x10\x02\x12\x1b\n" +
+	"\x17PART_EVENT_TYPE_DELETED\x10\x03\x12!\n" +
+	"\x1dPART_EVENT_TYPE_STOCK_CHANGED\x10\x042\x99\b\n" +
 	"\x10InventoryService\x12F\n" +
 	"\aGetPart\x12\x1c.inventory.v1.GetPartRequest\x1a\x1d.inventory.v1.GetPartResponse\x12L\n" +
-	"\tListParts\x12\x1e.inventory.v1.ListPartsRequest\x1a\x1f.inventory.v1.ListPartsResponseBOZMgithub.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1;inventory_v1b\x06proto3"
+	"\tListParts\x12\x1e.inventory.v1.ListPartsRequest\x1a\x1f.inventory.v1.ListPartsResponse\x12U\n" +
+	"\fReserveParts\x12!.inventory.v1.ReservePartsRequest\x1a\".inventory.v1.ReservePartsResponse\x12d\n" +
+	"\x11CommitReservation\x12&.inventory.v1.CommitReservationRequest\x1a'.inventory.v1.CommitReservationResponse\x12g\n" +
+	"\x12ReleaseReservation\x12'.inventory.v1.ReleaseReservationRequest\x1a(.inventory.v1.ReleaseReservationResponse\x12d\n" +
+	"\x11ReturnReservation\x12&.inventory.v1.ReturnReservationRequest\x1a'.inventory.v1.ReturnReservationResponse\x12O\n" +
+	"\n" +
+	"CreatePart\x12\x1f.inventory.v1.CreatePartRequest\x1a .inventory.v1.CreatePartResponse\x12O\n" +
+	"\n" +
+	"UpdatePart\x12\x1f.inventory.v1.UpdatePartRequest\x1a .inventory.v1.UpdatePartResponse\x12O\n" +
+	"\n" +
+	"DeletePart\x12\x1f.inventory.v1.DeletePartRequest\x1a .inventory.v1.DeletePartResponse\x12R\n" +
+	"\vAdjustStock\x12 .inventory.v1.AdjustStockRequest\x1a!.inventory.v1.AdjustStockResponse\x12R\n" +
+	"\vSearchParts\x12 .inventory.v1.SearchPartsRequest\x1a!.inventory.v1.SearchPartsResponse\x12H\n" +
+	"\n" +
+	"WatchParts\x12\x1f.inventory.v1.WatchPartsRequest\x1a\x17.inventory.v1.PartEvent0\x01BOZMgithub.com/Igorezka/rocket-factory/shared/pkg/proto/inventory/v1;inventory_v1b\x06proto3"
 
 var (
 	file_inventory_v1_inventory_proto_rawDescOnce sync.Once
@@ -836,43 +2997,135 @@ func file_inventory_v1_inventory_proto_rawDescGZIP() []byte {
 	return file_inventory_v1_inventory_proto_rawDescData
 }
 
-var file_inventory_v1_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_inventory_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_inventory_v1_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_inventory_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_inventory_v1_inventory_proto_goTypes = []any{
-	(Category)(0),                 // 0: inventory.v1.Category
-	(*Dimensions)(nil),            // 1: inventory.v1.Dimensions
-	(*Manufacturer)(nil),          // 2: inventory.v1.Manufacturer
-	(*Value)(nil),                 // 3: inventory.v1.Value
-	(*Part)(nil),                  // 4: inventory.v1.Part
-	(*PartsFilter)(nil),           // 5: inventory.v1.PartsFilter
-	(*GetPartRequest)(nil),        // 6: inventory.v1.GetPartRequest
-	(*GetPartResponse)(nil),       // 7: inventory.v1.GetPartResponse
-	(*ListPartsRequest)(nil),      // 8: inventory.v1.ListPartsRequest
-	(*ListPartsResponse)(nil),     // 9: inventory.v1.ListPartsResponse
-	nil,                           // 10: inventory.v1.Part.MetadataEntry
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(Category)(0),                      // 0: inventory.v1.Category
+	(ReservationStatus)(0),             // 1: inventory.v1.ReservationStatus
+	(PartsOrderBy)(0),                  // 2: inventory.v1.PartsOrderBy
+	(TagsMatchMode)(0),                 // 3: inventory.v1.TagsMatchMode
+	(MetadataOperator)(0),              // 4: inventory.v1.MetadataOperator
+	(PartEventType)(0),                 // 5: inventory.v1.PartEventType
+	(*Dimensions)(nil),                 // 6: inventory.v1.Dimensions
+	(*Manufacturer)(nil),               // 7: inventory.v1.Manufacturer
+	(*Value)(nil),                      // 8: inventory.v1.Value
+	(*Part)(nil),                       // 9: inventory.v1.Part
+	(*DoubleRange)(nil),                // 10: inventory.v1.DoubleRange
+	(*Int64Range)(nil),                 // 11: inventory.v1.Int64Range
+	(*TimestampRange)(nil),             // 12: inventory.v1.TimestampRange
+	(*MetadataPredicate)(nil),          // 13: inventory.v1.MetadataPredicate
+	(*PartsFilter)(nil),                // 14: inventory.v1.PartsFilter
+	(*GetPartRequest)(nil),             // 15: inventory.v1.GetPartRequest
+	(*GetPartResponse)(nil),            // 16: inventory.v1.GetPartResponse
+	(*ListPartsRequest)(nil),           // 17: inventory.v1.ListPartsRequest
+	(*ListPartsResponse)(nil),          // 18: inventory.v1.ListPartsResponse
+	(*SearchPartsRequest)(nil),         // 19: inventory.v1.SearchPartsRequest
+	(*SearchHighlight)(nil),            // 20: inventory.v1.SearchHighlight
+	(*SearchResult)(nil),               // 21: inventory.v1.SearchResult
+	(*SearchPartsResponse)(nil),        // 22: inventory.v1.SearchPartsResponse
+	(*WatchPartsRequest)(nil),          // 23: inventory.v1.WatchPartsRequest
+	(*PartEvent)(nil),                  // 24: inventory.v1.PartEvent
+	(*ReservationItem)(nil),            // 25: inventory.v1.ReservationItem
+	(*Reservation)(nil),                // 26: inventory.v1.Reservation
+	(*ReservePartsRequest)(nil),        // 27: inventory.v1.ReservePartsRequest
+	(*ReservePartsResponse)(nil),       // 28: inventory.v1.ReservePartsResponse
+	(*CommitReservationRequest)(nil),   // 29: inventory.v1.CommitReservationRequest
+	(*CommitReservationResponse)(nil),  // 30: inventory.v1.CommitReservationResponse
+	(*ReleaseReservationRequest)(nil),  // 31: inventory.v1.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil), // 32: inventory.v1.ReleaseReservationResponse
+	(*ReturnReservationRequest)(nil),   // 33: inventory.v1.ReturnReservationRequest
+	(*ReturnReservationResponse)(nil),  // 34: inventory.v1.ReturnReservationResponse
+	(*CreatePartRequest)(nil),          // 35: inventory.v1.CreatePartRequest
+	(*CreatePartResponse)(nil),         // 36: inventory.v1.CreatePartResponse
+	(*UpdatePartRequest)(nil),          // 37: inventory.v1.UpdatePartRequest
+	(*UpdatePartResponse)(nil),         // 38: inventory.v1.UpdatePartResponse
+	(*DeletePartRequest)(nil),          // 39: inventory.v1.DeletePartRequest
+	(*DeletePartResponse)(nil),         // 40: inventory.v1.DeletePartResponse
+	(*AdjustStockRequest)(nil),         // 41: inventory.v1.AdjustStockRequest
+	(*AdjustStockResponse)(nil),        // 42: inventory.v1.AdjustStockResponse
+	nil,                                // 43: inventory.v1.Part.MetadataEntry
+	(*timestamppb.Timestamp)(nil),      // 44: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),      // 45: google.protobuf.FieldMask
 }
 var file_inventory_v1_inventory_proto_depIdxs = []int32{
 	0,  // 0: inventory.v1.Part.category:type_name -> inventory.v1.Category
-	1,  // 1: inventory.v1.Part.dimensions:type_name -> inventory.v1.Dimensions
-	2,  // 2: inventory.v1.Part.manufacturer:type_name -> inventory.v1.Manufacturer
-	10, // 3: inventory.v1.Part.metadata:type_name -> inventory.v1.Part.MetadataEntry
-	11, // 4: inventory.v1.Part.created_at:type_name -> google.protobuf.Timestamp
-	11, // 5: inventory.v1.Part.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 6: inventory.v1.PartsFilter.categories:type_name -> inventory.v1.Category
-	4,  // 7: inventory.v1.GetPartResponse.part:type_name -> inventory.v1.Part
-	5,  // 8: inventory.v1.ListPartsRequest.filter:type_name -> inventory.v1.PartsFilter
-	4,  // 9: inventory.v1.ListPartsResponse.parts:type_name -> inventory.v1.Part
-	3,  // 10: inventory.v1.Part.MetadataEntry.value:type_name -> inventory.v1.Value
-	6,  // 11: inventory.v1.InventoryService.GetPart:input_type -> inventory.v1.GetPartRequest
-	8,  // 12: inventory.v1.InventoryService.ListParts:input_type -> inventory.v1.ListPartsRequest
-	7,  // 13: inventory.v1.InventoryService.GetPart:output_type -> inventory.v1.GetPartResponse
-	9,  // 14: inventory.v1.InventoryService.ListParts:output_type -> inventory.v1.ListPartsResponse
-	13, // [13:15] is the sub-list for method output_type
-	11, // [11:13] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	6,  // 1: inventory.v1.Part.dimensions:type_name -> inventory.v1.Dimensions
+	7,  // 2: inventory.v1.Part.manufacturer:type_name -> inventory.v1.Manufacturer
+	43, // 3: inventory.v1.Part.metadata:type_name -> inventory.v1.Part.MetadataEntry
+	44, // 4: inventory.v1.Part.created_at:type_name -> google.protobuf.Timestamp
+	44, // 5: inventory.v1.Part.updated_at:type_name -> google.protobuf.Timestamp
+	44, // 6: inventory.v1.TimestampRange.from:type_name -> google.protobuf.Timestamp
+	44, // 7: inventory.v1.TimestampRange.to:type_name -> google.protobuf.Timestamp
+	4,  // 8: inventory.v1.MetadataPredicate.operator:type_name -> inventory.v1.MetadataOperator
+	8,  // 9: inventory.v1.MetadataPredicate.value:type_name -> inventory.v1.Value
+	0,  // 10: inventory.v1.PartsFilter.categories:type_name -> inventory.v1.Category
+	3,  // 11: inventory.v1.PartsFilter.tags_mode:type_name -> inventory.v1.TagsMatchMode
+	10, // 12: inventory.v1.PartsFilter.price:type_name -> inventory.v1.DoubleRange
+	11, // 13: inventory.v1.PartsFilter.stock_quantity:type_name -> inventory.v1.Int64Range
+	10, // 14: inventory.v1.PartsFilter.length:type_name -> inventory.v1.DoubleRange
+	10, // 15: inventory.v1.PartsFilter.width:type_name -> inventory.v1.DoubleRange
+	10, // 16: inventory.v1.PartsFilter.height:type_name -> inventory.v1.DoubleRange
+	10, // 17: inventory.v1.PartsFilter.weight:type_name -> inventory.v1.DoubleRange
+	12, // 18: inventory.v1.PartsFilter.created_at:type_name -> inventory.v1.TimestampRange
+	0,  // 19: inventory.v1.PartsFilter.exclude_categories:type_name -> inventory.v1.Category
+	13, // 20: inventory.v1.PartsFilter.metadata:type_name -> inventory.v1.MetadataPredicate
+	9,  // 21: inventory.v1.GetPartResponse.part:type_name -> inventory.v1.Part
+	14, // 22: inventory.v1.ListPartsRequest.filter:type_name -> inventory.v1.PartsFilter
+	2,  // 23: inventory.v1.ListPartsRequest.order_by:type_name -> inventory.v1.PartsOrderBy
+	9,  // 24: inventory.v1.ListPartsResponse.parts:type_name -> inventory.v1.Part
+	14, // 25: inventory.v1.SearchPartsRequest.filter:type_name -> inventory.v1.PartsFilter
+	9,  // 26: inventory.v1.SearchResult.part:type_name -> inventory.v1.Part
+	20, // 27: inventory.v1.SearchResult.highlights:type_name -> inventory.v1.SearchHighlight
+	21, // 28: inventory.v1.SearchPartsResponse.results:type_name -> inventory.v1.SearchResult
+	14, // 29: inventory.v1.WatchPartsRequest.filter:type_name -> inventory.v1.PartsFilter
+	5,  // 30: inventory.v1.PartEvent.type:type_name -> inventory.v1.PartEventType
+	9,  // 31: inventory.v1.PartEvent.part:type_name -> inventory.v1.Part
+	44, // 32: inventory.v1.PartEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	25, // 33: inventory.v1.Reservation.items:type_name -> inventory.v1.ReservationItem
+	1,  // 34: inventory.v1.Reservation.status:type_name -> inventory.v1.ReservationStatus
+	44, // 35: inventory.v1.Reservation.created_at:type_name -> google.protobuf.Timestamp
+	44, // 36: inventory.v1.Reservation.expires_at:type_name -> google.protobuf.Timestamp
+	25, // 37: inventory.v1.ReservePartsRequest.items:type_name -> inventory.v1.ReservationItem
+	26, // 38: inventory.v1.ReservePartsResponse.reservation:type_name -> inventory.v1.Reservation
+	26, // 39: inventory.v1.CommitReservationResponse.reservation:type_name -> inventory.v1.Reservation
+	26, // 40: inventory.v1.ReleaseReservationResponse.reservation:type_name -> inventory.v1.Reservation
+	26, // 41: inventory.v1.ReturnReservationResponse.reservation:type_name -> inventory.v1.Reservation
+	9,  // 42: inventory.v1.CreatePartRequest.part:type_name -> inventory.v1.Part
+	9,  // 43: inventory.v1.CreatePartResponse.part:type_name -> inventory.v1.Part
+	9,  // 44: inventory.v1.UpdatePartRequest.part:type_name -> inventory.v1.Part
+	45, // 45: inventory.v1.UpdatePartRequest.update_mask:type_name -> google.protobuf.FieldMask
+	9,  // 46: inventory.v1.UpdatePartResponse.part:type_name -> inventory.v1.Part
+	9,  // 47: inventory.v1.AdjustStockResponse.part:type_name -> inventory.v1.Part
+	8,  // 48: inventory.v1.Part.MetadataEntry.value:type_name -> inventory.v1.Value
+	15, // 49: inventory.v1.InventoryService.GetPart:input_type -> inventory.v1.GetPartRequest
+	17, // 50: inventory.v1.InventoryService.ListParts:input_type -> inventory.v1.ListPartsRequest
+	27, // 51: inventory.v1.InventoryService.ReserveParts:input_type -> inventory.v1.ReservePartsRequest
+	29, // 52: inventory.v1.InventoryService.CommitReservation:input_type -> inventory.v1.CommitReservationRequest
+	31, // 53: inventory.v1.InventoryService.ReleaseReservation:input_type -> inventory.v1.ReleaseReservationRequest
+	33, // 54: inventory.v1.InventoryService.ReturnReservation:input_type -> inventory.v1.ReturnReservationRequest
+	35, // 55: inventory.v1.InventoryService.CreatePart:input_type -> inventory.v1.CreatePartRequest
+	37, // 56: inventory.v1.InventoryService.UpdatePart:input_type -> inventory.v1.UpdatePartRequest
+	39, // 57: inventory.v1.InventoryService.DeletePart:input_type -> inventory.v1.DeletePartRequest
+	41, // 58: inventory.v1.InventoryService.AdjustStock:input_type -> inventory.v1.AdjustStockRequest
+	19, // 59: inventory.v1.InventoryService.SearchParts:input_type -> inventory.v1.SearchPartsRequest
+	23, // 60: inventory.v1.InventoryService.WatchParts:input_type -> inventory.v1.WatchPartsRequest
+	16, // 61: inventory.v1.InventoryService.GetPart:output_type -> inventory.v1.GetPartResponse
+	18, // 62: inventory.v1.InventoryService.ListParts:output_type -> inventory.v1.ListPartsResponse
+	28, // 63: inventory.v1.InventoryService.ReserveParts:output_type -> inventory.v1.ReservePartsResponse
+	30, // 64: inventory.v1.InventoryService.CommitReservation:output_type -> inventory.v1.CommitReservationResponse
+	32, // 65: inventory.v1.InventoryService.ReleaseReservation:output_type -> inventory.v1.ReleaseReservationResponse
+	34, // 66: inventory.v1.InventoryService.ReturnReservation:output_type -> inventory.v1.ReturnReservationResponse
+	36, // 67: inventory.v1.InventoryService.CreatePart:output_type -> inventory.v1.CreatePartResponse
+	38, // 68: inventory.v1.InventoryService.UpdatePart:output_type -> inventory.v1.UpdatePartResponse
+	40, // 69: inventory.v1.InventoryService.DeletePart:output_type -> inventory.v1.DeletePartResponse
+	42, // 70: inventory.v1.InventoryService.AdjustStock:output_type -> inventory.v1.AdjustStockResponse
+	22, // 71: inventory.v1.InventoryService.SearchParts:output_type -> inventory.v1.SearchPartsResponse
+	24, // 72: inventory.v1.InventoryService.WatchParts:output_type -> inventory.v1.PartEvent
+	61, // [61:73] is the sub-list for method output_type
+	49, // [49:61] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_inventory_v1_inventory_proto_init() }
@@ -886,13 +3139,15 @@ func file_inventory_v1_inventory_proto_init() {
 		(*Value_DoubleValue)(nil),
 		(*Value_BoolValue)(nil),
 	}
+	file_inventory_v1_inventory_proto_msgTypes[4].OneofWrappers = []any{}
+	file_inventory_v1_inventory_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_v1_inventory_proto_rawDesc), len(file_inventory_v1_inventory_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   10,
+			NumEnums:      6,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
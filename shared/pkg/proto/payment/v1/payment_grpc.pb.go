@@ -21,7 +21,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PaymentService_PayOrder_FullMethodName = "/payment.v1.PaymentService/PayOrder"
+	PaymentService_PayOrder_FullMethodName        = "/payment.v1.PaymentService/PayOrder"
+	PaymentService_RefundPayment_FullMethodName   = "/payment.v1.PaymentService/RefundPayment"
+	PaymentService_GetOrderPayment_FullMethodName = "/payment.v1.PaymentService/GetOrderPayment"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -30,8 +32,22 @@ const (
 //
 // PaymentService представляет API для работы с оплатой заказов
 type PaymentServiceClient interface {
-	// PayOrder производит оплату и возвращает uuid транзакции
+	// PayOrder производит оплату и возвращает uuid транзакции.
+	// Запрос идемпотентен по order_uuid: повтор для оплаченного заказа возвращает ту же транзакцию
+	// без повторного списания, повтор с другими параметрами оплаты завершается ошибкой ALREADY_EXISTS,
+	// а повтор во время обработки первого запроса — ошибкой ABORTED. При таймауте платежного провайдера
+	// запрос завершается ошибкой DEADLINE_EXCEEDED и транзакция остается в ожидании: повтор отправляет
+	// провайдеру списание той же транзакции, которое провайдер не проводит дважды
 	PayOrder(ctx context.Context, in *PayOrderRequest, opts ...grpc.CallOption) (*PayOrderResponse, error)
+	// RefundPayment возвращает средства по успешной транзакции полностью или частично.
+	// Сумма всех возвратов не может превышать сумму транзакции. Запрос идемпотентен по refund_uuid:
+	// повтор проведенного возврата возвращает его без повторного зачисления, повтор с другими
+	// параметрами завершается ошибкой ALREADY_EXISTS, а повтор во время обработки — ошибкой ABORTED
+	RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error)
+	// GetOrderPayment возвращает ожидающую или успешную транзакцию заказа, по ней клиент выясняет результат
+	// оплаты, ответ на которую не получен. Если такой транзакции нет, средства по заказу не списаны
+	// и запрос завершается ошибкой NOT_FOUND
+	GetOrderPayment(ctx context.Context, in *GetOrderPaymentRequest, opts ...grpc.CallOption) (*GetOrderPaymentResponse, error)
 }
 
 type paymentServiceClient struct {
@@ -52,14 +68,48 @@ func (c *paymentServiceClient) PayOrder(ctx context.Context, in *PayOrderRequest
 	return out, nil
 }
 
+func (c *paymentServiceClient) RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundPaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_RefundPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetOrderPayment(ctx context.Context, in *GetOrderPaymentRequest, opts ...grpc.CallOption) (*GetOrderPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderPaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetOrderPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 // All implementations must embed UnimplementedPaymentServiceServer
 // for forward compatibility.
 //
 // PaymentService представляет API для работы с оплатой заказов
 type PaymentServiceServer interface {
-	// PayOrder производит оплату и возвращает uuid транзакции
+	// PayOrder производит оплату и возвращает uuid транзакции.
+	// Запрос идемпотентен по order_uuid: повтор для оплаченного заказа возвращает ту же транзакцию
+	// без повторного списания, повтор с другими параметрами оплаты завершается ошибкой ALREADY_EXISTS,
+	// а повтор во время обработки первого запроса — ошибкой ABORTED. При таймауте платежного провайдера
+	// запрос завершается ошибкой DEADLINE_EXCEEDED и транзакция остается в ожидании: повтор отправляет
+	// провайдеру списание той же транзакции, которое провайдер не проводит дважды
 	PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error)
+	// RefundPayment возвращает средства по успешной транзакции полностью или частично.
+	// Сумма всех возвратов не может превышать сумму транзакции. Запрос идемпотентен по refund_uuid:
+	// повтор проведенного возврата возвращает его без повторного зачисления, повтор с другими
+	// параметрами завершается ошибкой ALREADY_EXISTS, а повтор во время обработки — ошибкой ABORTED
+	RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error)
+	// GetOrderPayment возвращает ожидающую или успешную транзакцию заказа, по ней клиент выясняет результат
+	// оплаты, ответ на которую не получен. Если такой транзакции нет, средства по заказу не списаны
+	// и запрос завершается ошибкой NOT_FOUND
+	GetOrderPayment(context.Context, *GetOrderPaymentRequest) (*GetOrderPaymentResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
 
@@ -73,6 +123,12 @@ type UnimplementedPaymentServiceServer struct{}
 func (UnimplementedPaymentServiceServer) PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PayOrder not implemented")
 }
+func (UnimplementedPaymentServiceServer) RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundPayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetOrderPayment(context.Context, *GetOrderPaymentRequest) (*GetOrderPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderPayment not implemented")
+}
 func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
 func (UnimplementedPaymentServiceServer) testEmbeddedByValue()                        {}
 
@@ -112,6 +168,42 @@ func _PaymentService_PayOrder_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_RefundPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RefundPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_RefundPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RefundPayment(ctx, req.(*RefundPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetOrderPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetOrderPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetOrderPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetOrderPayment(ctx, req.(*GetOrderPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -123,6 +215,14 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PayOrder",
 			Handler:    _PaymentService_PayOrder_Handler,
 		},
+		{
+			MethodName: "RefundPayment",
+			Handler:    _PaymentService_RefundPayment_Handler,
+		},
+		{
+			MethodName: "GetOrderPayment",
+			Handler:    _PaymentService_GetOrderPayment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "payment/v1/payment.proto",